@@ -0,0 +1,318 @@
+package datacache
+
+import (
+	"container/list"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/lynkdb/lynkapi/go/lynkapi"
+)
+
+const (
+	defaultTTL        = 60 * time.Second
+	defaultMaxEntries = 1000
+)
+
+// TTL is the max age of a cached result.
+type TTL time.Duration
+
+// MaxEntries is the max number of cached results, the least recently used
+// ones are evicted first.
+type MaxEntries int
+
+// Service is a read-through cache of a DataService: query results are cached
+// by the normalized query, and the results of a table are dropped on every
+// write to it through the Service. Writes which bypass the Service are seen
+// once the cached results expire.
+type Service struct {
+	lynkapi.DataService
+
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]*list.Element
+	tables     map[string]map[string]bool
+	gens       map[string]uint64 // table generation, bumped by Invalidate
+	lru        *list.List
+
+	hits      atomic.Int64
+	misses    atomic.Int64
+	evictions atomic.Int64
+}
+
+type entry struct {
+	key     string
+	table   string
+	created time.Time
+	result  *lynkapi.DataResult
+}
+
+// Stats are the counters of the cache. The queries with explain bypass the
+// cache, they are sent to the backend without counting a hit or a miss.
+type Stats struct {
+	Hits      int64 `json:"hits"`
+	Misses    int64 `json:"misses"`
+	Evictions int64 `json:"evictions"`
+	Entries   int   `json:"entries"`
+}
+
+func NewService(ds lynkapi.DataService, args ...any) *Service {
+
+	it := &Service{
+		DataService: ds,
+		ttl:         defaultTTL,
+		maxEntries:  defaultMaxEntries,
+		entries:     map[string]*list.Element{},
+		tables:      map[string]map[string]bool{},
+		gens:        map[string]uint64{},
+		lru:         list.New(),
+	}
+
+	for _, arg := range args {
+		if arg == nil {
+			continue
+		}
+		switch arg.(type) {
+		case TTL:
+			if v := time.Duration(arg.(TTL)); v > 0 {
+				it.ttl = v
+			}
+		case MaxEntries:
+			if v := int(arg.(MaxEntries)); v > 0 {
+				it.maxEntries = v
+			}
+		}
+	}
+
+	return it
+}
+
+func (it *Service) Stats() Stats {
+	it.mu.Lock()
+	n := len(it.entries)
+	it.mu.Unlock()
+	return Stats{
+		Hits:      it.hits.Load(),
+		Misses:    it.misses.Load(),
+		Evictions: it.evictions.Load(),
+		Entries:   n,
+	}
+}
+
+// queryKey returns the key of the query, the fields and the conditions of
+// an and-filter are sorted so the same query in any order has the same key.
+func queryKey(q *lynkapi.DataQuery) string {
+
+	q = proto.Clone(q).(*lynkapi.DataQuery)
+
+	sort.Strings(q.Fields)
+	q.Filter = filterNormalize(q.Filter)
+
+	b, _ := proto.MarshalOptions{Deterministic: true}.Marshal(q)
+	return string(b)
+}
+
+func filterNormalize(fr *lynkapi.DataQuery_Filter) *lynkapi.DataQuery_Filter {
+
+	if fr == nil {
+		return nil
+	}
+
+	if fr.Field != "" && len(fr.Inner) == 0 && (fr.Type == "" || fr.Type == "and") {
+		return &lynkapi.DataQuery_Filter{
+			Inner: []*lynkapi.DataQuery_Filter{fr},
+		}
+	}
+
+	for i, sub := range fr.Inner {
+		if fr.Type == "" || fr.Type == "and" {
+			if sub.Field == "" {
+				fr.Inner[i] = filterNormalize(sub)
+			}
+		}
+	}
+
+	if fr.Field == "" && (fr.Type == "" || fr.Type == "and") {
+		keys := make([]string, len(fr.Inner))
+		for i, sub := range fr.Inner {
+			b, _ := proto.MarshalOptions{Deterministic: true}.Marshal(sub)
+			keys[i] = string(b)
+		}
+		sort.Sort(filterSorter{keys: keys, filters: fr.Inner})
+	}
+
+	return fr
+}
+
+type filterSorter struct {
+	keys    []string
+	filters []*lynkapi.DataQuery_Filter
+}
+
+func (it filterSorter) Len() int           { return len(it.keys) }
+func (it filterSorter) Less(i, j int) bool { return it.keys[i] < it.keys[j] }
+func (it filterSorter) Swap(i, j int) {
+	it.keys[i], it.keys[j] = it.keys[j], it.keys[i]
+	it.filters[i], it.filters[j] = it.filters[j], it.filters[i]
+}
+
+func (it *Service) Query(q *lynkapi.DataQuery) (*lynkapi.DataResult, error) {
+
+	// the plan of a cached result would not be the one of the query
+	if q.Explain {
+		return it.DataService.Query(q)
+	}
+
+	key := queryKey(q)
+
+	it.mu.Lock()
+	if elem, ok := it.entries[key]; ok {
+		e := elem.Value.(*entry)
+		if time.Since(e.created) < it.ttl {
+			it.lru.MoveToFront(elem)
+			rs := proto.Clone(e.result).(*lynkapi.DataResult)
+			it.mu.Unlock()
+			it.hits.Add(1)
+			return rs, nil
+		}
+		it.remove(elem)
+	}
+	gen := it.gens[q.TableName]
+	it.mu.Unlock()
+
+	it.misses.Add(1)
+
+	rs, err := it.DataService.Query(q)
+	if err != nil || rs == nil {
+		return rs, err
+	}
+
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	// a write invalidated the table while the query ran, its result may
+	// be the one before the write
+	if it.gens[q.TableName] != gen {
+		return rs, nil
+	}
+
+	if elem, ok := it.entries[key]; ok {
+		it.remove(elem)
+	}
+
+	e := &entry{
+		key:     key,
+		table:   q.TableName,
+		created: time.Now(),
+		result:  proto.Clone(rs).(*lynkapi.DataResult),
+	}
+	it.entries[key] = it.lru.PushFront(e)
+	if it.tables[e.table] == nil {
+		it.tables[e.table] = map[string]bool{}
+	}
+	it.tables[e.table][key] = true
+
+	for it.lru.Len() > it.maxEntries {
+		it.remove(it.lru.Back())
+		it.evictions.Add(1)
+	}
+
+	return rs, nil
+}
+
+// remove drops a cached result, it must be called with the lock held.
+func (it *Service) remove(elem *list.Element) {
+	e := elem.Value.(*entry)
+	it.lru.Remove(elem)
+	delete(it.entries, e.key)
+	if keys, ok := it.tables[e.table]; ok {
+		delete(keys, e.key)
+		if len(keys) == 0 {
+			delete(it.tables, e.table)
+		}
+	}
+}
+
+// Invalidate drops the cached results of a table, and the results of the
+// queries of the table still running.
+func (it *Service) Invalidate(tableName string) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	it.gens[tableName] += 1
+	for key := range it.tables[tableName] {
+		if elem, ok := it.entries[key]; ok {
+			it.remove(elem)
+		}
+	}
+}
+
+func (it *Service) Upsert(q *lynkapi.DataInsert) (*lynkapi.DataResult, error) {
+	if !q.DryRun {
+		defer it.Invalidate(q.TableName)
+	}
+	return it.DataService.Upsert(q)
+}
+
+func (it *Service) Igsert(q *lynkapi.DataInsert) (*lynkapi.DataResult, error) {
+	if !q.DryRun {
+		defer it.Invalidate(q.TableName)
+	}
+	return it.DataService.Igsert(q)
+}
+
+func (it *Service) Delete(q *lynkapi.DataDelete) (*lynkapi.DataResult, error) {
+	if !q.DryRun {
+		defer it.Invalidate(q.TableName)
+	}
+	return it.DataService.Delete(q)
+}
+
+func (it *Service) Update(q *lynkapi.DataInsert) (*lynkapi.DataResult, error) {
+	us, ok := it.DataService.(lynkapi.DataUpdateService)
+	if !ok {
+		return nil, lynkapi.NewNotImplementedError("instance update not supported")
+	}
+	if !q.DryRun {
+		defer it.Invalidate(q.TableName)
+	}
+	return us.Update(q)
+}
+
+// DryRun reports whether the underlying service accepts the writes with
+// dry_run, which keep the cached results.
+func (it *Service) DryRun() bool {
+	ds, ok := it.DataService.(lynkapi.DataDryRunService)
+	return ok && ds.DryRun()
+}
+
+// Columnar reports whether the underlying service returns the columns of a
+// columnar query.
+func (it *Service) Columnar() bool {
+	ds, ok := it.DataService.(lynkapi.DataColumnarService)
+	return ok && ds.Columnar()
+}
+
+func (it *Service) Restore(q *lynkapi.DataRestore) (*lynkapi.DataResult, error) {
+	hs, ok := it.DataService.(lynkapi.DataHistoryService)
+	if !ok {
+		return nil, lynkapi.NewNotImplementedError("instance history not supported")
+	}
+	if !q.DryRun {
+		defer it.Invalidate(q.TableName)
+	}
+	return hs.Restore(q)
+}
+
+// Snapshot reads a snapshot of the underlying service, the queries of the
+// snapshot are not cached.
+func (it *Service) Snapshot() (lynkapi.DataSnapshot, error) {
+	ss, ok := it.DataService.(lynkapi.DataSnapshotService)
+	if !ok {
+		return nil, lynkapi.NewNotImplementedError("instance snapshot not supported")
+	}
+	return ss.Snapshot()
+}
@@ -0,0 +1,239 @@
+package datacache_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lynkdb/lynkapi/go/datacache"
+	"github.com/lynkdb/lynkapi/go/lynkapi"
+	"github.com/lynkdb/lynkapi/go/oneobject"
+)
+
+type Item struct {
+	Name  string `json:"name" x_attrs:"primary_key"`
+	Group string `json:"group"`
+	Value string `json:"value"`
+}
+
+type Object struct {
+	Items  []*Item `json:"items"`
+	Others []*Item `json:"others"`
+}
+
+func Test_Service(t *testing.T) {
+
+	inst, err := oneobject.NewInstance("test", &Object{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"items", "others"} {
+		if err := inst.TableSetup(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ds := datacache.NewService(inst, datacache.TTL(200*time.Millisecond), datacache.MaxEntries(2))
+
+	upsert := func(table, name, group, value string) {
+		req := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    table,
+		}
+		req.SetField("name", name)
+		req.SetField("group", group)
+		req.SetField("value", value)
+		if _, err := ds.Upsert(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	query := func(table string, kvs ...string) *lynkapi.DataResult {
+		q := &lynkapi.DataQuery{
+			InstanceName: "test",
+			TableName:    table,
+		}
+		for i := 0; i+1 < len(kvs); i += 2 {
+			q.AddFilter(kvs[i], kvs[i+1])
+		}
+		rs, err := ds.Query(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rs
+	}
+
+	upsert("items", "a", "g1", "1")
+	upsert("others", "x", "g1", "1")
+
+	stats := func(hits, misses int64) {
+		if st := ds.Stats(); st.Hits != hits || st.Misses != misses {
+			t.Helper()
+			t.Fatalf("stats hits %d misses %d, expected %d %d", st.Hits, st.Misses, hits, misses)
+		}
+	}
+
+	// filters in any order have the same key
+	query("items", "group", "g1", "name", "a")
+	rs := query("items", "name", "a", "group", "g1")
+	stats(1, 1)
+	if len(rs.Rows) != 1 || rs.Rows[0].Fields["value"].GetStringValue() != "1" {
+		t.Fatalf("invalid rows %v", rs.Rows)
+	}
+
+	// a cached result is not changed by the caller
+	delete(rs.Rows[0].Fields, "value")
+	if rs := query("items", "name", "a", "group", "g1"); rs.Rows[0].Fields["value"] == nil {
+		t.Fatal("cached result modified")
+	}
+	stats(2, 1)
+
+	// writes drop the results of the table only
+	query("others")
+	upsert("items", "a", "g1", "2")
+	query("others")
+	stats(3, 2)
+	if rs := query("items", "group", "g1", "name", "a"); rs.Rows[0].Fields["value"].GetStringValue() != "2" {
+		t.Fatalf("stale result %v", rs.Rows[0])
+	}
+	stats(3, 3)
+
+	// size limit
+	query("items", "name", "b")
+	if st := ds.Stats(); st.Entries != 2 || st.Evictions != 1 {
+		t.Fatalf("invalid stats %+v", st)
+	}
+
+	// ttl
+	query("items", "name", "b")
+	stats(4, 4)
+	time.Sleep(250 * time.Millisecond)
+	query("items", "name", "b")
+	stats(4, 5)
+}
+
+// blockingService holds a query, once read from the backend, until released.
+type blockingService struct {
+	lynkapi.DataService
+	read    chan bool
+	release chan bool
+}
+
+func (it *blockingService) Query(q *lynkapi.DataQuery) (*lynkapi.DataResult, error) {
+	rs, err := it.DataService.Query(q)
+	if it.read != nil {
+		it.read <- true
+		<-it.release
+	}
+	return rs, err
+}
+
+func Test_Service_QueryWrite(t *testing.T) {
+
+	inst, err := oneobject.NewInstance("test", &Object{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("items"); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		bs = &blockingService{DataService: inst}
+		ds = datacache.NewService(bs)
+	)
+
+	upsert := func(value string) {
+		req := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    "items",
+		}
+		req.SetField("name", "a")
+		req.SetField("value", value)
+		if _, err := ds.Upsert(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	query := func() string {
+		rs, err := ds.Query(&lynkapi.DataQuery{
+			InstanceName: "test",
+			TableName:    "items",
+		})
+		if err != nil || len(rs.Rows) != 1 {
+			t.Errorf("invalid result %v %v", rs, err)
+			return ""
+		}
+		return rs.Rows[0].Fields["value"].GetStringValue()
+	}
+
+	upsert("1")
+
+	bs.read, bs.release = make(chan bool), make(chan bool)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if v := query(); v != "1" {
+			t.Errorf("invalid value %s", v)
+		}
+	}()
+
+	// the write lands between the backend read and the cache store
+	<-bs.read
+	upsert("2")
+	bs.release <- true
+	wg.Wait()
+
+	bs.read = nil
+	if v := query(); v != "2" {
+		t.Fatalf("stale result cached (value %s)", v)
+	}
+}
+
+func Test_Service_Snapshot(t *testing.T) {
+
+	inst, err := oneobject.NewInstance("test", &Object{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("items"); err != nil {
+		t.Fatal(err)
+	}
+
+	ds := datacache.NewService(inst)
+
+	upsert := func(name string) {
+		req := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    "items",
+		}
+		req.SetField("name", name)
+		if _, err := ds.Upsert(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	upsert("a")
+
+	snap, err := ds.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upsert("b")
+
+	rs, err := snap.Query(&lynkapi.DataQuery{
+		InstanceName: "test",
+		TableName:    "items",
+	})
+	if err != nil || len(rs.Rows) != 1 {
+		t.Fatalf("invalid snapshot result %v %v", rs, err)
+	}
+
+	// a driver without snapshots
+	if _, err := datacache.NewService(&blockingService{DataService: inst}).Snapshot(); lynkapi.ParseError(err).Code != lynkapi.StatusCode_NotImplemented {
+		t.Fatalf("snapshot of a driver without snapshots: %v", err)
+	}
+}
@@ -0,0 +1,512 @@
+package datadict
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/lynkdb/lynkapi/go/codec"
+	"github.com/lynkdb/lynkapi/go/lynkapi"
+)
+
+const (
+	TableName = "dict"
+
+	defaultLimit = 10
+)
+
+// Service is a ready-made dictionary DataService, items are grouped by
+// namespace (ns), a name is unique in its namespace and items can be nested
+// by pid. Writes replace the item list (copy-on-write), reads are served
+// from a per-namespace cache ordered by (order, name).
+type Service struct {
+	mu      sync.RWMutex
+	name    string
+	spec    *lynkapi.TableSpec
+	items   []*lynkapi.DataDict
+	cache   map[string]*nsCache
+	flusher Flusher
+}
+
+type Flusher func(items []*lynkapi.DataDict) error
+
+type nsCache struct {
+	items []*lynkapi.DataDict
+	names map[string]*lynkapi.DataDict
+}
+
+type dictObject struct {
+	Items []*lynkapi.DataDict `json:"items"`
+}
+
+func NewService(name string, items []*lynkapi.DataDict, args ...any) (*Service, error) {
+
+	if !lynkapi.NameIdentifier.MatchString(name) {
+		return nil, errors.New("invalid name")
+	}
+
+	spec, _, err := lynkapi.NewSpecFromStruct(&lynkapi.DataDict{})
+	if err != nil {
+		return nil, err
+	}
+
+	tableSpec := spec.TableSpec()
+	tableSpec.Name = TableName
+
+	it := &Service{
+		name:  name,
+		spec:  tableSpec,
+		items: items,
+	}
+
+	for _, arg := range args {
+		if arg == nil {
+			continue
+		}
+		switch arg.(type) {
+		case Flusher:
+			it.flusher = arg.(Flusher)
+		}
+	}
+
+	return it, nil
+}
+
+func NewServiceFromFile(name, file string, args ...any) (*Service, error) {
+
+	var obj dictObject
+
+	b, err := ioutil.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if err == nil {
+		if err = codec.Json.Decode(b, &obj); err != nil {
+			return nil, err
+		}
+	}
+
+	args = append(args, Flusher(func(items []*lynkapi.DataDict) error {
+		b, _ := codec.Json.Encode(&dictObject{
+			Items: items,
+		}, &codec.JsonOptions{
+			Width: 120,
+		})
+		return ioutil.WriteFile(file, b, 0640)
+	}))
+
+	return NewService(name, obj.Items, args...)
+}
+
+func (it *Service) Instance() *lynkapi.DataInstance {
+	return &lynkapi.DataInstance{
+		Name: it.name,
+		Spec: &lynkapi.DataSpec{
+			Driver: "datadict",
+			Tables: []*lynkapi.TableSpec{it.spec},
+		},
+	}
+}
+
+func (it *Service) namespace(ns string) *nsCache {
+
+	it.mu.RLock()
+	c, ok := it.cache[ns]
+	it.mu.RUnlock()
+	if ok {
+		return c
+	}
+
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	if c, ok = it.cache[ns]; ok {
+		return c
+	}
+
+	c = &nsCache{
+		names: map[string]*lynkapi.DataDict{},
+	}
+	for _, item := range it.items {
+		if item.Ns == ns {
+			c.items = append(c.items, item)
+			c.names[item.Name] = item
+		}
+	}
+	sortItems(c.items)
+
+	if it.cache == nil {
+		it.cache = map[string]*nsCache{}
+	}
+	it.cache[ns] = c
+
+	return c
+}
+
+func sortItems(items []*lynkapi.DataDict) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Ns != items[j].Ns {
+			return items[i].Ns < items[j].Ns
+		}
+		if items[i].Order != items[j].Order {
+			return items[i].Order < items[j].Order
+		}
+		return items[i].Name < items[j].Name
+	})
+}
+
+// DictItem returns the item named name in the namespace ns, the item is
+// shared by the cache and must not be modified.
+func (it *Service) DictItem(ns, name string) *lynkapi.DataDict {
+	return it.namespace(ns).names[name]
+}
+
+// Items returns the ordered items of the namespace ns.
+func (it *Service) Items(ns string) []*lynkapi.DataDict {
+	return it.namespace(ns).items
+}
+
+// Children returns the ordered items of the namespace ns with the parent pid,
+// or the root items if pid is empty.
+func (it *Service) Children(ns, pid string) []*lynkapi.DataDict {
+	var items []*lynkapi.DataDict
+	for _, item := range it.namespace(ns).items {
+		if item.Pid == pid {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// DisplayName returns the display name of the value name in the namespace ns,
+// or the name itself if not found.
+func (it *Service) DisplayName(ns, name string) string {
+	if item := it.DictItem(ns, name); item != nil {
+		return item.Title()
+	}
+	return name
+}
+
+func queryFilters(fr *lynkapi.DataQuery_Filter, filters map[string]string) error {
+	if fr == nil {
+		return nil
+	}
+	switch fr.Type {
+	case "", "and":
+	default:
+		return lynkapi.NewBadRequestError(fmt.Sprintf("filter type (%s) not supported", fr.Type))
+	}
+	if fr.Op != "" && fr.Op != lynkapi.DataQuery_Filter_Eq {
+		return lynkapi.NewBadRequestError(fmt.Sprintf("filter op (%s) not supported", fr.Op))
+	}
+	if fr.Field != "" {
+		switch fr.Field {
+		case "id", "pid", "ns", "name", "ref_table":
+			filters[fr.Field] = fr.Value.GetStringValue()
+		default:
+			return errors.New("filter/field not found")
+		}
+	}
+	for _, sub := range fr.Inner {
+		if err := queryFilters(sub, filters); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func itemMatch(item *lynkapi.DataDict, filters map[string]string) bool {
+	for field, value := range filters {
+		var v string
+		switch field {
+		case "id":
+			v = item.Id
+		case "pid":
+			v = item.Pid
+		case "ns":
+			v = item.Ns
+		case "name":
+			v = item.Name
+		case "ref_table":
+			v = item.RefTable
+		}
+		if v != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (it *Service) Query(q *lynkapi.DataQuery) (*lynkapi.DataResult, error) {
+
+	if q.TableName != TableName {
+		return nil, errors.New("table not found")
+	}
+
+	filters := map[string]string{}
+	if err := queryFilters(q.Filter, filters); err != nil {
+		return nil, err
+	}
+
+	limit := int(q.Limit)
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	var items []*lynkapi.DataDict
+	if ns, ok := filters["ns"]; ok {
+		items = it.namespace(ns).items
+	} else {
+		it.mu.RLock()
+		items = append(items, it.items...)
+		it.mu.RUnlock()
+		sortItems(items)
+	}
+
+	rs := &lynkapi.DataResult{
+		Spec: it.spec,
+	}
+
+	offset := int(q.Offset)
+	for _, item := range items {
+		if !itemMatch(item, filters) {
+			continue
+		}
+		if offset > 0 {
+			offset -= 1
+			continue
+		}
+		fields, err := lynkapi.ConvertReflectValueToMapValue(reflect.ValueOf(item))
+		if err != nil {
+			continue
+		}
+		rs.Rows = append(rs.Rows, &lynkapi.DataRow{
+			Id:     item.Id,
+			Fields: fields,
+		})
+		if len(rs.Rows) >= limit {
+			break
+		}
+	}
+
+	if len(rs.Rows) == 0 {
+		rs.Status = lynkapi.NewServiceStatus(lynkapi.StatusCode_NotFound, "")
+	} else {
+		rs.Status = lynkapi.NewServiceStatusOK()
+	}
+
+	return rs, nil
+}
+
+const (
+	kInsertRaw int = iota + 1
+	kInsertIgsert
+	kInsertUpsert
+	kInsertUpdate
+)
+
+func (it *Service) Insert(q *lynkapi.DataInsert) (*lynkapi.DataResult, error) {
+	return it.insert(q, kInsertRaw)
+}
+
+func (it *Service) Igsert(q *lynkapi.DataInsert) (*lynkapi.DataResult, error) {
+	return it.insert(q, kInsertIgsert)
+}
+
+func (it *Service) Upsert(q *lynkapi.DataInsert) (*lynkapi.DataResult, error) {
+	return it.insert(q, kInsertUpsert)
+}
+
+// Update writes the item only if it exists, it never creates an item.
+func (it *Service) Update(q *lynkapi.DataInsert) (*lynkapi.DataResult, error) {
+	return it.insert(q, kInsertUpdate)
+}
+
+func (it *Service) insert(q *lynkapi.DataInsert, typ int) (*lynkapi.DataResult, error) {
+
+	if q.TableName != TableName {
+		return nil, errors.New("table not found")
+	}
+
+	if len(q.Fields) == 0 || len(q.Fields) != len(q.Values) {
+		return nil, errors.New("invalid request (fields != values)")
+	}
+
+	data := map[string]*structpb.Value{}
+	for i, name := range q.Fields {
+		data[name] = q.Values[i]
+	}
+	js, _ := codec.Json.Encode(data)
+
+	var req lynkapi.DataDict
+	if err := codec.Json.Decode(js, &req); err != nil {
+		return nil, err
+	}
+
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	pos := -1
+	for i, item := range it.items {
+		if (req.Id != "" && item.Id == req.Id) ||
+			(req.Id == "" && item.Ns == req.Ns && item.Name == req.Name) {
+			pos = i
+			break
+		}
+	}
+
+	var item *lynkapi.DataDict
+
+	if pos >= 0 {
+		switch typ {
+		case kInsertRaw:
+			return nil, lynkapi.NewConflictError("item exist")
+
+		case kInsertIgsert:
+			return &lynkapi.DataResult{
+				Status: lynkapi.NewServiceStatusOK(),
+			}, nil
+		}
+		item = proto.Clone(it.items[pos]).(*lynkapi.DataDict)
+		if err := codec.Json.Decode(js, item); err != nil {
+			return nil, err
+		}
+	} else {
+		if typ == kInsertUpdate {
+			return &lynkapi.DataResult{
+				Status: lynkapi.NewServiceStatus(lynkapi.StatusCode_NotFound, ""),
+			}, nil
+		}
+		item = &req
+		if item.Id == "" {
+			item.Id = lynkapi.RandHexString(8)
+		}
+	}
+
+	if err := it.itemCheck(item); err != nil {
+		return nil, err
+	}
+	item.Version += 1
+
+	var items []*lynkapi.DataDict
+	if pos >= 0 {
+		items = append(items, it.items...)
+		items[pos] = item
+	} else {
+		items = append(append(items, it.items...), item)
+	}
+
+	if err := it.commit(items); err != nil {
+		return nil, err
+	}
+
+	return &lynkapi.DataResult{
+		Status: lynkapi.NewServiceStatusOK(),
+	}, nil
+}
+
+// itemCheck validates a new or updated item, it must be called with the
+// write lock held.
+func (it *Service) itemCheck(item *lynkapi.DataDict) error {
+
+	if !lynkapi.NamespaceIdentifier.MatchString(item.Ns) {
+		return lynkapi.NewBadRequestError("invalid ns")
+	}
+
+	if item.Name == "" {
+		return lynkapi.NewBadRequestError("name not setup")
+	}
+
+	ids := map[string]*lynkapi.DataDict{}
+	for _, v := range it.items {
+		if v.Id == item.Id {
+			continue
+		}
+		if v.Ns == item.Ns && v.Name == item.Name {
+			return lynkapi.NewConflictError("name exist in ns " + item.Ns)
+		}
+		ids[v.Id] = v
+	}
+
+	for pid, n := item.Pid, 0; pid != ""; n++ {
+		if pid == item.Id || n > len(ids) {
+			return lynkapi.NewBadRequestError("pid cycle detected")
+		}
+		parent, ok := ids[pid]
+		if !ok {
+			return lynkapi.NewBadRequestError("parent item (" + pid + ") not found")
+		}
+		if parent.Ns != item.Ns {
+			return lynkapi.NewBadRequestError("parent item not in ns " + item.Ns)
+		}
+		pid = parent.Pid
+	}
+
+	return nil
+}
+
+func (it *Service) commit(items []*lynkapi.DataDict) error {
+	if it.flusher != nil {
+		if err := it.flusher(items); err != nil {
+			return err
+		}
+	}
+	it.items = items
+	it.cache = nil
+	return nil
+}
+
+func (it *Service) Delete(q *lynkapi.DataDelete) (*lynkapi.DataResult, error) {
+
+	if q.TableName != TableName {
+		return nil, errors.New("table not found")
+	}
+
+	filters := map[string]string{}
+	if err := queryFilters(q.Filter, filters); err != nil {
+		return nil, err
+	}
+
+	if filters["id"] == "" && (filters["ns"] == "" || filters["name"] == "") {
+		return nil, errors.New("filter (id or ns,name) not found")
+	}
+
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	pos := -1
+	for i, item := range it.items {
+		if itemMatch(item, filters) {
+			pos = i
+			break
+		}
+	}
+
+	if pos >= 0 {
+
+		for _, item := range it.items {
+			if item.Pid == it.items[pos].Id {
+				return nil, lynkapi.NewConflictError("item has children")
+			}
+		}
+
+		var items []*lynkapi.DataDict
+		items = append(append(items, it.items[:pos]...), it.items[pos+1:]...)
+
+		if err := it.commit(items); err != nil {
+			return nil, err
+		}
+	}
+
+	return &lynkapi.DataResult{
+		Status: lynkapi.NewServiceStatusOK(),
+	}, nil
+}
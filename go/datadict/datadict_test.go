@@ -0,0 +1,166 @@
+package datadict_test
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/lynkdb/lynkapi/go/datadict"
+	"github.com/lynkdb/lynkapi/go/lynkapi"
+	"github.com/lynkdb/lynkapi/go/oneobject"
+)
+
+func Test_Service(t *testing.T) {
+
+	dict, err := datadict.NewService("dict", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upsert := func(kvs ...string) error {
+		req := &lynkapi.DataInsert{
+			TableName: datadict.TableName,
+		}
+		for i := 0; i+1 < len(kvs); i += 2 {
+			req.SetField(kvs[i], kvs[i+1])
+		}
+		_, err := dict.Upsert(req)
+		return err
+	}
+
+	for _, kvs := range [][]string{
+		{"id", "a", "ns", "status", "name", "active", "display_name", "Active"},
+		{"id", "b", "ns", "status", "name", "archived"},
+		{"id", "c", "ns", "state", "name", "active"},
+		{"id", "d", "ns", "status", "name", "frozen", "pid", "b"},
+	} {
+		if err := upsert(kvs...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := upsert("id", "e", "ns", "status", "name", "active"); lynkapi.ParseError(err).Code != lynkapi.StatusCode_Conflict {
+		t.Fatalf("duplicate name in ns: %v", err)
+	}
+	if err := upsert("id", "b", "pid", "d"); lynkapi.ParseError(err).Code != lynkapi.StatusCode_BadRequest {
+		t.Fatalf("pid cycle: %v", err)
+	}
+
+	if items := dict.Items("status"); len(items) != 3 || items[0].Name != "active" {
+		t.Fatalf("invalid items %v", items)
+	}
+	if items := dict.Children("status", "b"); len(items) != 1 || items[0].Name != "frozen" {
+		t.Fatalf("invalid children %v", items)
+	}
+	if name := dict.DisplayName("status", "active"); name != "Active" {
+		t.Fatalf("invalid display name %s", name)
+	}
+
+	{ // query by pid
+		q := &lynkapi.DataQuery{
+			TableName: datadict.TableName,
+			Filter:    &lynkapi.DataQuery_Filter{},
+		}
+		q.Filter.And("ns", "status").And("pid", "")
+		rs, err := dict.Query(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rs.Rows) != 2 {
+			t.Fatalf("invalid hits %d", len(rs.Rows))
+		}
+	}
+
+	{ // filters other than the equality of fields
+		for _, fr := range []*lynkapi.DataQuery_Filter{
+			{Field: "ns", Op: lynkapi.DataQuery_Filter_Ne, Value: structpb.NewStringValue("status")},
+			{Type: "or", Inner: []*lynkapi.DataQuery_Filter{
+				{Field: "ns", Value: structpb.NewStringValue("status")},
+				{Field: "ns", Value: structpb.NewStringValue("state")},
+			}},
+		} {
+			_, err := dict.Query(&lynkapi.DataQuery{
+				TableName: datadict.TableName,
+				Filter:    fr,
+			})
+			if lynkapi.ParseError(err).Code != lynkapi.StatusCode_BadRequest {
+				t.Fatalf("filter %v not rejected: %v", fr, err)
+			}
+		}
+	}
+
+	{ // delete
+		del := &lynkapi.DataDelete{
+			TableName: datadict.TableName,
+			Filter:    &lynkapi.DataQuery_Filter{},
+		}
+		del.Filter.And("id", "b")
+		if _, err := dict.Delete(del); lynkapi.ParseError(err).Code != lynkapi.StatusCode_Conflict {
+			t.Fatalf("delete item with children: %v", err)
+		}
+	}
+}
+
+func Test_Service_DictField(t *testing.T) {
+
+	type Task struct {
+		Id     string `json:"id" x_attrs:"primary_key"`
+		Status string `json:"status" x_dict_ns:"status"`
+	}
+	type Object struct {
+		Tasks []*Task `json:"tasks"`
+	}
+
+	dict, err := datadict.NewService("dict", []*lynkapi.DataDict{
+		{Id: "a", Ns: "status", Name: "active", DisplayName: "Active"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inst, err := oneobject.NewInstance("test", &Object{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("tasks"); err != nil {
+		t.Fatal(err)
+	}
+
+	s := lynkapi.NewService()
+	for _, ds := range []lynkapi.DataService{dict, inst} {
+		if err := s.RegisterDataService(ds); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	upsert := func(status string) error {
+		req := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    "tasks",
+		}
+		req.SetField("id", "t1")
+		req.SetField("status", status)
+		_, err := s.DataUpsert(context.Background(), req)
+		return err
+	}
+
+	if err := upsert("unknown"); lynkapi.ParseError(err).Code != lynkapi.StatusCode_BadRequest {
+		t.Fatalf("value not in dictionary accepted: %v", err)
+	}
+	if err := upsert("active"); err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := s.DataQuery(context.Background(), &lynkapi.DataQuery{
+		InstanceName: "test",
+		TableName:    "tasks",
+		DictDisplay:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rs.Rows) != 1 || rs.Rows[0].DisplayNames["status"] != "Active" {
+		t.Fatalf("invalid display names %v", rs.Rows)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hooto/hauth/go/hauth/v1"
+)
+
+// AuthIdentity is the authenticated caller of a request.
+type AuthIdentity struct {
+	User  string
+	Roles []string
+}
+
+// AuthIdentityResolver returns the identity of the caller from the gRPC or
+// HTTP request context, or an error if the request is not authenticated.
+type AuthIdentityResolver func(ctx context.Context) (*AuthIdentity, error)
+
+type authIdentityKey struct{}
+
+func NewAuthIdentityContext(ctx context.Context, id *AuthIdentity) context.Context {
+	return context.WithValue(ctx, authIdentityKey{}, id)
+}
+
+func AuthIdentityFromContext(ctx context.Context) *AuthIdentity {
+	if ctx == nil {
+		return nil
+	}
+	if id, ok := ctx.Value(authIdentityKey{}).(*AuthIdentity); ok {
+		return id
+	}
+	return nil
+}
+
+// NewAccessKeyIdentityResolver validates the signed access token of the
+// caller (gRPC metadata or HTTP header) with the access keys of keyMgr.
+func NewAccessKeyIdentityResolver(keyMgr *hauth.AccessKeyManager) AuthIdentityResolver {
+	return func(ctx context.Context) (*AuthIdentity, error) {
+
+		var (
+			av  *hauth.AppValidator
+			err error
+		)
+
+		if r, body := httpRequestFromContext(ctx); r != nil {
+			av, err = hauth.AppValidWithHttpRequest(r, body, keyMgr)
+		} else if av, err = hauth.GrpcAppValidator(ctx, keyMgr); err == nil {
+			err = av.SignValid(nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if av.Key == nil {
+			return nil, errors.New("access_key not found")
+		}
+
+		id := &AuthIdentity{
+			User:  av.Key.User,
+			Roles: av.Key.Roles,
+		}
+		if id.User == "" {
+			id.User = av.AppPayload.User
+		}
+		return id, nil
+	}
+}
+
+func (it *LynkService) SetupAuthIdentityResolver(fn AuthIdentityResolver) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	it.authIdentityResolver = fn
+}
+
+// authIdentity returns the identity of the caller, nil if the request is not
+// authenticated.
+func (it *LynkService) authIdentity(ctx context.Context) *AuthIdentity {
+
+	if id := AuthIdentityFromContext(ctx); id != nil {
+		return id
+	}
+
+	it.mu.RLock()
+	fn := it.authIdentityResolver
+	it.mu.RUnlock()
+
+	if fn == nil || ctx == nil {
+		return nil
+	}
+
+	if id, err := fn(ctx); err == nil && id != nil && id.User != "" {
+		return id
+	}
+	return nil
+}
+
+// authOperator returns the user recorded as the operator of a write. The
+// client supplied DataInsert.operator (and the like) is never trusted, so an
+// unauthenticated request is stored without an operator.
+func (it *LynkService) authOperator(ctx context.Context) string {
+	if id := it.authIdentity(ctx); id != nil {
+		return id.User
+	}
+	return ""
+}
@@ -18,6 +18,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"slices"
 	"sync"
 	"time"
 
@@ -42,7 +44,14 @@ type Client interface {
 	//
 	DataProject(req *DataProjectRequest) *DataProjectResponse
 	DataQuery(req *DataQuery) *DataResult
+	DataQueryStream(req *DataQuery) *DataQueryIterator
 	DataUpsert(req *DataInsert) *DataResult
+	DataIgsert(req *DataInsert) *DataResult
+	DataUpdate(req *DataUpdate) *DataResult
+	DataDelete(req *DataDelete) *DataResult
+	DataRestore(req *DataRestore) *DataResult
+	DataBackup(req *DataBackupRequest, w io.Writer) *DataBackupReport
+	DataBackupRestore(req *DataBackupRequest, r io.Reader) *DataBackupReport
 }
 
 type ClientConfig struct {
@@ -140,13 +149,8 @@ func (it *clientImpl) ApiList(req *ApiListRequest) *ApiListResponse {
 
 	rs, err := it.rpcClient.ApiList(ctx, req)
 	if err != nil {
-		if status, ok := status.FromError(err); ok && len(status.Message()) > 5 {
-			return &ApiListResponse{
-				Status: ParseError(errors.New(status.Message())),
-			}
-		}
 		return &ApiListResponse{
-			Status: ParseError(err),
+			Status: clientStatus(err),
 		}
 	}
 	if rs.Status == nil {
@@ -170,13 +174,8 @@ func (it *clientImpl) Exec(req *Request) *Response {
 
 		rs, err := it.rpcClient.Exec(ctx, req)
 		if err != nil {
-			if status, ok := status.FromError(err); ok && len(status.Message()) > 5 {
-				return &Response{
-					Status: ParseError(errors.New(status.Message())),
-				}
-			}
 			return &Response{
-				Status: ParseError(err),
+				Status: clientStatus(err),
 			}
 		}
 
@@ -210,13 +209,8 @@ func (it *clientImpl) DataProject(req *DataProjectRequest) *DataProjectResponse
 
 	rs, err := it.rpcClient.DataProject(ctx, req)
 	if err != nil {
-		if status, ok := status.FromError(err); ok && len(status.Message()) > 5 {
-			return &DataProjectResponse{
-				Status: ParseError(errors.New(status.Message())),
-			}
-		}
 		return &DataProjectResponse{
-			Status: ParseError(err),
+			Status: clientStatus(err),
 		}
 	}
 	if rs.Status == nil {
@@ -232,13 +226,8 @@ func (it *clientImpl) DataQuery(req *DataQuery) *DataResult {
 
 	rs, err := it.rpcClient.DataQuery(ctx, req)
 	if err != nil {
-		if status, ok := status.FromError(err); ok && len(status.Message()) > 5 {
-			return &DataResult{
-				Status: ParseError(errors.New(status.Message())),
-			}
-		}
 		return &DataResult{
-			Status: ParseError(err),
+			Status: clientStatus(err),
 		}
 	}
 	if rs.Status == nil {
@@ -254,13 +243,76 @@ func (it *clientImpl) DataUpsert(req *DataInsert) *DataResult {
 
 	rs, err := it.rpcClient.DataUpsert(ctx, req)
 	if err != nil {
-		if status, ok := status.FromError(err); ok && len(status.Message()) > 5 {
-			return &DataResult{
-				Status: ParseError(errors.New(status.Message())),
-			}
+		return &DataResult{
+			Status: clientStatus(err),
 		}
+	}
+	if rs.Status == nil {
+		rs.Status = NewServiceStatusOK()
+	}
+	return rs
+}
+
+func (it *clientImpl) DataIgsert(req *DataInsert) *DataResult {
+
+	ctx, fc := context.WithTimeout(context.Background(), it.cfg.timeout())
+	defer fc()
+
+	rs, err := it.rpcClient.DataIgsert(ctx, req)
+	if err != nil {
+		return &DataResult{
+			Status: clientStatus(err),
+		}
+	}
+	if rs.Status == nil {
+		rs.Status = NewServiceStatusOK()
+	}
+	return rs
+}
+
+func (it *clientImpl) DataUpdate(req *DataUpdate) *DataResult {
+
+	ctx, fc := context.WithTimeout(context.Background(), it.cfg.timeout())
+	defer fc()
+
+	rs, err := it.rpcClient.DataUpdate(ctx, req)
+	if err != nil {
+		return &DataResult{
+			Status: clientStatus(err),
+		}
+	}
+	if rs.Status == nil {
+		rs.Status = NewServiceStatusOK()
+	}
+	return rs
+}
+
+func (it *clientImpl) DataDelete(req *DataDelete) *DataResult {
+
+	ctx, fc := context.WithTimeout(context.Background(), it.cfg.timeout())
+	defer fc()
+
+	rs, err := it.rpcClient.DataDelete(ctx, req)
+	if err != nil {
+		return &DataResult{
+			Status: clientStatus(err),
+		}
+	}
+	if rs.Status == nil {
+		rs.Status = NewServiceStatusOK()
+	}
+	return rs
+}
+
+func (it *clientImpl) DataRestore(req *DataRestore) *DataResult {
+
+	ctx, fc := context.WithTimeout(context.Background(), it.cfg.timeout())
+	defer fc()
+
+	rs, err := it.rpcClient.DataRestore(ctx, req)
+	if err != nil {
 		return &DataResult{
-			Status: ParseError(err),
+			Status: clientStatus(err),
 		}
 	}
 	if rs.Status == nil {
@@ -321,3 +373,150 @@ func rpcClientConnect(
 
 	return c, nil
 }
+
+// clientStatus returns the status of a rpc error, with the violations of a
+// validation error in the status details.
+func clientStatus(err error) *ServiceStatus {
+	if status, ok := status.FromError(err); ok && len(status.Message()) > 5 {
+		for _, detail := range status.Details() {
+			if st, ok := detail.(*ServiceStatus); ok {
+				return st
+			}
+		}
+		return ParseError(errors.New(status.Message()))
+	}
+	return ParseError(err)
+}
+
+// DataBackup writes a backup of the instance req.InstanceName (or the tables
+// req.Tables only) to w in the archive file format.
+func (it *clientImpl) DataBackup(req *DataBackupRequest, w io.Writer) *DataBackupReport {
+
+	ctx, fc := context.WithCancel(context.Background())
+	defer fc()
+
+	stream, err := it.rpcClient.DataBackup(ctx, req)
+	if err != nil {
+		return &DataBackupReport{
+			Status: clientStatus(err),
+		}
+	}
+
+	var (
+		write  = NewDataBackupWriter(w)
+		rs     = &DataBackupReport{}
+		tables = map[string]*DataBackupReport_Table{}
+	)
+
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return &DataBackupReport{
+				Status: clientStatus(err),
+			}
+		}
+		if err := write(chunk); err != nil {
+			return &DataBackupReport{
+				Status: NewServiceStatusClientError(err.Error()),
+			}
+		}
+		for _, spec := range chunk.Specs {
+			tables[spec.Name] = &DataBackupReport_Table{
+				Name: spec.Name,
+			}
+			rs.Tables = append(rs.Tables, tables[spec.Name])
+		}
+		if tbl, ok := tables[chunk.TableName]; ok {
+			tbl.Rows += int64(len(chunk.Rows))
+		}
+	}
+
+	rs.Status = NewServiceStatusOK()
+	return rs
+}
+
+// DataBackupRestore restores the archive read from r, into the instance
+// req.InstanceName if set (or the instance of the archive), and of the tables
+// req.Tables only if set.
+func (it *clientImpl) DataBackupRestore(req *DataBackupRequest, r io.Reader) *DataBackupReport {
+
+	ctx, fc := context.WithCancel(context.Background())
+	defer fc()
+
+	stream, err := it.rpcClient.DataBackupRestore(ctx)
+	if err != nil {
+		return &DataBackupReport{
+			Status: clientStatus(err),
+		}
+	}
+
+	read := NewDataBackupReader(r)
+
+	for i := 0; ; i++ {
+		chunk, err := read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return &DataBackupReport{
+				Status: NewServiceStatusClientError(err.Error()),
+			}
+		}
+		if i == 0 {
+			if req.InstanceName != "" {
+				chunk.InstanceName = req.InstanceName
+			}
+			if len(req.Tables) > 0 {
+				var specs []*TableSpec
+				for _, spec := range chunk.Specs {
+					if slices.Contains(req.Tables, spec.Name) {
+						specs = append(specs, spec)
+					}
+				}
+				chunk.Specs = specs
+			}
+		} else if len(req.Tables) > 0 && !slices.Contains(req.Tables, chunk.TableName) {
+			continue
+		}
+		if err := stream.Send(chunk); err != nil {
+			break // the error is returned by CloseAndRecv
+		}
+	}
+
+	rs, err := stream.CloseAndRecv()
+	if err != nil {
+		return &DataBackupReport{
+			Status: clientStatus(err),
+		}
+	}
+	if rs.Status == nil {
+		rs.Status = NewServiceStatusOK()
+	}
+	return rs
+}
+
+// DataQueryStream returns an iterator of the rows of the query, which are
+// received in batches: the rows of a large table do not have to fit in one
+// message or in memory. The iterator must be closed if not read to the end.
+func (it *clientImpl) DataQueryStream(req *DataQuery) *DataQueryIterator {
+
+	ctx, fc := context.WithCancel(context.Background())
+
+	stream, err := it.rpcClient.DataQueryStream(ctx, req)
+	if err != nil {
+		fc()
+		rs := &DataResult{Status: clientStatus(err)}
+		return NewDataQueryIterator(func() (*DataResult, error) {
+			return rs, nil
+		}, nil)
+	}
+
+	return NewDataQueryIterator(func() (*DataResult, error) {
+		rs, err := stream.Recv()
+		if err != nil && err != io.EOF {
+			return nil, clientStatus(err).Err()
+		}
+		return rs, err
+	}, fc)
+}
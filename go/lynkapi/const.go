@@ -49,6 +49,36 @@ const (
 	DataMerge_Delete
 )
 
+// DataMerge_ArrayMode sets how the array:struct fields are merged, the other
+// arrays are always replaced.
+type DataMerge_ArrayMode int
+
+const (
+	// replace the whole array
+	DataMerge_ArrayReplace DataMerge_ArrayMode = iota
+	// merge the elements matching by primary key, and append the new ones
+	DataMerge_ArrayMerge
+	// as DataMerge_ArrayMerge, and delete the elements missing in the source
+	DataMerge_ArraySync
+)
+
+// DataMerge_FieldMask lists the field paths (dotted tag names, ex: `sub.name`)
+// overwritten by a merge, zero values included: a zero value clears the field,
+// and a masked struct, array or map is replaced with the source one. The
+// fields not in the mask are left untouched.
+type DataMerge_FieldMask []string
+
+// the rules of the violations in a ValidationError
+const (
+	ValidationRule_Required = "required"
+	ValidationRule_Enum     = "enum"
+	ValidationRule_Min      = "min"
+	ValidationRule_Max      = "max"
+	ValidationRule_Pattern  = "pattern"
+	ValidationRule_Type     = "type"
+	ValidationRule_Unique   = "unique"
+)
+
 const RequestSpecNameInContext = "lynkdb.lynkapi.Context.RequestSpec"
 
 var (
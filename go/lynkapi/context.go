@@ -27,6 +27,7 @@ type Context interface {
 type xContext struct {
 	context.Context
 	request *http.Request
+	body    []byte
 	spec    *TypeSpec
 }
 
@@ -34,6 +35,15 @@ func (it *xContext) RequestSpec() *TypeSpec {
 	return it.spec
 }
 
+func httpRequestFromContext(ctx context.Context) (*http.Request, []byte) {
+	for xc, ok := ctx.(*xContext); ok && xc != nil; xc, ok = xc.Context.(*xContext) {
+		if xc.request != nil {
+			return xc.request, xc.body
+		}
+	}
+	return nil, nil
+}
+
 func (it *xContext) Value(key any) any {
 	if it == nil || key == nil {
 		return nil
@@ -32,6 +32,50 @@ type DataService interface {
 	Delete(q *DataDelete) (*DataResult, error)
 }
 
+type DataHistoryService interface {
+	DataService
+
+	Restore(q *DataRestore) (*DataResult, error)
+}
+
+// DataDryRunService is a DataService which accepts the writes (and restores)
+// with dry_run: the write is validated and the rows and changes it would make
+// are returned in the result (Rows and Changes), but nothing is committed.
+type DataDryRunService interface {
+	DataService
+
+	DryRun() bool
+}
+
+// DataUpdateService is a DataService which writes the existing rows only:
+// Update merges the fields into the row matched by the keys, as Upsert does,
+// and returns a NotFound result (no rows) if there is no such row.
+type DataUpdateService interface {
+	DataService
+
+	Update(q *DataInsert) (*DataResult, error)
+}
+
+// DataColumnarService is a DataService which returns the rows of a query
+// with columnar in columns (Cols, see DataColWriter) instead of rows.
+type DataColumnarService interface {
+	DataService
+
+	Columnar() bool
+}
+
+// DataSnapshotService is a DataService which reads a consistent snapshot of
+// all its tables.
+type DataSnapshotService interface {
+	DataService
+
+	Snapshot() (DataSnapshot, error)
+}
+
+type DataSnapshot interface {
+	Query(q *DataQuery) (*DataResult, error)
+}
+
 type dataProjectManager struct {
 	mu      sync.RWMutex
 	project *DataProject
@@ -102,6 +146,27 @@ func (it *dataProjectManager) RegisterService(ds DataService) error {
 	return nil
 }
 
+// writeCheck validates the references, dictionary values and tree of a write.
+func (it *dataProjectManager) writeCheck(req *DataInsert) error {
+	if err := it.refCheck(req); err != nil {
+		return err
+	}
+	if err := it.dictCheck(req); err != nil {
+		return err
+	}
+	return it.treeCheck(req)
+}
+
+// trashCheck is the writeCheck of a row a backup restore puts back into the
+// trash: its references may be rows of the trash, they are checked when the
+// row is restored.
+func (it *dataProjectManager) trashCheck(req *DataInsert) error {
+	if err := it.dictCheck(req); err != nil {
+		return err
+	}
+	return it.treeCheck(req)
+}
+
 func (it *DataInstance) SetName(name string) *DataInstance {
 	if NameIdentifier.MatchString(name) {
 		it.Name = name
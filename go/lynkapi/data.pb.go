@@ -161,6 +161,12 @@ type DataRow struct {
 	Values []*structpb.Value `protobuf:"bytes,9,rep,name=values,proto3" json:"values,omitempty" toml:"values,omitempty" yaml:"values,omitempty"`
 	// field.tag_name = any-value
 	Fields map[string]*structpb.Value `protobuf:"bytes,10,rep,name=fields,proto3" json:"fields,omitempty" toml:"fields,omitempty" yaml:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// field.tag_name = display name of the dictionary value
+	DisplayNames map[string]string `protobuf:"bytes,12,rep,name=display_names,json=displayNames,proto3" json:"display_names,omitempty" toml:"display_names,omitempty" yaml:"display_names,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// depth in the tree of a tree query
+	Depth int32 `protobuf:"varint,13,opt,name=depth,proto3" json:"depth,omitempty" toml:"depth,omitempty" yaml:"depth,omitempty"`
+	// instance of the row in a federated query
+	InstanceName string `protobuf:"bytes,14,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty" toml:"instance_name,omitempty" yaml:"instance_name,omitempty"`
 	// ex ...
 	InterOrder int64 `protobuf:"varint,32,opt,name=inter_order,json=interOrder,proto3" json:"inter_order,omitempty" toml:"inter_order,omitempty" yaml:"inter_order,omitempty"`
 }
@@ -218,6 +224,27 @@ func (x *DataRow) GetFields() map[string]*structpb.Value {
 	return nil
 }
 
+func (x *DataRow) GetDisplayNames() map[string]string {
+	if x != nil {
+		return x.DisplayNames
+	}
+	return nil
+}
+
+func (x *DataRow) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *DataRow) GetInstanceName() string {
+	if x != nil {
+		return x.InstanceName
+	}
+	return ""
+}
+
 func (x *DataRow) GetInterOrder() int64 {
 	if x != nil {
 		return x.InterOrder
@@ -225,22 +252,200 @@ func (x *DataRow) GetInterOrder() int64 {
 	return 0
 }
 
+type DataFieldChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path     string          `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty" toml:"path,omitempty" yaml:"path,omitempty"`
+	OldValue *structpb.Value `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty" toml:"old_value,omitempty" yaml:"old_value,omitempty"`
+	NewValue *structpb.Value `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty" toml:"new_value,omitempty" yaml:"new_value,omitempty"`
+}
+
+func (x *DataFieldChange) Reset() {
+	*x = DataFieldChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lynkapi_data_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataFieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataFieldChange) ProtoMessage() {}
+
+func (x *DataFieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataFieldChange.ProtoReflect.Descriptor instead.
+func (*DataFieldChange) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DataFieldChange) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *DataFieldChange) GetOldValue() *structpb.Value {
+	if x != nil {
+		return x.OldValue
+	}
+	return nil
+}
+
+func (x *DataFieldChange) GetNewValue() *structpb.Value {
+	if x != nil {
+		return x.NewValue
+	}
+	return nil
+}
+
+type DataRowChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       string                     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty" toml:"id,omitempty" yaml:"id,omitempty"`
+	Action   string                     `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty" toml:"action,omitempty" yaml:"action,omitempty" x_enums:"create,update,delete,restore"`
+	Version  uint64                     `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty" toml:"version,omitempty" yaml:"version,omitempty"`
+	Operator string                     `protobuf:"bytes,4,opt,name=operator,proto3" json:"operator,omitempty" toml:"operator,omitempty" yaml:"operator,omitempty"`
+	Created  int64                      `protobuf:"varint,5,opt,name=created,proto3" json:"created,omitempty" toml:"created,omitempty" yaml:"created,omitempty"` // unix time in milliseconds
+	// the table of a row changed by a cascade or set_null of a delete
+	TableName string `protobuf:"bytes,6,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty" toml:"table_name,omitempty" yaml:"table_name,omitempty"`
+	Fields   map[string]*structpb.Value `protobuf:"bytes,9,rep,name=fields,proto3" json:"fields,omitempty" toml:"fields,omitempty" yaml:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Changes  []*DataFieldChange         `protobuf:"bytes,10,rep,name=changes,proto3" json:"changes,omitempty" toml:"changes,omitempty" yaml:"changes,omitempty"`
+}
+
+func (x *DataRowChange) Reset() {
+	*x = DataRowChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lynkapi_data_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataRowChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataRowChange) ProtoMessage() {}
+
+func (x *DataRowChange) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataRowChange.ProtoReflect.Descriptor instead.
+func (*DataRowChange) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DataRowChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DataRowChange) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *DataRowChange) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DataRowChange) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *DataRowChange) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *DataRowChange) GetTableName() string {
+	if x != nil {
+		return x.TableName
+	}
+	return ""
+}
+
+func (x *DataRowChange) GetFields() map[string]*structpb.Value {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *DataRowChange) GetChanges() []*DataFieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// a column of a columnar result, the values of the rows with a value are in
+// one of the value lists (by the column type), in the order of the rows.
 type DataCol struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	Field  string            `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty" toml:"field,omitempty" yaml:"field,omitempty"`
+	Type   string            `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty" toml:"type,omitempty" yaml:"type,omitempty"` // field type, empty for the values of any type
+	Values []*structpb.Value `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty" toml:"values,omitempty" yaml:"values,omitempty"`
+	Nulls  []uint32          `protobuf:"varint,4,rep,packed,name=nulls,proto3" json:"nulls,omitempty" toml:"nulls,omitempty" yaml:"nulls,omitempty"` // indexes of the rows without a value
+	// int, uint and bool: the first value, and the deltas from the previous
+	// value (the first one from base_int_value)
 	BaseIntValue int64     `protobuf:"varint,6,opt,name=base_int_value,json=baseIntValue,proto3" json:"base_int_value,omitempty" toml:"base_int_value,omitempty" yaml:"base_int_value,omitempty"`
 	IntValues    []int64   `protobuf:"varint,7,rep,packed,name=int_values,json=intValues,proto3" json:"int_values,omitempty" toml:"int_values,omitempty" yaml:"int_values,omitempty"`
 	StringValues []string  `protobuf:"bytes,9,rep,name=string_values,json=stringValues,proto3" json:"string_values,omitempty" toml:"string_values,omitempty" yaml:"string_values,omitempty"`
 	BytesValues  [][]byte  `protobuf:"bytes,11,rep,name=bytes_values,json=bytesValues,proto3" json:"bytes_values,omitempty" toml:"bytes_values,omitempty" yaml:"bytes_values,omitempty"`
 	FloatValues  []float32 `protobuf:"fixed32,13,rep,packed,name=float_values,json=floatValues,proto3" json:"float_values,omitempty" toml:"float_values,omitempty" yaml:"float_values,omitempty"`
+	DoubleValues []float64 `protobuf:"fixed64,14,rep,packed,name=double_values,json=doubleValues,proto3" json:"double_values,omitempty" toml:"double_values,omitempty" yaml:"double_values,omitempty"`
+	// display names of the dictionary values, one per row if a row has one
+	DisplayNames []string `protobuf:"bytes,15,rep,name=display_names,json=displayNames,proto3" json:"display_names,omitempty" toml:"display_names,omitempty" yaml:"display_names,omitempty"`
 }
 
 func (x *DataCol) Reset() {
 	*x = DataCol{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lynkapi_data_proto_msgTypes[2]
+		mi := &file_lynkapi_data_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -253,7 +458,7 @@ func (x *DataCol) String() string {
 func (*DataCol) ProtoMessage() {}
 
 func (x *DataCol) ProtoReflect() protoreflect.Message {
-	mi := &file_lynkapi_data_proto_msgTypes[2]
+	mi := &file_lynkapi_data_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -266,7 +471,35 @@ func (x *DataCol) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataCol.ProtoReflect.Descriptor instead.
 func (*DataCol) Descriptor() ([]byte, []int) {
-	return file_lynkapi_data_proto_rawDescGZIP(), []int{2}
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DataCol) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *DataCol) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DataCol) GetValues() []*structpb.Value {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *DataCol) GetNulls() []uint32 {
+	if x != nil {
+		return x.Nulls
+	}
+	return nil
 }
 
 func (x *DataCol) GetBaseIntValue() int64 {
@@ -304,6 +537,20 @@ func (x *DataCol) GetFloatValues() []float32 {
 	return nil
 }
 
+func (x *DataCol) GetDoubleValues() []float64 {
+	if x != nil {
+		return x.DoubleValues
+	}
+	return nil
+}
+
+func (x *DataCol) GetDisplayNames() []string {
+	if x != nil {
+		return x.DisplayNames
+	}
+	return nil
+}
+
 type TableSpec struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -324,7 +571,7 @@ type TableSpec struct {
 func (x *TableSpec) Reset() {
 	*x = TableSpec{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lynkapi_data_proto_msgTypes[3]
+		mi := &file_lynkapi_data_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -337,7 +584,7 @@ func (x *TableSpec) String() string {
 func (*TableSpec) ProtoMessage() {}
 
 func (x *TableSpec) ProtoReflect() protoreflect.Message {
-	mi := &file_lynkapi_data_proto_msgTypes[3]
+	mi := &file_lynkapi_data_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -350,7 +597,7 @@ func (x *TableSpec) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TableSpec.ProtoReflect.Descriptor instead.
 func (*TableSpec) Descriptor() ([]byte, []int) {
-	return file_lynkapi_data_proto_rawDescGZIP(), []int{3}
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *TableSpec) GetName() string {
@@ -429,7 +676,7 @@ type DataSpec struct {
 func (x *DataSpec) Reset() {
 	*x = DataSpec{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lynkapi_data_proto_msgTypes[4]
+		mi := &file_lynkapi_data_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -442,7 +689,7 @@ func (x *DataSpec) String() string {
 func (*DataSpec) ProtoMessage() {}
 
 func (x *DataSpec) ProtoReflect() protoreflect.Message {
-	mi := &file_lynkapi_data_proto_msgTypes[4]
+	mi := &file_lynkapi_data_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -455,7 +702,7 @@ func (x *DataSpec) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataSpec.ProtoReflect.Descriptor instead.
 func (*DataSpec) Descriptor() ([]byte, []int) {
-	return file_lynkapi_data_proto_rawDescGZIP(), []int{4}
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DataSpec) GetDriver() string {
@@ -493,7 +740,7 @@ type DataConnect struct {
 func (x *DataConnect) Reset() {
 	*x = DataConnect{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lynkapi_data_proto_msgTypes[5]
+		mi := &file_lynkapi_data_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -506,7 +753,7 @@ func (x *DataConnect) String() string {
 func (*DataConnect) ProtoMessage() {}
 
 func (x *DataConnect) ProtoReflect() protoreflect.Message {
-	mi := &file_lynkapi_data_proto_msgTypes[5]
+	mi := &file_lynkapi_data_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -519,7 +766,7 @@ func (x *DataConnect) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataConnect.ProtoReflect.Descriptor instead.
 func (*DataConnect) Descriptor() ([]byte, []int) {
-	return file_lynkapi_data_proto_rawDescGZIP(), []int{5}
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DataConnect) GetAddress() string {
@@ -565,7 +812,7 @@ type DataInstance struct {
 func (x *DataInstance) Reset() {
 	*x = DataInstance{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lynkapi_data_proto_msgTypes[6]
+		mi := &file_lynkapi_data_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -578,7 +825,7 @@ func (x *DataInstance) String() string {
 func (*DataInstance) ProtoMessage() {}
 
 func (x *DataInstance) ProtoReflect() protoreflect.Message {
-	mi := &file_lynkapi_data_proto_msgTypes[6]
+	mi := &file_lynkapi_data_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -591,7 +838,7 @@ func (x *DataInstance) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataInstance.ProtoReflect.Descriptor instead.
 func (*DataInstance) Descriptor() ([]byte, []int) {
-	return file_lynkapi_data_proto_rawDescGZIP(), []int{6}
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DataInstance) GetKind() string {
@@ -641,7 +888,7 @@ type DataProject struct {
 func (x *DataProject) Reset() {
 	*x = DataProject{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lynkapi_data_proto_msgTypes[7]
+		mi := &file_lynkapi_data_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -654,7 +901,7 @@ func (x *DataProject) String() string {
 func (*DataProject) ProtoMessage() {}
 
 func (x *DataProject) ProtoReflect() protoreflect.Message {
-	mi := &file_lynkapi_data_proto_msgTypes[7]
+	mi := &file_lynkapi_data_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -667,7 +914,7 @@ func (x *DataProject) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataProject.ProtoReflect.Descriptor instead.
 func (*DataProject) Descriptor() ([]byte, []int) {
-	return file_lynkapi_data_proto_rawDescGZIP(), []int{7}
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *DataProject) GetName() string {
@@ -695,13 +942,27 @@ type DataQuery struct {
 	Filter       *DataQuery_Filter     `protobuf:"bytes,6,opt,name=filter,proto3" json:"filter,omitempty" toml:"filter,omitempty" yaml:"filter,omitempty"`
 	Sort         *DataQuery_SortFilter `protobuf:"bytes,7,opt,name=sort,proto3" json:"sort,omitempty" toml:"sort,omitempty" yaml:"sort,omitempty"`
 	Offset       int32                 `protobuf:"varint,8,opt,name=offset,proto3" json:"offset,omitempty" toml:"offset,omitempty" yaml:"offset,omitempty"`
-	Limit        int32                 `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty" toml:"limit,omitempty" yaml:"limit,omitempty"` // string page_token = 11;
+	Limit        int32                 `protobuf:"varint,9,opt,name=limit,proto3" json:"limit,omitempty" toml:"limit,omitempty" yaml:"limit,omitempty"`
+	// string page_token = 11;
+	AsOf        int64           `protobuf:"varint,12,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty" toml:"as_of,omitempty" yaml:"as_of,omitempty"` // unix time in milliseconds
+	History     bool            `protobuf:"varint,13,opt,name=history,proto3" json:"history,omitempty" toml:"history,omitempty" yaml:"history,omitempty"`
+	Trash       bool            `protobuf:"varint,14,opt,name=trash,proto3" json:"trash,omitempty" toml:"trash,omitempty" yaml:"trash,omitempty"`
+	DictDisplay bool            `protobuf:"varint,15,opt,name=dict_display,json=dictDisplay,proto3" json:"dict_display,omitempty" toml:"dict_display,omitempty" yaml:"dict_display,omitempty"`
+	Tree        *DataQuery_Tree `protobuf:"bytes,16,opt,name=tree,proto3" json:"tree,omitempty" toml:"tree,omitempty" yaml:"tree,omitempty"`
+	// federated query of the table in these instances (sharing its spec),
+	// instead of instance_name
+	InstanceNames []string        `protobuf:"bytes,17,rep,name=instance_names,json=instanceNames,proto3" json:"instance_names,omitempty" toml:"instance_names,omitempty" yaml:"instance_names,omitempty"`
+	Join          *DataQuery_Join `protobuf:"bytes,18,opt,name=join,proto3" json:"join,omitempty" toml:"join,omitempty" yaml:"join,omitempty"`
+	// returns the plan of the query in the result
+	Explain bool `protobuf:"varint,19,opt,name=explain,proto3" json:"explain,omitempty" toml:"explain,omitempty" yaml:"explain,omitempty"`
+	// returns the rows in the columns of the result (cols) instead of rows
+	Columnar bool `protobuf:"varint,20,opt,name=columnar,proto3" json:"columnar,omitempty" toml:"columnar,omitempty" yaml:"columnar,omitempty"`
 }
 
 func (x *DataQuery) Reset() {
 	*x = DataQuery{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lynkapi_data_proto_msgTypes[8]
+		mi := &file_lynkapi_data_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -714,7 +975,7 @@ func (x *DataQuery) String() string {
 func (*DataQuery) ProtoMessage() {}
 
 func (x *DataQuery) ProtoReflect() protoreflect.Message {
-	mi := &file_lynkapi_data_proto_msgTypes[8]
+	mi := &file_lynkapi_data_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -727,7 +988,7 @@ func (x *DataQuery) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataQuery.ProtoReflect.Descriptor instead.
 func (*DataQuery) Descriptor() ([]byte, []int) {
-	return file_lynkapi_data_proto_rawDescGZIP(), []int{8}
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DataQuery) GetInstanceName() string {
@@ -779,106 +1040,100 @@ func (x *DataQuery) GetLimit() int32 {
 	return 0
 }
 
-type DataInsert struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	InstanceName string            `protobuf:"bytes,2,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty" toml:"instance_name,omitempty" yaml:"instance_name,omitempty" x_attrs:"name_identifier"`
-	TableName    string            `protobuf:"bytes,3,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty" toml:"table_name,omitempty" yaml:"table_name,omitempty" x_attrs:"name_identifier"`
-	Fields       []string          `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty" toml:"fields,omitempty" yaml:"fields,omitempty"`
-	Values       []*structpb.Value `protobuf:"bytes,6,rep,name=values,proto3" json:"values,omitempty" toml:"values,omitempty" yaml:"values,omitempty"`
-}
-
-func (x *DataInsert) Reset() {
-	*x = DataInsert{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_lynkapi_data_proto_msgTypes[9]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *DataQuery) GetAsOf() int64 {
+	if x != nil {
+		return x.AsOf
 	}
+	return 0
 }
 
-func (x *DataInsert) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *DataQuery) GetHistory() bool {
+	if x != nil {
+		return x.History
+	}
+	return false
 }
 
-func (*DataInsert) ProtoMessage() {}
-
-func (x *DataInsert) ProtoReflect() protoreflect.Message {
-	mi := &file_lynkapi_data_proto_msgTypes[9]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *DataQuery) GetTrash() bool {
+	if x != nil {
+		return x.Trash
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use DataInsert.ProtoReflect.Descriptor instead.
-func (*DataInsert) Descriptor() ([]byte, []int) {
-	return file_lynkapi_data_proto_rawDescGZIP(), []int{9}
+func (x *DataQuery) GetDictDisplay() bool {
+	if x != nil {
+		return x.DictDisplay
+	}
+	return false
 }
 
-func (x *DataInsert) GetInstanceName() string {
+func (x *DataQuery) GetTree() *DataQuery_Tree {
 	if x != nil {
-		return x.InstanceName
+		return x.Tree
 	}
-	return ""
+	return nil
 }
 
-func (x *DataInsert) GetTableName() string {
+func (x *DataQuery) GetInstanceNames() []string {
 	if x != nil {
-		return x.TableName
+		return x.InstanceNames
 	}
-	return ""
+	return nil
 }
 
-func (x *DataInsert) GetFields() []string {
+func (x *DataQuery) GetJoin() *DataQuery_Join {
 	if x != nil {
-		return x.Fields
+		return x.Join
 	}
 	return nil
 }
 
-func (x *DataInsert) GetValues() []*structpb.Value {
+func (x *DataQuery) GetExplain() bool {
 	if x != nil {
-		return x.Values
+		return x.Explain
 	}
-	return nil
+	return false
 }
 
-type DataUpdate struct {
+func (x *DataQuery) GetColumnar() bool {
+	if x != nil {
+		return x.Columnar
+	}
+	return false
+}
+
+type DataQueryPlan struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	InstanceName string            `protobuf:"bytes,2,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty" toml:"instance_name,omitempty" yaml:"instance_name,omitempty" x_attrs:"name_identifier"`
-	TableName    string            `protobuf:"bytes,3,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty" toml:"table_name,omitempty" yaml:"table_name,omitempty" x_attrs:"name_identifier"`
-	Fields       []string          `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty" toml:"fields,omitempty" yaml:"fields,omitempty"`
-	Values       []*structpb.Value `protobuf:"bytes,6,rep,name=values,proto3" json:"values,omitempty" toml:"values,omitempty" yaml:"values,omitempty"`
-	Filter       *DataQuery_Filter `protobuf:"bytes,9,opt,name=filter,proto3" json:"filter,omitempty" toml:"filter,omitempty" yaml:"filter,omitempty"`
+	Driver       string                 `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty" toml:"driver,omitempty" yaml:"driver,omitempty"`
+	Index        string                 `protobuf:"bytes,2,opt,name=index,proto3" json:"index,omitempty" toml:"index,omitempty" yaml:"index,omitempty"` // index used, empty for none
+	Access       string                 `protobuf:"bytes,3,opt,name=access,proto3" json:"access,omitempty" toml:"access,omitempty" yaml:"access,omitempty" x_enums:"full_scan,range,key"`
+	RowsExamined int64                  `protobuf:"varint,4,opt,name=rows_examined,json=rowsExamined,proto3" json:"rows_examined,omitempty" toml:"rows_examined,omitempty" yaml:"rows_examined,omitempty"`
+	RowsReturned int64                  `protobuf:"varint,5,opt,name=rows_returned,json=rowsReturned,proto3" json:"rows_returned,omitempty" toml:"rows_returned,omitempty" yaml:"rows_returned,omitempty"`
+	Stages       []*DataQueryPlan_Stage `protobuf:"bytes,6,rep,name=stages,proto3" json:"stages,omitempty" toml:"stages,omitempty" yaml:"stages,omitempty"`
+	TimeUs       int64                  `protobuf:"varint,7,opt,name=time_us,json=timeUs,proto3" json:"time_us,omitempty" toml:"time_us,omitempty" yaml:"time_us,omitempty"` // total time in microseconds
 }
 
-func (x *DataUpdate) Reset() {
-	*x = DataUpdate{}
+func (x *DataQueryPlan) Reset() {
+	*x = DataQueryPlan{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lynkapi_data_proto_msgTypes[10]
+		mi := &file_lynkapi_data_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DataUpdate) String() string {
+func (x *DataQueryPlan) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DataUpdate) ProtoMessage() {}
+func (*DataQueryPlan) ProtoMessage() {}
 
-func (x *DataUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_lynkapi_data_proto_msgTypes[10]
+func (x *DataQueryPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -889,73 +1144,93 @@ func (x *DataUpdate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DataUpdate.ProtoReflect.Descriptor instead.
-func (*DataUpdate) Descriptor() ([]byte, []int) {
-	return file_lynkapi_data_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use DataQueryPlan.ProtoReflect.Descriptor instead.
+func (*DataQueryPlan) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *DataUpdate) GetInstanceName() string {
+func (x *DataQueryPlan) GetDriver() string {
 	if x != nil {
-		return x.InstanceName
+		return x.Driver
 	}
 	return ""
 }
 
-func (x *DataUpdate) GetTableName() string {
+func (x *DataQueryPlan) GetIndex() string {
 	if x != nil {
-		return x.TableName
+		return x.Index
 	}
 	return ""
 }
 
-func (x *DataUpdate) GetFields() []string {
+func (x *DataQueryPlan) GetAccess() string {
 	if x != nil {
-		return x.Fields
+		return x.Access
 	}
-	return nil
+	return ""
 }
 
-func (x *DataUpdate) GetValues() []*structpb.Value {
+func (x *DataQueryPlan) GetRowsExamined() int64 {
 	if x != nil {
-		return x.Values
+		return x.RowsExamined
 	}
-	return nil
+	return 0
 }
 
-func (x *DataUpdate) GetFilter() *DataQuery_Filter {
+func (x *DataQueryPlan) GetRowsReturned() int64 {
 	if x != nil {
-		return x.Filter
+		return x.RowsReturned
+	}
+	return 0
+}
+
+func (x *DataQueryPlan) GetStages() []*DataQueryPlan_Stage {
+	if x != nil {
+		return x.Stages
 	}
 	return nil
 }
 
-type DataDelete struct {
+func (x *DataQueryPlan) GetTimeUs() int64 {
+	if x != nil {
+		return x.TimeUs
+	}
+	return 0
+}
+
+type DataInsert struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	InstanceName string            `protobuf:"bytes,2,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty" toml:"instance_name,omitempty" yaml:"instance_name,omitempty" x_attrs:"name_identifier"`
 	TableName    string            `protobuf:"bytes,3,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty" toml:"table_name,omitempty" yaml:"table_name,omitempty" x_attrs:"name_identifier"`
-	Filter       *DataQuery_Filter `protobuf:"bytes,7,opt,name=filter,proto3" json:"filter,omitempty" toml:"filter,omitempty" yaml:"filter,omitempty"`
+	Fields       []string          `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty" toml:"fields,omitempty" yaml:"fields,omitempty"`
+	Values       []*structpb.Value `protobuf:"bytes,6,rep,name=values,proto3" json:"values,omitempty" toml:"values,omitempty" yaml:"values,omitempty"`
+	Operator     string            `protobuf:"bytes,8,opt,name=operator,proto3" json:"operator,omitempty" toml:"operator,omitempty" yaml:"operator,omitempty"`
+	// validate and return the rows and changes of the write without commit
+	DryRun bool `protobuf:"varint,9,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty" toml:"dry_run,omitempty" yaml:"dry_run,omitempty"`
+	// the field paths overwritten in an existing row, zero values included
+	FieldMask []string `protobuf:"bytes,10,rep,name=field_mask,json=fieldMask,proto3" json:"field_mask,omitempty" toml:"field_mask,omitempty" yaml:"field_mask,omitempty"`
 }
 
-func (x *DataDelete) Reset() {
-	*x = DataDelete{}
+func (x *DataInsert) Reset() {
+	*x = DataInsert{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lynkapi_data_proto_msgTypes[11]
+		mi := &file_lynkapi_data_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DataDelete) String() string {
+func (x *DataInsert) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DataDelete) ProtoMessage() {}
+func (*DataInsert) ProtoMessage() {}
 
-func (x *DataDelete) ProtoReflect() protoreflect.Message {
-	mi := &file_lynkapi_data_proto_msgTypes[11]
+func (x *DataInsert) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -966,65 +1241,93 @@ func (x *DataDelete) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DataDelete.ProtoReflect.Descriptor instead.
-func (*DataDelete) Descriptor() ([]byte, []int) {
-	return file_lynkapi_data_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use DataInsert.ProtoReflect.Descriptor instead.
+func (*DataInsert) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *DataDelete) GetInstanceName() string {
+func (x *DataInsert) GetInstanceName() string {
 	if x != nil {
 		return x.InstanceName
 	}
 	return ""
 }
 
-func (x *DataDelete) GetTableName() string {
+func (x *DataInsert) GetTableName() string {
 	if x != nil {
 		return x.TableName
 	}
 	return ""
 }
 
-func (x *DataDelete) GetFilter() *DataQuery_Filter {
+func (x *DataInsert) GetFields() []string {
 	if x != nil {
-		return x.Filter
+		return x.Fields
 	}
 	return nil
 }
 
-type DataResult struct {
+func (x *DataInsert) GetValues() []*structpb.Value {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *DataInsert) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *DataInsert) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *DataInsert) GetFieldMask() []string {
+	if x != nil {
+		return x.FieldMask
+	}
+	return nil
+}
+
+type DataUpdate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Kind       string            `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty" toml:"kind,omitempty" yaml:"kind,omitempty"`
-	Status     *ServiceStatus    `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty" toml:"status,omitempty" yaml:"status,omitempty"`
-	Name       string            `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty" toml:"name,omitempty" yaml:"name,omitempty"`
-	Spec       *TableSpec        `protobuf:"bytes,4,opt,name=spec,proto3" json:"spec,omitempty" toml:"spec,omitempty" yaml:"spec,omitempty"`
-	Stats      *DataResult_Stats `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty" toml:"stats,omitempty" yaml:"stats,omitempty"`
-	Rows       []*DataRow        `protobuf:"bytes,9,rep,name=rows,proto3" json:"rows,omitempty" toml:"rows,omitempty" yaml:"rows,omitempty" x_attrs:"rows"`
-	Cols       []*DataCol        `protobuf:"bytes,19,rep,name=cols,proto3" json:"cols,omitempty" toml:"cols,omitempty" yaml:"cols,omitempty" x_attrs:"cols"`
-	Objs       []*structpb.Value `protobuf:"bytes,20,rep,name=objs,proto3" json:"objs,omitempty" toml:"objs,omitempty" yaml:"objs,omitempty"`
-	NextOffset string            `protobuf:"bytes,10,opt,name=next_offset,json=nextOffset,proto3" json:"next_offset,omitempty" toml:"next_offset,omitempty" yaml:"next_offset,omitempty"`
+	InstanceName string            `protobuf:"bytes,2,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty" toml:"instance_name,omitempty" yaml:"instance_name,omitempty" x_attrs:"name_identifier"`
+	TableName    string            `protobuf:"bytes,3,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty" toml:"table_name,omitempty" yaml:"table_name,omitempty" x_attrs:"name_identifier"`
+	Fields       []string          `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty" toml:"fields,omitempty" yaml:"fields,omitempty"`
+	Values       []*structpb.Value `protobuf:"bytes,6,rep,name=values,proto3" json:"values,omitempty" toml:"values,omitempty" yaml:"values,omitempty"`
+	Filter       *DataQuery_Filter `protobuf:"bytes,9,opt,name=filter,proto3" json:"filter,omitempty" toml:"filter,omitempty" yaml:"filter,omitempty"`
+	Operator     string            `protobuf:"bytes,10,opt,name=operator,proto3" json:"operator,omitempty" toml:"operator,omitempty" yaml:"operator,omitempty"`
+	DryRun       bool              `protobuf:"varint,11,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty" toml:"dry_run,omitempty" yaml:"dry_run,omitempty"`
+	// the field paths overwritten, the fields if not set
+	FieldMask []string `protobuf:"bytes,12,rep,name=field_mask,json=fieldMask,proto3" json:"field_mask,omitempty" toml:"field_mask,omitempty" yaml:"field_mask,omitempty"`
 }
 
-func (x *DataResult) Reset() {
-	*x = DataResult{}
+func (x *DataUpdate) Reset() {
+	*x = DataUpdate{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lynkapi_data_proto_msgTypes[12]
+		mi := &file_lynkapi_data_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DataResult) String() string {
+func (x *DataUpdate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DataResult) ProtoMessage() {}
+func (*DataUpdate) ProtoMessage() {}
 
-func (x *DataResult) ProtoReflect() protoreflect.Message {
-	mi := &file_lynkapi_data_proto_msgTypes[12]
+func (x *DataUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1035,101 +1338,97 @@ func (x *DataResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DataResult.ProtoReflect.Descriptor instead.
-func (*DataResult) Descriptor() ([]byte, []int) {
-	return file_lynkapi_data_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use DataUpdate.ProtoReflect.Descriptor instead.
+func (*DataUpdate) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *DataResult) GetKind() string {
+func (x *DataUpdate) GetInstanceName() string {
 	if x != nil {
-		return x.Kind
+		return x.InstanceName
 	}
 	return ""
 }
 
-func (x *DataResult) GetStatus() *ServiceStatus {
-	if x != nil {
-		return x.Status
-	}
-	return nil
-}
-
-func (x *DataResult) GetName() string {
+func (x *DataUpdate) GetTableName() string {
 	if x != nil {
-		return x.Name
+		return x.TableName
 	}
 	return ""
 }
 
-func (x *DataResult) GetSpec() *TableSpec {
+func (x *DataUpdate) GetFields() []string {
 	if x != nil {
-		return x.Spec
+		return x.Fields
 	}
 	return nil
 }
 
-func (x *DataResult) GetStats() *DataResult_Stats {
+func (x *DataUpdate) GetValues() []*structpb.Value {
 	if x != nil {
-		return x.Stats
+		return x.Values
 	}
 	return nil
 }
 
-func (x *DataResult) GetRows() []*DataRow {
+func (x *DataUpdate) GetFilter() *DataQuery_Filter {
 	if x != nil {
-		return x.Rows
+		return x.Filter
 	}
 	return nil
 }
 
-func (x *DataResult) GetCols() []*DataCol {
+func (x *DataUpdate) GetOperator() string {
 	if x != nil {
-		return x.Cols
+		return x.Operator
 	}
-	return nil
+	return ""
 }
 
-func (x *DataResult) GetObjs() []*structpb.Value {
+func (x *DataUpdate) GetDryRun() bool {
 	if x != nil {
-		return x.Objs
+		return x.DryRun
 	}
-	return nil
+	return false
 }
 
-func (x *DataResult) GetNextOffset() string {
+func (x *DataUpdate) GetFieldMask() []string {
 	if x != nil {
-		return x.NextOffset
+		return x.FieldMask
 	}
-	return ""
+	return nil
 }
 
-type DataResults struct {
+type DataDelete struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Kind    string         `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty" toml:"kind,omitempty" yaml:"kind,omitempty"`
-	Status  *ServiceStatus `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty" toml:"status,omitempty" yaml:"status,omitempty"`
-	Results []*DataResult  `protobuf:"bytes,9,rep,name=results,proto3" json:"results,omitempty" toml:"results,omitempty" yaml:"results,omitempty"`
+	InstanceName string            `protobuf:"bytes,2,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty" toml:"instance_name,omitempty" yaml:"instance_name,omitempty" x_attrs:"name_identifier"`
+	TableName    string            `protobuf:"bytes,3,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty" toml:"table_name,omitempty" yaml:"table_name,omitempty" x_attrs:"name_identifier"`
+	Filter       *DataQuery_Filter `protobuf:"bytes,7,opt,name=filter,proto3" json:"filter,omitempty" toml:"filter,omitempty" yaml:"filter,omitempty"`
+	Operator     string            `protobuf:"bytes,9,opt,name=operator,proto3" json:"operator,omitempty" toml:"operator,omitempty" yaml:"operator,omitempty"`
+	Purge        bool              `protobuf:"varint,10,opt,name=purge,proto3" json:"purge,omitempty" toml:"purge,omitempty" yaml:"purge,omitempty"`
+	DryRun       bool              `protobuf:"varint,11,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty" toml:"dry_run,omitempty" yaml:"dry_run,omitempty"`
 }
 
-func (x *DataResults) Reset() {
-	*x = DataResults{}
+func (x *DataDelete) Reset() {
+	*x = DataDelete{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lynkapi_data_proto_msgTypes[13]
+		mi := &file_lynkapi_data_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DataResults) String() string {
+func (x *DataDelete) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DataResults) ProtoMessage() {}
+func (*DataDelete) ProtoMessage() {}
 
-func (x *DataResults) ProtoReflect() protoreflect.Message {
-	mi := &file_lynkapi_data_proto_msgTypes[13]
+func (x *DataDelete) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1140,58 +1439,85 @@ func (x *DataResults) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DataResults.ProtoReflect.Descriptor instead.
-func (*DataResults) Descriptor() ([]byte, []int) {
-	return file_lynkapi_data_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use DataDelete.ProtoReflect.Descriptor instead.
+func (*DataDelete) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *DataResults) GetKind() string {
+func (x *DataDelete) GetInstanceName() string {
 	if x != nil {
-		return x.Kind
+		return x.InstanceName
 	}
 	return ""
 }
 
-func (x *DataResults) GetStatus() *ServiceStatus {
+func (x *DataDelete) GetTableName() string {
 	if x != nil {
-		return x.Status
+		return x.TableName
 	}
-	return nil
+	return ""
 }
 
-func (x *DataResults) GetResults() []*DataResult {
+func (x *DataDelete) GetFilter() *DataQuery_Filter {
 	if x != nil {
-		return x.Results
+		return x.Filter
 	}
 	return nil
 }
 
-type TableSpec_Index struct {
+func (x *DataDelete) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *DataDelete) GetPurge() bool {
+	if x != nil {
+		return x.Purge
+	}
+	return false
+}
+
+func (x *DataDelete) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type DataRestore struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Fields string `protobuf:"bytes,2,opt,name=fields,proto3" json:"fields,omitempty" toml:"fields,omitempty" yaml:"fields,omitempty"`
-	Type   string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty" toml:"type,omitempty" yaml:"type,omitempty"`
+	InstanceName string            `protobuf:"bytes,2,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty" toml:"instance_name,omitempty" yaml:"instance_name,omitempty" x_attrs:"name_identifier"`
+	TableName    string            `protobuf:"bytes,3,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty" toml:"table_name,omitempty" yaml:"table_name,omitempty" x_attrs:"name_identifier"`
+	Filter       *DataQuery_Filter `protobuf:"bytes,7,opt,name=filter,proto3" json:"filter,omitempty" toml:"filter,omitempty" yaml:"filter,omitempty"`
+	Version      uint64            `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty" toml:"version,omitempty" yaml:"version,omitempty"`
+	AsOf         int64             `protobuf:"varint,9,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty" toml:"as_of,omitempty" yaml:"as_of,omitempty"` // unix time in milliseconds
+	Operator     string            `protobuf:"bytes,10,opt,name=operator,proto3" json:"operator,omitempty" toml:"operator,omitempty" yaml:"operator,omitempty"`
+	// validate and return the restored row without commit
+	DryRun bool `protobuf:"varint,11,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty" toml:"dry_run,omitempty" yaml:"dry_run,omitempty"`
 }
 
-func (x *TableSpec_Index) Reset() {
-	*x = TableSpec_Index{}
+func (x *DataRestore) Reset() {
+	*x = DataRestore{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lynkapi_data_proto_msgTypes[16]
+		mi := &file_lynkapi_data_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TableSpec_Index) String() string {
+func (x *DataRestore) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TableSpec_Index) ProtoMessage() {}
+func (*DataRestore) ProtoMessage() {}
 
-func (x *TableSpec_Index) ProtoReflect() protoreflect.Message {
-	mi := &file_lynkapi_data_proto_msgTypes[16]
+func (x *DataRestore) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1202,14 +1528,543 @@ func (x *TableSpec_Index) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TableSpec_Index.ProtoReflect.Descriptor instead.
-func (*TableSpec_Index) Descriptor() ([]byte, []int) {
-	return file_lynkapi_data_proto_rawDescGZIP(), []int{3, 0}
+// Deprecated: Use DataRestore.ProtoReflect.Descriptor instead.
+func (*DataRestore) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *TableSpec_Index) GetFields() string {
+func (x *DataRestore) GetInstanceName() string {
 	if x != nil {
-		return x.Fields
+		return x.InstanceName
+	}
+	return ""
+}
+
+func (x *DataRestore) GetTableName() string {
+	if x != nil {
+		return x.TableName
+	}
+	return ""
+}
+
+func (x *DataRestore) GetFilter() *DataQuery_Filter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *DataRestore) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DataRestore) GetAsOf() int64 {
+	if x != nil {
+		return x.AsOf
+	}
+	return 0
+}
+
+func (x *DataRestore) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *DataRestore) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type DataResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind       string            `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty" toml:"kind,omitempty" yaml:"kind,omitempty"`
+	Status     *ServiceStatus    `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty" toml:"status,omitempty" yaml:"status,omitempty"`
+	Name       string            `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty" toml:"name,omitempty" yaml:"name,omitempty"`
+	Spec       *TableSpec        `protobuf:"bytes,4,opt,name=spec,proto3" json:"spec,omitempty" toml:"spec,omitempty" yaml:"spec,omitempty"`
+	Stats      *DataResult_Stats `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty" toml:"stats,omitempty" yaml:"stats,omitempty"`
+	Rows       []*DataRow        `protobuf:"bytes,9,rep,name=rows,proto3" json:"rows,omitempty" toml:"rows,omitempty" yaml:"rows,omitempty" x_attrs:"rows"`
+	Cols       []*DataCol        `protobuf:"bytes,19,rep,name=cols,proto3" json:"cols,omitempty" toml:"cols,omitempty" yaml:"cols,omitempty" x_attrs:"cols"`
+	Objs       []*structpb.Value `protobuf:"bytes,20,rep,name=objs,proto3" json:"objs,omitempty" toml:"objs,omitempty" yaml:"objs,omitempty"`
+	Changes    []*DataRowChange  `protobuf:"bytes,21,rep,name=changes,proto3" json:"changes,omitempty" toml:"changes,omitempty" yaml:"changes,omitempty"`
+	Plan       *DataQueryPlan    `protobuf:"bytes,22,opt,name=plan,proto3" json:"plan,omitempty" toml:"plan,omitempty" yaml:"plan,omitempty"`
+	NextOffset string            `protobuf:"bytes,10,opt,name=next_offset,json=nextOffset,proto3" json:"next_offset,omitempty" toml:"next_offset,omitempty" yaml:"next_offset,omitempty"`
+
+	// instance and depth of each row of a columnar result, if a row has one
+	RowInstanceNames []string `protobuf:"bytes,23,rep,name=row_instance_names,json=rowInstanceNames,proto3" json:"row_instance_names,omitempty" toml:"row_instance_names,omitempty" yaml:"row_instance_names,omitempty"`
+	RowDepths        []int32  `protobuf:"varint,24,rep,packed,name=row_depths,json=rowDepths,proto3" json:"row_depths,omitempty" toml:"row_depths,omitempty" yaml:"row_depths,omitempty"`
+}
+
+func (x *DataResult) Reset() {
+	*x = DataResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lynkapi_data_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataResult) ProtoMessage() {}
+
+func (x *DataResult) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataResult.ProtoReflect.Descriptor instead.
+func (*DataResult) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DataResult) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DataResult) GetStatus() *ServiceStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *DataResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DataResult) GetSpec() *TableSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *DataResult) GetStats() *DataResult_Stats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *DataResult) GetRows() []*DataRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *DataResult) GetCols() []*DataCol {
+	if x != nil {
+		return x.Cols
+	}
+	return nil
+}
+
+func (x *DataResult) GetObjs() []*structpb.Value {
+	if x != nil {
+		return x.Objs
+	}
+	return nil
+}
+
+func (x *DataResult) GetChanges() []*DataRowChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *DataResult) GetPlan() *DataQueryPlan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+func (x *DataResult) GetRowInstanceNames() []string {
+	if x != nil {
+		return x.RowInstanceNames
+	}
+	return nil
+}
+
+func (x *DataResult) GetRowDepths() []int32 {
+	if x != nil {
+		return x.RowDepths
+	}
+	return nil
+}
+
+func (x *DataResult) GetNextOffset() string {
+	if x != nil {
+		return x.NextOffset
+	}
+	return ""
+}
+
+type DataResults struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind    string         `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty" toml:"kind,omitempty" yaml:"kind,omitempty"`
+	Status  *ServiceStatus `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty" toml:"status,omitempty" yaml:"status,omitempty"`
+	Results []*DataResult  `protobuf:"bytes,9,rep,name=results,proto3" json:"results,omitempty" toml:"results,omitempty" yaml:"results,omitempty"`
+}
+
+func (x *DataResults) Reset() {
+	*x = DataResults{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lynkapi_data_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataResults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataResults) ProtoMessage() {}
+
+func (x *DataResults) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataResults.ProtoReflect.Descriptor instead.
+func (*DataResults) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DataResults) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DataResults) GetStatus() *ServiceStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *DataResults) GetResults() []*DataResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// a backup archive is a sequence of chunks: the first one holds the archive
+// metadata and the spec of every table, the next ones the rows of a table.
+type DataBackupChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind         string         `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty" toml:"kind,omitempty" yaml:"kind,omitempty"`
+	Status       *ServiceStatus `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty" toml:"status,omitempty" yaml:"status,omitempty"`
+	Version      int32          `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty" toml:"version,omitempty" yaml:"version,omitempty"` // archive format version
+	InstanceName string         `protobuf:"bytes,4,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty" toml:"instance_name,omitempty" yaml:"instance_name,omitempty"`
+	Driver       string         `protobuf:"bytes,5,opt,name=driver,proto3" json:"driver,omitempty" toml:"driver,omitempty" yaml:"driver,omitempty"` // driver of the source instance
+	Created      int64          `protobuf:"varint,6,opt,name=created,proto3" json:"created,omitempty" toml:"created,omitempty" yaml:"created,omitempty"` // unix time in milliseconds
+	Specs        []*TableSpec   `protobuf:"bytes,7,rep,name=specs,proto3" json:"specs,omitempty" toml:"specs,omitempty" yaml:"specs,omitempty"`
+	TableName    string         `protobuf:"bytes,8,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty" toml:"table_name,omitempty" yaml:"table_name,omitempty"`
+	Rows         []*DataRow     `protobuf:"bytes,9,rep,name=rows,proto3" json:"rows,omitempty" toml:"rows,omitempty" yaml:"rows,omitempty"`
+}
+
+func (x *DataBackupChunk) Reset() {
+	*x = DataBackupChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lynkapi_data_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataBackupChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataBackupChunk) ProtoMessage() {}
+
+func (x *DataBackupChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataBackupChunk.ProtoReflect.Descriptor instead.
+func (*DataBackupChunk) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DataBackupChunk) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DataBackupChunk) GetStatus() *ServiceStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *DataBackupChunk) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DataBackupChunk) GetInstanceName() string {
+	if x != nil {
+		return x.InstanceName
+	}
+	return ""
+}
+
+func (x *DataBackupChunk) GetDriver() string {
+	if x != nil {
+		return x.Driver
+	}
+	return ""
+}
+
+func (x *DataBackupChunk) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *DataBackupChunk) GetSpecs() []*TableSpec {
+	if x != nil {
+		return x.Specs
+	}
+	return nil
+}
+
+func (x *DataBackupChunk) GetTableName() string {
+	if x != nil {
+		return x.TableName
+	}
+	return ""
+}
+
+func (x *DataBackupChunk) GetRows() []*DataRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type DataBackupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InstanceName string   `protobuf:"bytes,2,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty" toml:"instance_name,omitempty" yaml:"instance_name,omitempty" x_attrs:"name_identifier"`
+	Tables       []string `protobuf:"bytes,3,rep,name=tables,proto3" json:"tables,omitempty" toml:"tables,omitempty" yaml:"tables,omitempty"` // empty for all tables
+}
+
+func (x *DataBackupRequest) Reset() {
+	*x = DataBackupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lynkapi_data_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataBackupRequest) ProtoMessage() {}
+
+func (x *DataBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataBackupRequest.ProtoReflect.Descriptor instead.
+func (*DataBackupRequest) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DataBackupRequest) GetInstanceName() string {
+	if x != nil {
+		return x.InstanceName
+	}
+	return ""
+}
+
+func (x *DataBackupRequest) GetTables() []string {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+type DataBackupReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind   string                    `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty" toml:"kind,omitempty" yaml:"kind,omitempty"`
+	Status *ServiceStatus            `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty" toml:"status,omitempty" yaml:"status,omitempty"`
+	Tables []*DataBackupReport_Table `protobuf:"bytes,9,rep,name=tables,proto3" json:"tables,omitempty" toml:"tables,omitempty" yaml:"tables,omitempty"`
+}
+
+func (x *DataBackupReport) Reset() {
+	*x = DataBackupReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lynkapi_data_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataBackupReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataBackupReport) ProtoMessage() {}
+
+func (x *DataBackupReport) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataBackupReport.ProtoReflect.Descriptor instead.
+func (*DataBackupReport) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DataBackupReport) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DataBackupReport) GetStatus() *ServiceStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *DataBackupReport) GetTables() []*DataBackupReport_Table {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+type TableSpec_Index struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fields string `protobuf:"bytes,2,opt,name=fields,proto3" json:"fields,omitempty" toml:"fields,omitempty" yaml:"fields,omitempty"`
+	Type   string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty" toml:"type,omitempty" yaml:"type,omitempty"`
+}
+
+func (x *TableSpec_Index) Reset() {
+	*x = TableSpec_Index{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lynkapi_data_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TableSpec_Index) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableSpec_Index) ProtoMessage() {}
+
+func (x *TableSpec_Index) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableSpec_Index.ProtoReflect.Descriptor instead.
+func (*TableSpec_Index) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *TableSpec_Index) GetFields() string {
+	if x != nil {
+		return x.Fields
 	}
 	return ""
 }
@@ -1230,12 +2085,13 @@ type DataQuery_Filter struct {
 	Field string              `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty" toml:"field,omitempty" yaml:"field,omitempty"`
 	Value *structpb.Value     `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty" toml:"value,omitempty" yaml:"value,omitempty"`
 	Inner []*DataQuery_Filter `protobuf:"bytes,4,rep,name=inner,proto3" json:"inner,omitempty" toml:"inner,omitempty" yaml:"inner,omitempty"`
+	Op    string              `protobuf:"bytes,5,opt,name=op,proto3" json:"op,omitempty" toml:"op,omitempty" yaml:"op,omitempty" x_enums:",eq,ne,gt,gte,lt,lte,in,like"`
 }
 
 func (x *DataQuery_Filter) Reset() {
 	*x = DataQuery_Filter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lynkapi_data_proto_msgTypes[18]
+		mi := &file_lynkapi_data_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1245,10 +2101,226 @@ func (x *DataQuery_Filter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DataQuery_Filter) ProtoMessage() {}
+func (*DataQuery_Filter) ProtoMessage() {}
+
+func (x *DataQuery_Filter) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataQuery_Filter.ProtoReflect.Descriptor instead.
+func (*DataQuery_Filter) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{10, 0}
+}
+
+func (x *DataQuery_Filter) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DataQuery_Filter) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *DataQuery_Filter) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *DataQuery_Filter) GetInner() []*DataQuery_Filter {
+	if x != nil {
+		return x.Inner
+	}
+	return nil
+}
+
+func (x *DataQuery_Filter) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+type DataQuery_SortFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type  string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty" toml:"type,omitempty" yaml:"type,omitempty" x_enums:",asc,desc"`
+	Field string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty" toml:"field,omitempty" yaml:"field,omitempty"`
+	Value string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty" toml:"value,omitempty" yaml:"value,omitempty"` // TODO repeated SortFilter inner = 4;
+}
+
+func (x *DataQuery_SortFilter) Reset() {
+	*x = DataQuery_SortFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lynkapi_data_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataQuery_SortFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataQuery_SortFilter) ProtoMessage() {}
+
+func (x *DataQuery_SortFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataQuery_SortFilter.ProtoReflect.Descriptor instead.
+func (*DataQuery_SortFilter) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{10, 1}
+}
+
+func (x *DataQuery_SortFilter) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DataQuery_SortFilter) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *DataQuery_SortFilter) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type DataQuery_Tree struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type     string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty" toml:"type,omitempty" yaml:"type,omitempty" x_enums:"children,subtree,ancestors"`
+	Id       string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty" toml:"id,omitempty" yaml:"id,omitempty"` // the node id, empty for the roots
+	Depth    int32  `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty" toml:"depth,omitempty" yaml:"depth,omitempty"` // max depth of the subtree, 0 for unlimited
+	PidField string `protobuf:"bytes,4,opt,name=pid_field,json=pidField,proto3" json:"pid_field,omitempty" toml:"pid_field,omitempty" yaml:"pid_field,omitempty"`
+}
+
+func (x *DataQuery_Tree) Reset() {
+	*x = DataQuery_Tree{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lynkapi_data_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataQuery_Tree) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataQuery_Tree) ProtoMessage() {}
+
+func (x *DataQuery_Tree) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataQuery_Tree.ProtoReflect.Descriptor instead.
+func (*DataQuery_Tree) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{10, 2}
+}
+
+func (x *DataQuery_Tree) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DataQuery_Tree) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DataQuery_Tree) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *DataQuery_Tree) GetPidField() string {
+	if x != nil {
+		return x.PidField
+	}
+	return ""
+}
+
+// joins the rows with the row of another table where join_field equals the
+// value of field, the joined fields are named "<table_name>.<field>".
+type DataQuery_Join struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type         string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty" toml:"type,omitempty" yaml:"type,omitempty" x_enums:"inner,left"`
+	InstanceName string   `protobuf:"bytes,2,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty" toml:"instance_name,omitempty" yaml:"instance_name,omitempty"` // empty for the instance of the row
+	TableName    string   `protobuf:"bytes,3,opt,name=table_name,json=tableName,proto3" json:"table_name,omitempty" toml:"table_name,omitempty" yaml:"table_name,omitempty"`
+	Field        string   `protobuf:"bytes,4,opt,name=field,proto3" json:"field,omitempty" toml:"field,omitempty" yaml:"field,omitempty"`
+	JoinField    string   `protobuf:"bytes,5,opt,name=join_field,json=joinField,proto3" json:"join_field,omitempty" toml:"join_field,omitempty" yaml:"join_field,omitempty"` // empty for the primary field of the table
+	Fields       []string `protobuf:"bytes,6,rep,name=fields,proto3" json:"fields,omitempty" toml:"fields,omitempty" yaml:"fields,omitempty"` // empty for all
+}
+
+func (x *DataQuery_Join) Reset() {
+	*x = DataQuery_Join{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lynkapi_data_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataQuery_Join) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataQuery_Join) ProtoMessage() {}
 
-func (x *DataQuery_Filter) ProtoReflect() protoreflect.Message {
-	mi := &file_lynkapi_data_proto_msgTypes[18]
+func (x *DataQuery_Join) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1259,66 +2331,81 @@ func (x *DataQuery_Filter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DataQuery_Filter.ProtoReflect.Descriptor instead.
-func (*DataQuery_Filter) Descriptor() ([]byte, []int) {
-	return file_lynkapi_data_proto_rawDescGZIP(), []int{8, 0}
+// Deprecated: Use DataQuery_Join.ProtoReflect.Descriptor instead.
+func (*DataQuery_Join) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{10, 3}
 }
 
-func (x *DataQuery_Filter) GetType() string {
+func (x *DataQuery_Join) GetType() string {
 	if x != nil {
 		return x.Type
 	}
 	return ""
 }
 
-func (x *DataQuery_Filter) GetField() string {
+func (x *DataQuery_Join) GetInstanceName() string {
+	if x != nil {
+		return x.InstanceName
+	}
+	return ""
+}
+
+func (x *DataQuery_Join) GetTableName() string {
+	if x != nil {
+		return x.TableName
+	}
+	return ""
+}
+
+func (x *DataQuery_Join) GetField() string {
 	if x != nil {
 		return x.Field
 	}
 	return ""
 }
 
-func (x *DataQuery_Filter) GetValue() *structpb.Value {
+func (x *DataQuery_Join) GetJoinField() string {
 	if x != nil {
-		return x.Value
+		return x.JoinField
 	}
-	return nil
+	return ""
 }
 
-func (x *DataQuery_Filter) GetInner() []*DataQuery_Filter {
+func (x *DataQuery_Join) GetFields() []string {
 	if x != nil {
-		return x.Inner
+		return x.Fields
 	}
 	return nil
 }
 
-type DataQuery_SortFilter struct {
+type DataQueryPlan_Stage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type  string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty" toml:"type,omitempty" yaml:"type,omitempty" x_enums:",asc,desc"`
-	Field string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty" toml:"field,omitempty" yaml:"field,omitempty"`
-	Value string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty" toml:"value,omitempty" yaml:"value,omitempty"` // TODO repeated SortFilter inner = 4;
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty" toml:"name,omitempty" yaml:"name,omitempty"`
+	Detail string `protobuf:"bytes,2,opt,name=detail,proto3" json:"detail,omitempty" toml:"detail,omitempty" yaml:"detail,omitempty"`
+	Rows   int64  `protobuf:"varint,3,opt,name=rows,proto3" json:"rows,omitempty" toml:"rows,omitempty" yaml:"rows,omitempty"` // rows out of the stage
+	TimeUs int64  `protobuf:"varint,4,opt,name=time_us,json=timeUs,proto3" json:"time_us,omitempty" toml:"time_us,omitempty" yaml:"time_us,omitempty"` // time in microseconds
 }
 
-func (x *DataQuery_SortFilter) Reset() {
-	*x = DataQuery_SortFilter{}
+func (x *DataQueryPlan_Stage) Reset() {
+	*x = DataQueryPlan_Stage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lynkapi_data_proto_msgTypes[19]
+		mi := &file_lynkapi_data_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DataQuery_SortFilter) String() string {
+func (x *DataQueryPlan_Stage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DataQuery_SortFilter) ProtoMessage() {}
+func (*DataQueryPlan_Stage) ProtoMessage() {}
 
-func (x *DataQuery_SortFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_lynkapi_data_proto_msgTypes[19]
+func (x *DataQueryPlan_Stage) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1329,30 +2416,37 @@ func (x *DataQuery_SortFilter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DataQuery_SortFilter.ProtoReflect.Descriptor instead.
-func (*DataQuery_SortFilter) Descriptor() ([]byte, []int) {
-	return file_lynkapi_data_proto_rawDescGZIP(), []int{8, 1}
+// Deprecated: Use DataQueryPlan_Stage.ProtoReflect.Descriptor instead.
+func (*DataQueryPlan_Stage) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{11, 0}
 }
 
-func (x *DataQuery_SortFilter) GetType() string {
+func (x *DataQueryPlan_Stage) GetName() string {
 	if x != nil {
-		return x.Type
+		return x.Name
 	}
 	return ""
 }
 
-func (x *DataQuery_SortFilter) GetField() string {
+func (x *DataQueryPlan_Stage) GetDetail() string {
 	if x != nil {
-		return x.Field
+		return x.Detail
 	}
 	return ""
 }
 
-func (x *DataQuery_SortFilter) GetValue() string {
+func (x *DataQueryPlan_Stage) GetRows() int64 {
 	if x != nil {
-		return x.Value
+		return x.Rows
 	}
-	return ""
+	return 0
+}
+
+func (x *DataQueryPlan_Stage) GetTimeUs() int64 {
+	if x != nil {
+		return x.TimeUs
+	}
+	return 0
 }
 
 type DataResult_Stats struct {
@@ -1369,7 +2463,7 @@ type DataResult_Stats struct {
 func (x *DataResult_Stats) Reset() {
 	*x = DataResult_Stats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_lynkapi_data_proto_msgTypes[20]
+		mi := &file_lynkapi_data_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1382,7 +2476,7 @@ func (x *DataResult_Stats) String() string {
 func (*DataResult_Stats) ProtoMessage() {}
 
 func (x *DataResult_Stats) ProtoReflect() protoreflect.Message {
-	mi := &file_lynkapi_data_proto_msgTypes[20]
+	mi := &file_lynkapi_data_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1395,7 +2489,7 @@ func (x *DataResult_Stats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DataResult_Stats.ProtoReflect.Descriptor instead.
 func (*DataResult_Stats) Descriptor() ([]byte, []int) {
-	return file_lynkapi_data_proto_rawDescGZIP(), []int{12, 0}
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{16, 0}
 }
 
 func (x *DataResult_Stats) GetRowsReturned() int32 {
@@ -1426,6 +2520,61 @@ func (x *DataResult_Stats) GetLimit() int32 {
 	return 0
 }
 
+type DataBackupReport_Table struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty" toml:"name,omitempty" yaml:"name,omitempty"`
+	Rows int64  `protobuf:"varint,2,opt,name=rows,proto3" json:"rows,omitempty" toml:"rows,omitempty" yaml:"rows,omitempty"`
+}
+
+func (x *DataBackupReport_Table) Reset() {
+	*x = DataBackupReport_Table{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lynkapi_data_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataBackupReport_Table) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataBackupReport_Table) ProtoMessage() {}
+
+func (x *DataBackupReport_Table) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_data_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataBackupReport_Table.ProtoReflect.Descriptor instead.
+func (*DataBackupReport_Table) Descriptor() ([]byte, []int) {
+	return file_lynkapi_data_proto_rawDescGZIP(), []int{20, 0}
+}
+
+func (x *DataBackupReport_Table) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DataBackupReport_Table) GetRows() int64 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
 var File_lynkapi_data_proto protoreflect.FileDescriptor
 
 var file_lynkapi_data_proto_rawDesc = []byte{
@@ -1459,7 +2608,7 @@ var file_lynkapi_data_proto_rawDesc = []byte{
 	0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
 	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
 	0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
-	0x01, 0x22, 0xf3, 0x01, 0x0a, 0x07, 0x44, 0x61, 0x74, 0x61, 0x52, 0x6f, 0x77, 0x12, 0x0e, 0x0a,
+	0x01, 0x22, 0xb8, 0x03, 0x0a, 0x07, 0x44, 0x61, 0x74, 0x61, 0x52, 0x6f, 0x77, 0x12, 0x0e, 0x0a,
 	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2e, 0x0a,
 	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
@@ -1467,124 +2616,242 @@ var file_lynkapi_data_proto_rawDesc = []byte{
 	0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
 	0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x6f, 0x77, 0x2e,
 	0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x66, 0x69, 0x65,
-	0x6c, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x6f, 0x72, 0x64,
-	0x65, 0x72, 0x18, 0x20, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x4f,
-	0x72, 0x64, 0x65, 0x72, 0x1a, 0x51, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb9, 0x01, 0x0a, 0x07, 0x44, 0x61, 0x74, 0x61,
-	0x43, 0x6f, 0x6c, 0x12, 0x24, 0x0a, 0x0e, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x5f,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x62, 0x61, 0x73,
-	0x65, 0x49, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e, 0x74,
-	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x03, 0x52, 0x09, 0x69,
-	0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x74, 0x72, 0x69,
-	0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x0c, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x21, 0x0a,
-	0x0c, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x0b, 0x20,
-	0x03, 0x28, 0x0c, 0x52, 0x0b, 0x62, 0x79, 0x74, 0x65, 0x73, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73,
-	0x12, 0x21, 0x0a, 0x0c, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
-	0x18, 0x0d, 0x20, 0x03, 0x28, 0x02, 0x52, 0x0b, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x73, 0x22, 0xc5, 0x03, 0x0a, 0x09, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x70, 0x65,
-	0x63, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x2a, 0x0a, 0x06, 0x66, 0x69, 0x65,
-	0x6c, 0x64, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6c, 0x79, 0x6e, 0x6b,
-	0x61, 0x70, 0x69, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x70, 0x65, 0x63, 0x52, 0x06, 0x66,
-	0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79,
-	0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x70,
-	0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x32, 0x0a, 0x07,
-	0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e,
-	0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x70, 0x65,
-	0x63, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x07, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73,
-	0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66,
-	0x69, 0x78, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78,
-	0x12, 0x39, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x1f, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x61, 0x62, 0x6c,
-	0x65, 0x53, 0x70, 0x65, 0x63, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x09, 0x64,
-	0x65, 0x6d, 0x6f, 0x5f, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
-	0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x6f, 0x77,
-	0x52, 0x08, 0x64, 0x65, 0x6d, 0x6f, 0x52, 0x6f, 0x77, 0x73, 0x1a, 0x33, 0x0a, 0x05, 0x49, 0x6e,
-	0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74,
-	0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x1a,
-	0x3a, 0x0a, 0x0c, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x62, 0x0a, 0x08, 0x44,
-	0x61, 0x74, 0x61, 0x53, 0x70, 0x65, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65,
-	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x12,
-	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
-	0x79, 0x70, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x09, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x61,
-	0x62, 0x6c, 0x65, 0x53, 0x70, 0x65, 0x63, 0x52, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x22,
-	0x76, 0x0a, 0x0b, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x18,
-	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08,
-	0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x65,
-	0x73, 0x73, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63,
-	0x63, 0x65, 0x73, 0x73, 0x4b, 0x65, 0x79, 0x22, 0xa1, 0x01, 0x0a, 0x0c, 0x44, 0x61, 0x74, 0x61,
-	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e,
-	0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x12, 0x25, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x09, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74,
-	0x61, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x22, 0x56, 0x0a, 0x0b, 0x44,
-	0x61, 0x74, 0x61, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x33,
-	0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x15, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61,
-	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
-	0x63, 0x65, 0x73, 0x22, 0xdd, 0x03, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72,
-	0x79, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
-	0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x61, 0x62, 0x6c,
-	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18,
-	0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x31, 0x0a,
-	0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
-	0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72,
-	0x79, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x12, 0x31, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x6c, 0x64, 0x73, 0x12, 0x47, 0x0a, 0x0d, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x79, 0x6e,
+	0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x6f, 0x77, 0x2e, 0x44, 0x69, 0x73,
+	0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c,
+	0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x65, 0x70,
+	0x74, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x20, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x1a, 0x51, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3f, 0x0a, 0x11, 0x44,
+	0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x8f, 0x01, 0x0a,
+	0x0f, 0x44, 0x61, 0x74, 0x61, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x12, 0x33, 0x0a, 0x09, 0x6f, 0x6c, 0x64, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x08, 0x6f, 0x6c, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x33, 0x0a, 0x09, 0x6e, 0x65, 0x77,
+	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xe9,
+	0x02, 0x0a, 0x0d, 0x44, 0x61, 0x74, 0x61, 0x52, 0x6f, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3a, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70,
+	0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x6f, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x73, 0x12, 0x32, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x0a,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44,
+	0x61, 0x74, 0x61, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x1a, 0x51, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xf3, 0x02, 0x0a, 0x07, 0x44,
+	0x61, 0x74, 0x61, 0x43, 0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x2e, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x6e, 0x75, 0x6c, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0d, 0x52,
+	0x05, 0x6e, 0x75, 0x6c, 0x6c, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x69,
+	0x6e, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c,
+	0x62, 0x61, 0x73, 0x65, 0x49, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x69, 0x6e, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x03,
+	0x52, 0x09, 0x69, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0c, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x18, 0x0b, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0b, 0x62, 0x79, 0x74, 0x65, 0x73, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x02, 0x52, 0x0b, 0x66, 0x6c, 0x6f, 0x61, 0x74,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65,
+	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0c, 0x64,
+	0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x64,
+	0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x73,
+	0x22, 0xc5, 0x03, 0x0a, 0x09, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x70, 0x65, 0x63, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x2a, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69,
+	0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x70, 0x65, 0x63, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x72, 0x69, 0x6d,
+	0x61, 0x72, 0x79, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x32, 0x0a, 0x07, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6c, 0x79, 0x6e,
+	0x6b, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x70, 0x65, 0x63, 0x2e, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x52, 0x07, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65,
+	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x39, 0x0a,
+	0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x70,
+	0x65, 0x63, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x09, 0x64, 0x65, 0x6d, 0x6f,
+	0x5f, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6c, 0x79,
+	0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x6f, 0x77, 0x52, 0x08, 0x64,
+	0x65, 0x6d, 0x6f, 0x52, 0x6f, 0x77, 0x73, 0x1a, 0x33, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x1a, 0x3a, 0x0a, 0x0c,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x62, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61,
+	0x53, 0x70, 0x65, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x2a, 0x0a, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x53, 0x70, 0x65, 0x63, 0x52, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x22, 0x76, 0x0a, 0x0b,
+	0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x4b, 0x65, 0x79, 0x22, 0xa1, 0x01, 0x0a, 0x0c, 0x44, 0x61, 0x74, 0x61, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x2e, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74,
+	0x61, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x12, 0x25, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x70,
+	0x65, 0x63, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x22, 0x56, 0x0a, 0x0b, 0x44, 0x61, 0x74, 0x61,
+	0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x33, 0x0a, 0x09, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x49, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x22, 0x99, 0x08, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x23,
+	0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x31, 0x0a, 0x06, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x79, 0x6e,
+	0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x31, 0x0a,
+	0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6c, 0x79,
+	0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e,
+	0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x13,
+	0x0a, 0x05, 0x61, 0x73, 0x5f, 0x6f, 0x66, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x61,
+	0x73, 0x4f, 0x66, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x72, 0x61, 0x73, 0x68, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x74, 0x72,
+	0x61, 0x73, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x63, 0x74, 0x5f, 0x64, 0x69, 0x73, 0x70,
+	0x6c, 0x61, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x64, 0x69, 0x63, 0x74, 0x44,
+	0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x12, 0x2b, 0x0a, 0x04, 0x74, 0x72, 0x65, 0x65, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44,
+	0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x52, 0x04, 0x74,
+	0x72, 0x65, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x04, 0x6a, 0x6f,
+	0x69, 0x6e, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61,
+	0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x4a, 0x6f, 0x69,
+	0x6e, 0x52, 0x04, 0x6a, 0x6f, 0x69, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x70, 0x6c, 0x61,
+	0x69, 0x6e, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x78, 0x70, 0x6c, 0x61, 0x69,
+	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x61, 0x72, 0x18, 0x14, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x61, 0x72, 0x1a, 0xa1, 0x01,
+	0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x2f, 0x0a, 0x05, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x05, 0x69, 0x6e, 0x6e, 0x65,
+	0x72, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f,
+	0x70, 0x1a, 0x4c, 0x0a, 0x0a, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a,
+	0x5d, 0x0a, 0x04, 0x54, 0x72, 0x65, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x64,
+	0x65, 0x70, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x65, 0x70, 0x74,
+	0x68, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x69, 0x64, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x69, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x1a, 0xab,
+	0x01, 0x0a, 0x04, 0x4a, 0x6f, 0x69, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6a, 0x6f, 0x69, 0x6e, 0x5f, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6a, 0x6f, 0x69, 0x6e, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0xd0, 0x02, 0x0a,
+	0x0d, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x6f, 0x77, 0x73, 0x5f, 0x65, 0x78, 0x61,
+	0x6d, 0x69, 0x6e, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x72, 0x6f, 0x77,
+	0x73, 0x45, 0x78, 0x61, 0x6d, 0x69, 0x6e, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x6f, 0x77,
+	0x73, 0x5f, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x72, 0x6f, 0x77, 0x73, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x65, 0x64, 0x12, 0x34,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x67, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c,
 	0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x04, 0x73,
-	0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x08, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c,
-	0x69, 0x6d, 0x69, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
-	0x74, 0x1a, 0x91, 0x01, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x04, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61,
-	0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x05,
-	0x69, 0x6e, 0x6e, 0x65, 0x72, 0x1a, 0x4c, 0x0a, 0x0a, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x6c,
-	0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x22, 0x98, 0x01, 0x0a, 0x0a, 0x44, 0x61, 0x74, 0x61, 0x49, 0x6e, 0x73, 0x65,
-	0x72, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61,
-	0x6e, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65,
-	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x61, 0x62,
-	0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73,
-	0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x2e,
-	0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0xcb,
-	0x01, 0x0a, 0x0a, 0x44, 0x61, 0x74, 0x61, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a,
+	0x72, 0x79, 0x50, 0x6c, 0x61, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x67, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x75, 0x73, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x69, 0x6d, 0x65, 0x55, 0x73, 0x1a, 0x60, 0x0a,
+	0x05, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x75,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x69, 0x6d, 0x65, 0x55, 0x73, 0x22,
+	0xec, 0x01, 0x0a, 0x0a, 0x44, 0x61, 0x74, 0x61, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x12, 0x23,
+	0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x2e, 0x0a, 0x06, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75,
+	0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x12,
+	0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x0a, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x9f,
+	0x02, 0x0a, 0x0a, 0x44, 0x61, 0x74, 0x61, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a,
 	0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4e, 0x61,
 	0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
@@ -1596,56 +2863,129 @@ var file_lynkapi_data_proto_rawDesc = []byte{
 	0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x31, 0x0a, 0x06, 0x66, 0x69, 0x6c,
 	0x74, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x79, 0x6e, 0x6b,
 	0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x83, 0x01, 0x0a,
-	0x0a, 0x44, 0x61, 0x74, 0x61, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x69,
-	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
-	0x31, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x19, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75,
-	0x65, 0x72, 0x79, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x22, 0xcd, 0x03, 0x0a, 0x0a, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x75, 0x6c,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x04, 0x73, 0x70, 0x65,
-	0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70,
-	0x69, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x73, 0x70, 0x65,
-	0x63, 0x12, 0x2f, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x19, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52,
-	0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61,
-	0x74, 0x73, 0x12, 0x24, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x10, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52,
-	0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x24, 0x0a, 0x04, 0x63, 0x6f, 0x6c, 0x73,
-	0x18, 0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69,
-	0x2e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x6c, 0x52, 0x04, 0x63, 0x6f, 0x6c, 0x73, 0x12, 0x2a,
-	0x0a, 0x04, 0x6f, 0x62, 0x6a, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x52, 0x04, 0x6f, 0x62, 0x6a, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65,
-	0x78, 0x74, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0a, 0x6e, 0x65, 0x78, 0x74, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x1a, 0x75, 0x0a, 0x05, 0x53,
-	0x74, 0x61, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x6f, 0x77, 0x73, 0x5f, 0x72, 0x65, 0x74,
-	0x75, 0x72, 0x6e, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x72, 0x6f, 0x77,
-	0x73, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x6f, 0x77,
-	0x73, 0x5f, 0x68, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x6f, 0x77,
-	0x73, 0x48, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05,
-	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d,
-	0x69, 0x74, 0x22, 0x80, 0x01, 0x0a, 0x0b, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x75, 0x6c,
-	0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69,
-	0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2d, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70,
-	0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x73, 0x42, 0x30, 0x48, 0x03, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x79, 0x6e, 0x6b, 0x64, 0x62, 0x2f, 0x6c, 0x79, 0x6e,
-	0x6b, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x6f, 0x2f, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x3b,
-	0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f,
+	0x72, 0x75, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75,
+	0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18,
+	0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b,
+	0x22, 0xce, 0x01, 0x0a, 0x0a, 0x44, 0x61, 0x74, 0x61, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12,
+	0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61,
+	0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x75, 0x72, 0x67, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x70, 0x75, 0x72, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f,
+	0x72, 0x75, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75,
+	0x6e, 0x22, 0xe8, 0x01, 0x0a, 0x0b, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x13, 0x0a, 0x05, 0x61, 0x73, 0x5f, 0x6f, 0x66, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x61, 0x73, 0x4f, 0x66, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x22, 0xf8, 0x04, 0x0a,
+	0x0a, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12,
+	0x2e, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x61, 0x62, 0x6c,
+	0x65, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x12, 0x2f, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6c, 0x79, 0x6e,
+	0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x04,
+	0x72, 0x6f, 0x77, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6c, 0x79, 0x6e,
+	0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f,
+	0x77, 0x73, 0x12, 0x24, 0x0a, 0x04, 0x63, 0x6f, 0x6c, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x43,
+	0x6f, 0x6c, 0x52, 0x04, 0x63, 0x6f, 0x6c, 0x73, 0x12, 0x2a, 0x0a, 0x04, 0x6f, 0x62, 0x6a, 0x73,
+	0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x04,
+	0x6f, 0x62, 0x6a, 0x73, 0x12, 0x30, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18,
+	0x15, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x6f, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x04, 0x70, 0x6c, 0x61, 0x6e, 0x18, 0x16,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44,
+	0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x04, 0x70, 0x6c,
+	0x61, 0x6e, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x6f, 0x77, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x17, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10,
+	0x72, 0x6f, 0x77, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x6f, 0x77, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x73, 0x18, 0x18,
+	0x20, 0x03, 0x28, 0x05, 0x52, 0x09, 0x72, 0x6f, 0x77, 0x44, 0x65, 0x70, 0x74, 0x68, 0x73, 0x12,
+	0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x1a, 0x75, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x6f, 0x77,
+	0x73, 0x5f, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0c, 0x72, 0x6f, 0x77, 0x73, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x65, 0x64, 0x12, 0x19,
+	0x0a, 0x08, 0x72, 0x6f, 0x77, 0x73, 0x5f, 0x68, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x72, 0x6f, 0x77, 0x73, 0x48, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x80, 0x01, 0x0a, 0x0b, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x79,
+	0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2d, 0x0a, 0x07, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6c,
+	0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0xb5, 0x02, 0x0a, 0x0f, 0x44,
+	0x61, 0x74, 0x61, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12,
+	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x63, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x61, 0x62,
+	0x6c, 0x65, 0x53, 0x70, 0x65, 0x63, 0x52, 0x05, 0x73, 0x70, 0x65, 0x63, 0x73, 0x12, 0x1d, 0x0a,
+	0x0a, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x04,
+	0x72, 0x6f, 0x77, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6c, 0x79, 0x6e,
+	0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f,
+	0x77, 0x73, 0x22, 0x50, 0x0a, 0x11, 0x44, 0x61, 0x74, 0x61, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61,
+	0x62, 0x6c, 0x65, 0x73, 0x22, 0xc0, 0x01, 0x0a, 0x10, 0x44, 0x61, 0x74, 0x61, 0x42, 0x61, 0x63,
+	0x6b, 0x75, 0x70, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x2e, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x37, 0x0a,
+	0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x42, 0x61, 0x63, 0x6b,
+	0x75, 0x70, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x06,
+	0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x1a, 0x2f, 0x0a, 0x05, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x42, 0x30, 0x48, 0x03, 0x5a, 0x2c, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x79, 0x6e, 0x6b, 0x64, 0x62, 0x2f, 0x6c,
+	0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x6f, 0x2f, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70,
+	0x69, 0x3b, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -1660,68 +3000,99 @@ func file_lynkapi_data_proto_rawDescGZIP() []byte {
 	return file_lynkapi_data_proto_rawDescData
 }
 
-var file_lynkapi_data_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_lynkapi_data_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_lynkapi_data_proto_goTypes = []interface{}{
-	(*DataDict)(nil),             // 0: lynkapi.DataDict
-	(*DataRow)(nil),              // 1: lynkapi.DataRow
-	(*DataCol)(nil),              // 2: lynkapi.DataCol
-	(*TableSpec)(nil),            // 3: lynkapi.TableSpec
-	(*DataSpec)(nil),             // 4: lynkapi.DataSpec
-	(*DataConnect)(nil),          // 5: lynkapi.DataConnect
-	(*DataInstance)(nil),         // 6: lynkapi.DataInstance
-	(*DataProject)(nil),          // 7: lynkapi.DataProject
-	(*DataQuery)(nil),            // 8: lynkapi.DataQuery
-	(*DataInsert)(nil),           // 9: lynkapi.DataInsert
-	(*DataUpdate)(nil),           // 10: lynkapi.DataUpdate
-	(*DataDelete)(nil),           // 11: lynkapi.DataDelete
-	(*DataResult)(nil),           // 12: lynkapi.DataResult
-	(*DataResults)(nil),          // 13: lynkapi.DataResults
-	nil,                          // 14: lynkapi.DataDict.ExtFieldsEntry
-	nil,                          // 15: lynkapi.DataRow.FieldsEntry
-	(*TableSpec_Index)(nil),      // 16: lynkapi.TableSpec.Index
-	nil,                          // 17: lynkapi.TableSpec.OptionsEntry
-	(*DataQuery_Filter)(nil),     // 18: lynkapi.DataQuery.Filter
-	(*DataQuery_SortFilter)(nil), // 19: lynkapi.DataQuery.SortFilter
-	(*DataResult_Stats)(nil),     // 20: lynkapi.DataResult.Stats
-	(*structpb.Value)(nil),       // 21: google.protobuf.Value
-	(*FieldSpec)(nil),            // 22: lynkapi.FieldSpec
-	(*ServiceStatus)(nil),        // 23: lynkapi.ServiceStatus
+	(*DataDict)(nil),               // 0: lynkapi.DataDict
+	(*DataRow)(nil),                // 1: lynkapi.DataRow
+	(*DataFieldChange)(nil),        // 2: lynkapi.DataFieldChange
+	(*DataRowChange)(nil),          // 3: lynkapi.DataRowChange
+	(*DataCol)(nil),                // 4: lynkapi.DataCol
+	(*TableSpec)(nil),              // 5: lynkapi.TableSpec
+	(*DataSpec)(nil),               // 6: lynkapi.DataSpec
+	(*DataConnect)(nil),            // 7: lynkapi.DataConnect
+	(*DataInstance)(nil),           // 8: lynkapi.DataInstance
+	(*DataProject)(nil),            // 9: lynkapi.DataProject
+	(*DataQuery)(nil),              // 10: lynkapi.DataQuery
+	(*DataQueryPlan)(nil),          // 11: lynkapi.DataQueryPlan
+	(*DataInsert)(nil),             // 12: lynkapi.DataInsert
+	(*DataUpdate)(nil),             // 13: lynkapi.DataUpdate
+	(*DataDelete)(nil),             // 14: lynkapi.DataDelete
+	(*DataRestore)(nil),            // 15: lynkapi.DataRestore
+	(*DataResult)(nil),             // 16: lynkapi.DataResult
+	(*DataResults)(nil),            // 17: lynkapi.DataResults
+	(*DataBackupChunk)(nil),        // 18: lynkapi.DataBackupChunk
+	(*DataBackupRequest)(nil),      // 19: lynkapi.DataBackupRequest
+	(*DataBackupReport)(nil),       // 20: lynkapi.DataBackupReport
+	nil,                            // 21: lynkapi.DataDict.ExtFieldsEntry
+	nil,                            // 22: lynkapi.DataRow.FieldsEntry
+	nil,                            // 23: lynkapi.DataRow.DisplayNamesEntry
+	nil,                            // 24: lynkapi.DataRowChange.FieldsEntry
+	(*TableSpec_Index)(nil),        // 25: lynkapi.TableSpec.Index
+	nil,                            // 26: lynkapi.TableSpec.OptionsEntry
+	(*DataQuery_Filter)(nil),       // 27: lynkapi.DataQuery.Filter
+	(*DataQuery_SortFilter)(nil),   // 28: lynkapi.DataQuery.SortFilter
+	(*DataQuery_Tree)(nil),         // 29: lynkapi.DataQuery.Tree
+	(*DataQuery_Join)(nil),         // 30: lynkapi.DataQuery.Join
+	(*DataQueryPlan_Stage)(nil),    // 31: lynkapi.DataQueryPlan.Stage
+	(*DataResult_Stats)(nil),       // 32: lynkapi.DataResult.Stats
+	(*DataBackupReport_Table)(nil), // 33: lynkapi.DataBackupReport.Table
+	(*structpb.Value)(nil),         // 34: google.protobuf.Value
+	(*FieldSpec)(nil),              // 35: lynkapi.FieldSpec
+	(*ServiceStatus)(nil),          // 36: lynkapi.ServiceStatus
 }
 var file_lynkapi_data_proto_depIdxs = []int32{
-	14, // 0: lynkapi.DataDict.ext_fields:type_name -> lynkapi.DataDict.ExtFieldsEntry
-	21, // 1: lynkapi.DataRow.values:type_name -> google.protobuf.Value
-	15, // 2: lynkapi.DataRow.fields:type_name -> lynkapi.DataRow.FieldsEntry
-	22, // 3: lynkapi.TableSpec.fields:type_name -> lynkapi.FieldSpec
-	16, // 4: lynkapi.TableSpec.indexes:type_name -> lynkapi.TableSpec.Index
-	17, // 5: lynkapi.TableSpec.options:type_name -> lynkapi.TableSpec.OptionsEntry
-	1,  // 6: lynkapi.TableSpec.demo_rows:type_name -> lynkapi.DataRow
-	3,  // 7: lynkapi.DataSpec.tables:type_name -> lynkapi.TableSpec
-	5,  // 8: lynkapi.DataInstance.connect:type_name -> lynkapi.DataConnect
-	4,  // 9: lynkapi.DataInstance.spec:type_name -> lynkapi.DataSpec
-	6,  // 10: lynkapi.DataProject.instances:type_name -> lynkapi.DataInstance
-	18, // 11: lynkapi.DataQuery.filter:type_name -> lynkapi.DataQuery.Filter
-	19, // 12: lynkapi.DataQuery.sort:type_name -> lynkapi.DataQuery.SortFilter
-	21, // 13: lynkapi.DataInsert.values:type_name -> google.protobuf.Value
-	21, // 14: lynkapi.DataUpdate.values:type_name -> google.protobuf.Value
-	18, // 15: lynkapi.DataUpdate.filter:type_name -> lynkapi.DataQuery.Filter
-	18, // 16: lynkapi.DataDelete.filter:type_name -> lynkapi.DataQuery.Filter
-	23, // 17: lynkapi.DataResult.status:type_name -> lynkapi.ServiceStatus
-	3,  // 18: lynkapi.DataResult.spec:type_name -> lynkapi.TableSpec
-	20, // 19: lynkapi.DataResult.stats:type_name -> lynkapi.DataResult.Stats
-	1,  // 20: lynkapi.DataResult.rows:type_name -> lynkapi.DataRow
-	2,  // 21: lynkapi.DataResult.cols:type_name -> lynkapi.DataCol
-	21, // 22: lynkapi.DataResult.objs:type_name -> google.protobuf.Value
-	23, // 23: lynkapi.DataResults.status:type_name -> lynkapi.ServiceStatus
-	12, // 24: lynkapi.DataResults.results:type_name -> lynkapi.DataResult
-	21, // 25: lynkapi.DataDict.ExtFieldsEntry.value:type_name -> google.protobuf.Value
-	21, // 26: lynkapi.DataRow.FieldsEntry.value:type_name -> google.protobuf.Value
-	21, // 27: lynkapi.DataQuery.Filter.value:type_name -> google.protobuf.Value
-	18, // 28: lynkapi.DataQuery.Filter.inner:type_name -> lynkapi.DataQuery.Filter
-	29, // [29:29] is the sub-list for method output_type
-	29, // [29:29] is the sub-list for method input_type
-	29, // [29:29] is the sub-list for extension type_name
-	29, // [29:29] is the sub-list for extension extendee
-	0,  // [0:29] is the sub-list for field type_name
+	21, // 0: lynkapi.DataDict.ext_fields:type_name -> lynkapi.DataDict.ExtFieldsEntry
+	34, // 1: lynkapi.DataRow.values:type_name -> google.protobuf.Value
+	22, // 2: lynkapi.DataRow.fields:type_name -> lynkapi.DataRow.FieldsEntry
+	23, // 3: lynkapi.DataRow.display_names:type_name -> lynkapi.DataRow.DisplayNamesEntry
+	34, // 4: lynkapi.DataFieldChange.old_value:type_name -> google.protobuf.Value
+	34, // 5: lynkapi.DataFieldChange.new_value:type_name -> google.protobuf.Value
+	24, // 6: lynkapi.DataRowChange.fields:type_name -> lynkapi.DataRowChange.FieldsEntry
+	2,  // 7: lynkapi.DataRowChange.changes:type_name -> lynkapi.DataFieldChange
+	34, // 8: lynkapi.DataCol.values:type_name -> google.protobuf.Value
+	35, // 9: lynkapi.TableSpec.fields:type_name -> lynkapi.FieldSpec
+	25, // 10: lynkapi.TableSpec.indexes:type_name -> lynkapi.TableSpec.Index
+	26, // 11: lynkapi.TableSpec.options:type_name -> lynkapi.TableSpec.OptionsEntry
+	1,  // 12: lynkapi.TableSpec.demo_rows:type_name -> lynkapi.DataRow
+	5,  // 13: lynkapi.DataSpec.tables:type_name -> lynkapi.TableSpec
+	7,  // 14: lynkapi.DataInstance.connect:type_name -> lynkapi.DataConnect
+	6,  // 15: lynkapi.DataInstance.spec:type_name -> lynkapi.DataSpec
+	8,  // 16: lynkapi.DataProject.instances:type_name -> lynkapi.DataInstance
+	27, // 17: lynkapi.DataQuery.filter:type_name -> lynkapi.DataQuery.Filter
+	28, // 18: lynkapi.DataQuery.sort:type_name -> lynkapi.DataQuery.SortFilter
+	29, // 19: lynkapi.DataQuery.tree:type_name -> lynkapi.DataQuery.Tree
+	30, // 20: lynkapi.DataQuery.join:type_name -> lynkapi.DataQuery.Join
+	31, // 21: lynkapi.DataQueryPlan.stages:type_name -> lynkapi.DataQueryPlan.Stage
+	34, // 22: lynkapi.DataInsert.values:type_name -> google.protobuf.Value
+	34, // 23: lynkapi.DataUpdate.values:type_name -> google.protobuf.Value
+	27, // 24: lynkapi.DataUpdate.filter:type_name -> lynkapi.DataQuery.Filter
+	27, // 25: lynkapi.DataDelete.filter:type_name -> lynkapi.DataQuery.Filter
+	27, // 26: lynkapi.DataRestore.filter:type_name -> lynkapi.DataQuery.Filter
+	36, // 27: lynkapi.DataResult.status:type_name -> lynkapi.ServiceStatus
+	5,  // 28: lynkapi.DataResult.spec:type_name -> lynkapi.TableSpec
+	32, // 29: lynkapi.DataResult.stats:type_name -> lynkapi.DataResult.Stats
+	1,  // 30: lynkapi.DataResult.rows:type_name -> lynkapi.DataRow
+	4,  // 31: lynkapi.DataResult.cols:type_name -> lynkapi.DataCol
+	34, // 32: lynkapi.DataResult.objs:type_name -> google.protobuf.Value
+	3,  // 33: lynkapi.DataResult.changes:type_name -> lynkapi.DataRowChange
+	11, // 34: lynkapi.DataResult.plan:type_name -> lynkapi.DataQueryPlan
+	36, // 35: lynkapi.DataResults.status:type_name -> lynkapi.ServiceStatus
+	16, // 36: lynkapi.DataResults.results:type_name -> lynkapi.DataResult
+	36, // 37: lynkapi.DataBackupChunk.status:type_name -> lynkapi.ServiceStatus
+	5,  // 38: lynkapi.DataBackupChunk.specs:type_name -> lynkapi.TableSpec
+	1,  // 39: lynkapi.DataBackupChunk.rows:type_name -> lynkapi.DataRow
+	36, // 40: lynkapi.DataBackupReport.status:type_name -> lynkapi.ServiceStatus
+	33, // 41: lynkapi.DataBackupReport.tables:type_name -> lynkapi.DataBackupReport.Table
+	34, // 42: lynkapi.DataDict.ExtFieldsEntry.value:type_name -> google.protobuf.Value
+	34, // 43: lynkapi.DataRow.FieldsEntry.value:type_name -> google.protobuf.Value
+	34, // 44: lynkapi.DataRowChange.FieldsEntry.value:type_name -> google.protobuf.Value
+	34, // 45: lynkapi.DataQuery.Filter.value:type_name -> google.protobuf.Value
+	27, // 46: lynkapi.DataQuery.Filter.inner:type_name -> lynkapi.DataQuery.Filter
+	47, // [47:47] is the sub-list for method output_type
+	47, // [47:47] is the sub-list for method input_type
+	47, // [47:47] is the sub-list for extension type_name
+	47, // [47:47] is the sub-list for extension extendee
+	0,  // [0:47] is the sub-list for field type_name
 }
 
 func init() { file_lynkapi_data_proto_init() }
@@ -1756,7 +3127,7 @@ func file_lynkapi_data_proto_init() {
 			}
 		}
 		file_lynkapi_data_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataCol); i {
+			switch v := v.(*DataFieldChange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1768,7 +3139,7 @@ func file_lynkapi_data_proto_init() {
 			}
 		}
 		file_lynkapi_data_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TableSpec); i {
+			switch v := v.(*DataRowChange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1780,7 +3151,7 @@ func file_lynkapi_data_proto_init() {
 			}
 		}
 		file_lynkapi_data_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataSpec); i {
+			switch v := v.(*DataCol); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1792,7 +3163,7 @@ func file_lynkapi_data_proto_init() {
 			}
 		}
 		file_lynkapi_data_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataConnect); i {
+			switch v := v.(*TableSpec); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1804,7 +3175,7 @@ func file_lynkapi_data_proto_init() {
 			}
 		}
 		file_lynkapi_data_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataInstance); i {
+			switch v := v.(*DataSpec); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1816,7 +3187,7 @@ func file_lynkapi_data_proto_init() {
 			}
 		}
 		file_lynkapi_data_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataProject); i {
+			switch v := v.(*DataConnect); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1828,7 +3199,7 @@ func file_lynkapi_data_proto_init() {
 			}
 		}
 		file_lynkapi_data_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataQuery); i {
+			switch v := v.(*DataInstance); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1840,7 +3211,7 @@ func file_lynkapi_data_proto_init() {
 			}
 		}
 		file_lynkapi_data_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataInsert); i {
+			switch v := v.(*DataProject); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1852,7 +3223,7 @@ func file_lynkapi_data_proto_init() {
 			}
 		}
 		file_lynkapi_data_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataUpdate); i {
+			switch v := v.(*DataQuery); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1864,7 +3235,7 @@ func file_lynkapi_data_proto_init() {
 			}
 		}
 		file_lynkapi_data_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataDelete); i {
+			switch v := v.(*DataQueryPlan); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1876,7 +3247,7 @@ func file_lynkapi_data_proto_init() {
 			}
 		}
 		file_lynkapi_data_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataResult); i {
+			switch v := v.(*DataInsert); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1888,7 +3259,31 @@ func file_lynkapi_data_proto_init() {
 			}
 		}
 		file_lynkapi_data_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataResults); i {
+			switch v := v.(*DataUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lynkapi_data_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataDelete); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lynkapi_data_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataRestore); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1900,7 +3295,19 @@ func file_lynkapi_data_proto_init() {
 			}
 		}
 		file_lynkapi_data_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TableSpec_Index); i {
+			switch v := v.(*DataResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lynkapi_data_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataResults); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1912,7 +3319,7 @@ func file_lynkapi_data_proto_init() {
 			}
 		}
 		file_lynkapi_data_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataQuery_Filter); i {
+			switch v := v.(*DataBackupChunk); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1924,7 +3331,7 @@ func file_lynkapi_data_proto_init() {
 			}
 		}
 		file_lynkapi_data_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DataQuery_SortFilter); i {
+			switch v := v.(*DataBackupRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1936,6 +3343,90 @@ func file_lynkapi_data_proto_init() {
 			}
 		}
 		file_lynkapi_data_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataBackupReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lynkapi_data_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TableSpec_Index); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lynkapi_data_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataQuery_Filter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lynkapi_data_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataQuery_SortFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lynkapi_data_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataQuery_Tree); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lynkapi_data_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataQuery_Join); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lynkapi_data_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataQueryPlan_Stage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lynkapi_data_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DataResult_Stats); i {
 			case 0:
 				return &v.state
@@ -1947,6 +3438,18 @@ func file_lynkapi_data_proto_init() {
 				return nil
 			}
 		}
+		file_lynkapi_data_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataBackupReport_Table); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1954,7 +3457,7 @@ func file_lynkapi_data_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_lynkapi_data_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   21,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,345 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hooto/htoml4g/htoml"
+)
+
+const (
+	DataAccess_Read   = "read"
+	DataAccess_Write  = "write"
+	DataAccess_Delete = "delete"
+
+	dataAccessAny = "*"
+)
+
+// DataAccessPolicy grants the actions (read, write, delete) on data instances
+// and tables to roles. A request is allowed if any rule matching one of the
+// caller roles grants the action, all other requests are denied.
+//
+//	[[rules]]
+//	roles = ["admin"]
+//	instance = "*"
+//	actions = ["read", "write", "delete"]
+//
+//	[[rules]]
+//	roles = ["guest"]
+//	instance = "main"
+//	table = "users"
+//	fields = ["id", "name"]
+//	actions = ["read"]
+type DataAccessPolicy struct {
+	Rules []*DataAccessRule `toml:"rules" json:"rules"`
+}
+
+// DataAccessRule grants the actions to the roles on an instance and table,
+// an empty or "*" role, instance or table matches any. If fields is set, the
+// rule only grants the actions on these fields: read results are reduced to
+// them, and a write of any other field is denied.
+type DataAccessRule struct {
+	Roles    []string `toml:"roles" json:"roles"`
+	Instance string   `toml:"instance,omitempty" json:"instance,omitempty"`
+	Table    string   `toml:"table,omitempty" json:"table,omitempty"`
+	Fields   []string `toml:"fields,omitempty" json:"fields,omitempty"`
+	Actions  []string `toml:"actions" json:"actions"`
+}
+
+func NewDataAccessPolicy(rules ...*DataAccessRule) *DataAccessPolicy {
+	return &DataAccessPolicy{
+		Rules: rules,
+	}
+}
+
+func NewDataAccessPolicyFromFile(file string) (*DataAccessPolicy, error) {
+	var p DataAccessPolicy
+	if err := htoml.DecodeFromFile(file, &p); err != nil {
+		return nil, err
+	}
+	if err := p.Valid(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (it *DataAccessPolicy) Allow(rule *DataAccessRule) *DataAccessPolicy {
+	it.Rules = append(it.Rules, rule)
+	return it
+}
+
+func (it *DataAccessPolicy) Valid() error {
+	for i, rule := range it.Rules {
+		if len(rule.Actions) == 0 {
+			return fmt.Errorf("rules[%d]: actions not setup", i)
+		}
+		for _, action := range rule.Actions {
+			switch action {
+			case DataAccess_Read, DataAccess_Write, DataAccess_Delete, dataAccessAny:
+			default:
+				return fmt.Errorf("rules[%d]: invalid action (%s)", i, action)
+			}
+		}
+	}
+	return nil
+}
+
+func dataAccessMatch(pattern, name string) bool {
+	return pattern == "" || pattern == dataAccessAny || pattern == name
+}
+
+func (it *DataAccessRule) match(roles []string, instanceName, tableName, action string) bool {
+	if !dataAccessMatch(it.Instance, instanceName) ||
+		!dataAccessMatch(it.Table, tableName) {
+		return false
+	}
+	if !slices.Contains(it.Actions, action) && !slices.Contains(it.Actions, dataAccessAny) {
+		return false
+	}
+	if len(it.Roles) == 0 || slices.Contains(it.Roles, dataAccessAny) {
+		return true
+	}
+	for _, role := range roles {
+		if slices.Contains(it.Roles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// Fields returns the fields the roles are granted the action on, all fields
+// if nil, or an error if the action is not granted.
+func (it *DataAccessPolicy) Fields(roles []string, instanceName, tableName, action string) (map[string]bool, error) {
+
+	var (
+		hit    = false
+		fields = map[string]bool{}
+	)
+
+	for _, rule := range it.Rules {
+		if !rule.match(roles, instanceName, tableName, action) {
+			continue
+		}
+		if len(rule.Fields) == 0 {
+			return nil, nil
+		}
+		hit = true
+		for _, field := range rule.Fields {
+			fields[field] = true
+		}
+	}
+
+	if !hit {
+		return nil, NewAuthDeniedError(fmt.Sprintf("%s access denied on %s.%s",
+			action, instanceName, tableName))
+	}
+
+	return fields, nil
+}
+
+func (it *LynkService) SetupDataAccessPolicy(p *DataAccessPolicy) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	it.dataAccessPolicy = p
+}
+
+// dataAccess checks the caller is granted the action on the table, and
+// returns the granted fields (nil if all).
+func (it *LynkService) dataAccess(
+	ctx context.Context,
+	instanceName, tableName, action string,
+) (map[string]bool, error) {
+
+	it.mu.RLock()
+	p := it.dataAccessPolicy
+	it.mu.RUnlock()
+
+	if p == nil {
+		return nil, nil
+	}
+
+	id := it.authIdentity(ctx)
+	if id == nil {
+		return nil, NewUnAuthError("auth identity not found")
+	}
+
+	return p.Fields(id.Roles, instanceName, tableName, action)
+}
+
+func dataAccessFilter(fr *DataQuery_Filter, fields map[string]bool) error {
+	if fr == nil {
+		return nil
+	}
+	if fr.Field != "" && !fields[fr.Field] {
+		return NewAuthDeniedError(fmt.Sprintf("read access denied on field (%s)", fr.Field))
+	}
+	for _, sub := range fr.Inner {
+		if err := dataAccessFilter(sub, fields); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dataAccessQuery checks the read access of a query: the fields of the
+// filter, the sort, the tree and the join field of the table.
+func (it *LynkService) dataAccessQuery(ctx context.Context, req *DataQuery) (map[string]bool, error) {
+
+	fields, err := it.dataAccess(ctx, req.InstanceName, req.TableName, DataAccess_Read)
+	if err != nil || fields == nil {
+		return fields, err
+	}
+
+	if err := dataAccessFilter(req.Filter, fields); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	if req.Sort != nil {
+		names = append(names, req.Sort.Field)
+	}
+	if req.Tree != nil {
+		pidField := req.Tree.PidField
+		if _, spec := it.dataProject.tableSpec(req.InstanceName, req.TableName); spec != nil {
+			if pidField == "" {
+				pidField = spec.pidField()
+			}
+			names = append(names, spec.primaryField())
+		}
+		names = append(names, pidField)
+	}
+	if req.Join != nil {
+		names = append(names, req.Join.Field)
+	}
+	for _, name := range names {
+		if name != "" && !fields[dataAccessPath(name)] {
+			return nil, NewAuthDeniedError(fmt.Sprintf("read access denied on field (%s)", name))
+		}
+	}
+
+	return fields, nil
+}
+
+// dataAccessPath returns the top field of a field path (ex: `sub.name`).
+func dataAccessPath(path string) string {
+	if n := strings.IndexAny(path, ".["); n > 0 {
+		return path[:n]
+	}
+	return path
+}
+
+// dataAccessResult reduces the rows, the display names and the row changes
+// (history, as_of and write results) to the granted fields.
+func dataAccessResult(rs *DataResult, fields map[string]bool) {
+	if rs == nil || fields == nil {
+		return
+	}
+	for _, row := range rs.Rows {
+		for name := range row.Fields {
+			if !fields[name] {
+				delete(row.Fields, name)
+			}
+		}
+		for name := range row.DisplayNames {
+			if !fields[name] {
+				delete(row.DisplayNames, name)
+			}
+		}
+	}
+	rs.Cols = slices.DeleteFunc(rs.Cols, func(col *DataCol) bool {
+		return !fields[col.Field]
+	})
+	for _, ch := range rs.Changes {
+		for name := range ch.Fields {
+			if !fields[name] {
+				delete(ch.Fields, name)
+			}
+		}
+		ch.Changes = slices.DeleteFunc(ch.Changes, func(fc *DataFieldChange) bool {
+			return !fields[dataAccessPath(fc.Path)]
+		})
+	}
+}
+
+// dataAccessWriteResult reduces the rows and changes returned by a write to
+// the fields the caller is granted to read, a caller without read access
+// only gets the status and stats.
+func (it *LynkService) dataAccessWriteResult(ctx context.Context, instanceName, tableName string, rs *DataResult) {
+	if rs == nil || (len(rs.Rows) == 0 && len(rs.Changes) == 0) {
+		return
+	}
+	fields, err := it.dataAccess(ctx, instanceName, tableName, DataAccess_Read)
+	if err != nil {
+		rs.Rows, rs.Changes = nil, nil
+		return
+	}
+	dataAccessResult(rs, fields)
+}
+
+// dataAccessInsert checks the write access of all fields of a write.
+func (it *LynkService) dataAccessInsert(ctx context.Context, req *DataInsert) error {
+
+	fields, err := it.dataAccess(ctx, req.InstanceName, req.TableName, DataAccess_Write)
+	if err != nil || fields == nil {
+		return err
+	}
+
+	for _, name := range req.Fields {
+		if !fields[name] {
+			return NewAuthDeniedError(fmt.Sprintf("write access denied on field (%s)", name))
+		}
+	}
+
+	// a path of the field mask is checked by its top field
+	for _, path := range req.FieldMask {
+		if path = dataAccessPath(path); !fields[path] {
+			return NewAuthDeniedError(fmt.Sprintf("write access denied on field (%s)", path))
+		}
+	}
+
+	return nil
+}
+
+// dataAccessTable checks the action (delete, restore) is granted on the whole
+// table, a rule granting it on some fields only is not enough.
+func (it *LynkService) dataAccessTable(ctx context.Context, instanceName, tableName, action string) error {
+
+	fields, err := it.dataAccess(ctx, instanceName, tableName, action)
+	if err != nil || fields == nil {
+		return err
+	}
+
+	return NewAuthDeniedError(fmt.Sprintf("%s access denied on %s.%s", action, instanceName, tableName))
+}
+
+// dataAccessRef checks the access of a write applied by a delete on a row
+// referencing the deleted rows: delete of the table for a cascade, write of
+// the field for a set_null.
+func (it *LynkService) dataAccessRef(ctx context.Context, act *dataRefAction) error {
+
+	if act.field == "" {
+		return it.dataAccessTable(ctx, act.instance, act.spec.Name, DataAccess_Delete)
+	}
+
+	return it.dataAccessInsert(ctx, &DataInsert{
+		InstanceName: act.instance,
+		TableName:    act.spec.Name,
+		Fields:       []string{act.field},
+	})
+}
@@ -0,0 +1,326 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+)
+
+const (
+	dataBackupVersion   = 1
+	dataBackupChunkRows = 100
+)
+
+// NewDataBackupWriter returns a writer of the chunks of a backup to w in the
+// archive file format: one json encoded chunk per line.
+func NewDataBackupWriter(w io.Writer) func(chunk *DataBackupChunk) error {
+	return func(chunk *DataBackupChunk) error {
+		b, err := json.Marshal(chunk)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(b, '\n'))
+		return err
+	}
+}
+
+// NewDataBackupReader returns a reader of the chunks of an archive file, it
+// returns io.EOF at the end of the archive.
+func NewDataBackupReader(r io.Reader) func() (*DataBackupChunk, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, dataImportLineMax)
+	return func() (*DataBackupChunk, error) {
+		for sc.Scan() {
+			if len(sc.Bytes()) == 0 {
+				continue
+			}
+			var chunk DataBackupChunk
+			if err := json.Unmarshal(sc.Bytes(), &chunk); err != nil {
+				return nil, err
+			}
+			return &chunk, nil
+		}
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+}
+
+func (it *LynkService) DataBackup(
+	req *DataBackupRequest,
+	stream LynkService_DataBackupServer,
+) error {
+	return it.dataBackup(stream.Context(), req, stream.Send)
+}
+
+// dataBackup sends the specs and rows of the tables of an instance. The rows
+// are read from a snapshot if the driver is a DataSnapshotService, otherwise
+// every table is read at a different moment.
+func (it *LynkService) dataBackup(
+	ctx context.Context,
+	req *DataBackupRequest,
+	send func(chunk *DataBackupChunk) error,
+) error {
+
+	ds := it.dataProject.service(req.InstanceName)
+	if ds == nil {
+		return NewNotFoundError("instance not found")
+	}
+
+	inst := ds.Instance()
+
+	head := &DataBackupChunk{
+		Version:      dataBackupVersion,
+		InstanceName: inst.Name,
+		Driver:       inst.Spec.Driver,
+		Created:      time.Now().UnixMilli(),
+	}
+
+	// the referenced tables are sent first, so a restore checks the
+	// references of each row against the rows already restored
+	for _, spec := range refOrder(inst.Name, inst.Spec.Tables) {
+		if len(req.Tables) == 0 || slices.Contains(req.Tables, spec.Name) {
+			head.Specs = append(head.Specs, spec)
+		}
+	}
+	for _, name := range req.Tables {
+		if inst.TableSpec(name) == nil {
+			return NewNotFoundError(fmt.Sprintf("table (%s) not found", name))
+		}
+	}
+
+	filters := map[string]*DataQuery_Filter{}
+	for _, spec := range head.Specs {
+		if err := it.dataAccessTable(ctx, inst.Name, spec.Name, DataAccess_Read); err != nil {
+			return err
+		}
+		filter, err := it.dataScopeFilter(ctx, inst.Name, spec.Name, nil)
+		if err != nil {
+			return err
+		}
+		filters[spec.Name] = filter
+	}
+
+	var snap DataSnapshot = ds
+	if ss, ok := ds.(DataSnapshotService); ok {
+		// a wrapper (ex: a cache) of a driver without snapshots
+		if s, err := ss.Snapshot(); err == nil {
+			snap = s
+		} else if ParseError(err).Code != StatusCode_NotImplemented {
+			return err
+		}
+	}
+
+	if err := send(head); err != nil {
+		return err
+	}
+
+	for _, spec := range head.Specs {
+
+		trash := []bool{false}
+		if spec.Option(TableSpec_Option_SoftDelete) != "" {
+			trash = append(trash, true)
+		}
+
+		for _, t := range trash {
+			for offset := 0; ; {
+				rs, err := snap.Query(&DataQuery{
+					InstanceName: inst.Name,
+					TableName:    spec.Name,
+					Filter:       filters[spec.Name],
+					Offset:       int32(offset),
+					Limit:        dataBackupChunkRows,
+					Trash:        t,
+				})
+				if err != nil {
+					return err
+				}
+				if len(rs.Rows) > 0 {
+					if err := send(&DataBackupChunk{
+						TableName: spec.Name,
+						Rows:      rs.Rows,
+					}); err != nil {
+						return err
+					}
+				}
+				if len(rs.Rows) < dataBackupChunkRows {
+					break
+				}
+				offset += len(rs.Rows)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (it *LynkService) DataBackupRestore(
+	stream LynkService_DataBackupRestoreServer,
+) error {
+	rs, err := it.dataBackupRestore(stream.Context(), stream.Recv)
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(rs)
+}
+
+// dataBackupRestore writes the rows of an archive into the instance named in
+// its first chunk, once the spec of every table in the archive is validated
+// against the current TableSpec. Each row goes through the write path of
+// DataUpsert (access, scope, write hooks, references, dictionary and tree
+// checks), so an archive can not load a row the API would refuse. A row of
+// the trash is written without its delete time and moved back to the trash
+// by a delete (its delete time is the restore time), its references are
+// checked once it is restored out of the trash.
+func (it *LynkService) dataBackupRestore(
+	ctx context.Context,
+	recv func() (*DataBackupChunk, error),
+) (*DataBackupReport, error) {
+
+	head, err := recv()
+	if err == io.EOF {
+		return nil, NewBadRequestError("empty archive")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if head.Version != dataBackupVersion {
+		return nil, NewBadRequestError(fmt.Sprintf("unsupported archive version (%d)", head.Version))
+	}
+
+	ds := it.dataProject.service(head.InstanceName)
+	if ds == nil {
+		return nil, NewNotFoundError("instance not found")
+	}
+
+	var (
+		inst   = ds.Instance()
+		specs  = map[string]*TableSpec{}
+		report = &DataBackupReport{}
+		tables = map[string]*DataBackupReport_Table{}
+	)
+
+	for _, src := range head.Specs {
+		spec := inst.TableSpec(src.Name)
+		if spec == nil {
+			return nil, NewNotFoundError(fmt.Sprintf("table (%s) not found", src.Name))
+		}
+		if err := dataBackupSpecCheck(spec, src); err != nil {
+			return nil, NewBadRequestError(fmt.Sprintf("table (%s): %s", src.Name, err.Error()))
+		}
+		if err := it.dataAccessTable(ctx, inst.Name, spec.Name, DataAccess_Write); err != nil {
+			return nil, err
+		}
+		specs[spec.Name] = spec
+		tables[spec.Name] = &DataBackupReport_Table{
+			Name: spec.Name,
+		}
+		report.Tables = append(report.Tables, tables[spec.Name])
+	}
+
+	for {
+
+		chunk, err := recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		spec, ok := specs[chunk.TableName]
+		if !ok {
+			return nil, NewBadRequestError(fmt.Sprintf("table (%s) spec not found in archive", chunk.TableName))
+		}
+
+		for _, row := range chunk.Rows {
+
+			var (
+				req = &DataInsert{
+					InstanceName: inst.Name,
+					TableName:    spec.Name,
+				}
+				n       = 0
+				trashed = false
+			)
+			for _, field := range spec.Fields {
+				v, ok := row.Fields[field.TagName]
+				if !ok {
+					continue
+				}
+				n += 1
+				if field.HasAttr("deleted") {
+					trashed = v.GetNumberValue() > 0
+					continue
+				}
+				req.Fields = append(req.Fields, field.TagName)
+				req.Values = append(req.Values, v)
+			}
+			if n < len(row.Fields) {
+				return nil, NewBadRequestError(fmt.Sprintf("table (%s) row (%s): field not found in spec",
+					spec.Name, row.Id))
+			}
+
+			if _, err := it.dataUpsert(ctx, ds, req, trashed); err != nil {
+				return nil, err
+			}
+			if trashed {
+				if _, err := ds.Delete(&DataDelete{
+					InstanceName: inst.Name,
+					TableName:    spec.Name,
+					Filter: &DataQuery_Filter{
+						Field: spec.primaryField(),
+						Value: row.Fields[spec.primaryField()],
+					},
+					Operator: req.Operator,
+				}); err != nil {
+					return nil, err
+				}
+			}
+			tables[spec.Name].Rows += 1
+		}
+	}
+
+	report.Status = NewServiceStatusOK()
+
+	return report, nil
+}
+
+// dataBackupSpecCheck checks the rows of the table spec src can be restored
+// into a table of the spec dst.
+func dataBackupSpecCheck(dst, src *TableSpec) error {
+
+	if !slices.Equal(dst.PrimaryFields, src.PrimaryFields) {
+		return fmt.Errorf("primary fields %v != %v", src.PrimaryFields, dst.PrimaryFields)
+	}
+
+	for _, field := range src.Fields {
+		cur := dst.field(field.TagName)
+		if cur == nil {
+			return fmt.Errorf("field (%s) not found", field.TagName)
+		}
+		if cur.Type != field.Type {
+			return fmt.Errorf("field (%s) type %s != %s", field.TagName, field.Type, cur.Type)
+		}
+	}
+
+	return nil
+}
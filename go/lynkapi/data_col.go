@@ -0,0 +1,630 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// NewDataCols returns the columns of the rows: the fields of the spec in
+// order, then the other fields by name. A column is encoded by the type of
+// its field, or as values of any type if a value does not match it.
+func NewDataCols(spec *TableSpec, rows []*DataRow) []*DataCol {
+
+	var (
+		cols   []*DataCol
+		fields = map[string]bool{}
+		others []string
+	)
+
+	if spec != nil {
+		for _, field := range spec.Fields {
+			fields[field.TagName] = true
+			cols = append(cols, newDataCol(field.TagName, field.Type, rows))
+		}
+	}
+
+	for _, row := range rows {
+		for name := range row.Fields {
+			if !fields[name] {
+				fields[name] = true
+				others = append(others, name)
+			}
+		}
+	}
+	sort.Strings(others)
+
+	for _, name := range others {
+		cols = append(cols, newDataCol(name, "", rows))
+	}
+
+	return cols
+}
+
+func newDataCol(name, typ string, rows []*DataRow) *DataCol {
+
+	col := &DataCol{
+		Field: name,
+		Type:  typ,
+	}
+
+	var prev int64
+
+	for i, row := range rows {
+
+		v := row.Fields[name]
+		if _, ok := v.GetKind().(*structpb.Value_NullValue); ok || v.GetKind() == nil {
+			col.Nulls = append(col.Nulls, uint32(i))
+			continue
+		}
+
+		ok := true
+
+		switch typ {
+		case FieldSpec_Int, FieldSpec_Uint, FieldSpec_Bool:
+			var iv int64
+			switch k := v.Kind.(type) {
+			case *structpb.Value_NumberValue:
+				n := k.NumberValue
+				switch {
+				case typ == FieldSpec_Bool || n != math.Trunc(n):
+					ok = false
+				case typ == FieldSpec_Uint && n >= 0 && n < math.MaxUint64:
+					iv = int64(uint64(n))
+				case typ == FieldSpec_Int && n >= math.MinInt64 && n < math.MaxInt64:
+					iv = int64(n)
+				default:
+					ok = false
+				}
+			case *structpb.Value_BoolValue:
+				if ok = typ == FieldSpec_Bool; ok && k.BoolValue {
+					iv = 1
+				}
+			default:
+				ok = false
+			}
+			if ok {
+				if len(col.IntValues) == 0 {
+					col.BaseIntValue, prev = iv, iv
+				}
+				col.IntValues = append(col.IntValues, iv-prev)
+				prev = iv
+			}
+
+		case FieldSpec_Float:
+			if k, is := v.Kind.(*structpb.Value_NumberValue); is {
+				col.DoubleValues = append(col.DoubleValues, k.NumberValue)
+			} else {
+				ok = false
+			}
+
+		case FieldSpec_String:
+			if k, is := v.Kind.(*structpb.Value_StringValue); is {
+				col.StringValues = append(col.StringValues, k.StringValue)
+			} else {
+				ok = false
+			}
+
+		case FieldSpec_Bytes:
+			// bytes are base64 strings in the json of a row
+			if k, is := v.Kind.(*structpb.Value_StringValue); is {
+				b, err := base64.StdEncoding.DecodeString(k.StringValue)
+				if ok = err == nil; ok {
+					col.BytesValues = append(col.BytesValues, b)
+				}
+			} else {
+				ok = false
+			}
+
+		default:
+			ok = false
+		}
+
+		if !ok {
+			if typ == "" {
+				col.Values = append(col.Values, v)
+				continue
+			}
+			return newDataCol(name, "", rows)
+		}
+	}
+
+	for i, row := range rows {
+		if dn, ok := row.DisplayNames[name]; ok {
+			if col.DisplayNames == nil {
+				col.DisplayNames = make([]string, len(rows))
+			}
+			col.DisplayNames[i] = dn
+		}
+	}
+
+	return col
+}
+
+// RowsToCols replaces the rows of the result by their columns (see
+// NewDataCols), with the instance and the depth of each row.
+func (it *DataResult) RowsToCols() {
+
+	for i, row := range it.Rows {
+		if row.InstanceName != "" && it.RowInstanceNames == nil {
+			it.RowInstanceNames = make([]string, len(it.Rows))
+		}
+		if it.RowInstanceNames != nil {
+			it.RowInstanceNames[i] = row.InstanceName
+		}
+		if row.Depth != 0 && it.RowDepths == nil {
+			it.RowDepths = make([]int32, len(it.Rows))
+		}
+		if it.RowDepths != nil {
+			it.RowDepths[i] = row.Depth
+		}
+	}
+
+	it.Cols, it.Rows = NewDataCols(it.Spec, it.Rows), nil
+}
+
+// Len returns the number of rows of the column.
+func (it *DataCol) Len() int {
+	n := len(it.Nulls)
+	switch it.Type {
+	case FieldSpec_Int, FieldSpec_Uint, FieldSpec_Bool:
+		n += len(it.IntValues)
+	case FieldSpec_Float:
+		n += len(it.DoubleValues)
+	case FieldSpec_String:
+		n += len(it.StringValues)
+	case FieldSpec_Bytes:
+		n += len(it.BytesValues)
+	default:
+		n += len(it.Values)
+	}
+	return n
+}
+
+// each calls fn with the row index and the index in the value list of the
+// rows with a value.
+func (it *DataCol) each(fn func(i, k int)) {
+	var (
+		n = it.Len()
+		m = n - len(it.Nulls)
+		j = 0
+		k = 0
+	)
+	for i := 0; i < n && k < m; i++ {
+		if j < len(it.Nulls) && int(it.Nulls[j]) == i {
+			j++
+			continue
+		}
+		fn(i, k)
+		k++
+	}
+}
+
+// Int64s returns the values of an int, uint or bool column, 0 for the rows
+// without a value.
+func (it *DataCol) Int64s() []int64 {
+	ls := make([]int64, it.Len())
+	switch it.Type {
+	case FieldSpec_Int, FieldSpec_Uint, FieldSpec_Bool:
+		v := it.BaseIntValue
+		it.each(func(i, k int) {
+			v += it.IntValues[k]
+			ls[i] = v
+		})
+	}
+	return ls
+}
+
+func (it *DataCol) Uint64s() []uint64 {
+	ls := make([]uint64, it.Len())
+	for i, v := range it.Int64s() {
+		ls[i] = uint64(v)
+	}
+	return ls
+}
+
+func (it *DataCol) Bools() []bool {
+	ls := make([]bool, it.Len())
+	for i, v := range it.Int64s() {
+		ls[i] = v != 0
+	}
+	return ls
+}
+
+// Float64s returns the values of a float, int or uint column, 0 for the
+// rows without a value.
+func (it *DataCol) Float64s() []float64 {
+	ls := make([]float64, it.Len())
+	switch it.Type {
+	case FieldSpec_Float:
+		it.each(func(i, k int) {
+			ls[i] = it.DoubleValues[k]
+		})
+	case FieldSpec_Int:
+		for i, v := range it.Int64s() {
+			ls[i] = float64(v)
+		}
+	case FieldSpec_Uint:
+		for i, v := range it.Uint64s() {
+			ls[i] = float64(v)
+		}
+	}
+	return ls
+}
+
+// Strings returns the values of a string column, "" for the rows without a
+// value.
+func (it *DataCol) Strings() []string {
+	ls := make([]string, it.Len())
+	if it.Type == FieldSpec_String {
+		it.each(func(i, k int) {
+			ls[i] = it.StringValues[k]
+		})
+	}
+	return ls
+}
+
+// ValueList returns the values of the column as in the fields of a row, nil for
+// the rows without a value.
+func (it *DataCol) ValueList() []*structpb.Value {
+	var (
+		ls = make([]*structpb.Value, it.Len())
+		v  = it.BaseIntValue
+	)
+	it.each(func(i, k int) {
+		switch it.Type {
+		case FieldSpec_Int, FieldSpec_Uint, FieldSpec_Bool:
+			v += it.IntValues[k]
+			switch it.Type {
+			case FieldSpec_Int:
+				ls[i] = structpb.NewNumberValue(float64(v))
+			case FieldSpec_Uint:
+				ls[i] = structpb.NewNumberValue(float64(uint64(v)))
+			default:
+				ls[i] = structpb.NewBoolValue(v != 0)
+			}
+		case FieldSpec_Float:
+			ls[i] = structpb.NewNumberValue(it.DoubleValues[k])
+		case FieldSpec_String:
+			ls[i] = structpb.NewStringValue(it.StringValues[k])
+		case FieldSpec_Bytes:
+			ls[i] = structpb.NewStringValue(base64.StdEncoding.EncodeToString(it.BytesValues[k]))
+		default:
+			ls[i] = it.Values[k]
+		}
+	})
+	return ls
+}
+
+// Col returns the column of the field in a columnar result.
+func (it *DataResult) Col(field string) *DataCol {
+	for _, col := range it.Cols {
+		if col.Field == field {
+			return col
+		}
+	}
+	return nil
+}
+
+// rowCount returns the number of rows, or of the rows of the columns.
+func (it *DataResult) rowCount() int {
+	if len(it.Cols) > 0 {
+		return it.Cols[0].Len()
+	}
+	return len(it.Rows)
+}
+
+// ColRows returns the rows of the columns of a columnar result, the row ids
+// are set by the spec of the result.
+func (it *DataResult) ColRows() ([]*DataRow, error) {
+
+	if len(it.Cols) == 0 {
+		return nil, nil
+	}
+
+	n := it.Cols[0].Len()
+	rows := make([]*DataRow, n)
+	for i := range rows {
+		rows[i] = &DataRow{
+			Fields: map[string]*structpb.Value{},
+		}
+	}
+
+	for _, col := range it.Cols {
+		if col.Len() != n {
+			return nil, fmt.Errorf("column (%s): %d rows, expected %d", col.Field, col.Len(), n)
+		}
+		if len(col.DisplayNames) > 0 && len(col.DisplayNames) != n {
+			return nil, fmt.Errorf("column (%s): %d display names, expected %d", col.Field, len(col.DisplayNames), n)
+		}
+		for j, v := range col.Nulls {
+			if int(v) >= n || (j > 0 && v <= col.Nulls[j-1]) {
+				return nil, fmt.Errorf("column (%s): invalid nulls", col.Field)
+			}
+		}
+		for i, v := range col.ValueList() {
+			if v != nil {
+				rows[i].Fields[col.Field] = v
+			}
+		}
+		for i, dn := range col.DisplayNames {
+			if dn == "" {
+				continue
+			}
+			if rows[i].DisplayNames == nil {
+				rows[i].DisplayNames = map[string]string{}
+			}
+			rows[i].DisplayNames[col.Field] = dn
+		}
+	}
+
+	if len(it.RowInstanceNames) > 0 && len(it.RowInstanceNames) != n {
+		return nil, fmt.Errorf("%d row instance names, expected %d", len(it.RowInstanceNames), n)
+	}
+	if len(it.RowDepths) > 0 && len(it.RowDepths) != n {
+		return nil, fmt.Errorf("%d row depths, expected %d", len(it.RowDepths), n)
+	}
+	for i, name := range it.RowInstanceNames {
+		rows[i].InstanceName = name
+	}
+	for i, depth := range it.RowDepths {
+		rows[i].Depth = depth
+	}
+
+	if it.Spec != nil {
+		for _, row := range rows {
+			row.Id = it.Spec.PrimaryId(row.Fields)
+		}
+	}
+
+	return rows, nil
+}
+
+// DataColWriter builds the columns of a columnar result from the struct rows
+// of a driver, without their rows: the fields of the spec in order, read from
+// the struct field of the FieldSpec name. The values are the ones of the json
+// encoded rows, a field not of the kind of its type (or with its own json
+// encoding) is a column of values of any type.
+type DataColWriter struct {
+	fields []*FieldSpec
+	cols   []*DataCol
+	prev   []int64
+	n      int
+}
+
+func NewDataColWriter(spec *TableSpec) *DataColWriter {
+	w := &DataColWriter{}
+	if spec != nil {
+		for _, field := range spec.Fields {
+			col := &DataCol{
+				Field: field.TagName,
+			}
+			switch field.Type {
+			case FieldSpec_Int, FieldSpec_Uint, FieldSpec_Bool,
+				FieldSpec_Float, FieldSpec_String, FieldSpec_Bytes:
+				col.Type = field.Type
+			}
+			w.fields = append(w.fields, field)
+			w.cols = append(w.cols, col)
+		}
+	}
+	w.prev = make([]int64, len(w.cols))
+	return w
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Append appends a struct (or pointer to struct) row.
+func (it *DataColWriter) Append(v reflect.Value) {
+
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	for i, field := range it.fields {
+
+		var fv reflect.Value
+		if v.Kind() == reflect.Struct {
+			if sf, ok := v.Type().FieldByName(field.Name); ok && sf.IsExported() {
+				fv, _ = v.FieldByIndexErr(sf.Index)
+				if _, opts, _ := strings.Cut(sf.Tag.Get("json"), ","); fv.IsValid() {
+					for _, opt := range strings.Split(opts, ",") {
+						switch {
+						case opt == "omitempty" && jsonEmpty(fv):
+							fv = reflect.Value{}
+						case opt == "string":
+							it.any(i)
+						}
+					}
+				}
+			}
+		}
+
+		if fv.IsValid() && (fv.Type().Implements(jsonMarshalerType) ||
+			fv.Type().Implements(textMarshalerType) ||
+			reflect.PointerTo(fv.Type()).Implements(jsonMarshalerType) ||
+			reflect.PointerTo(fv.Type()).Implements(textMarshalerType)) {
+			it.any(i)
+		}
+
+		col := it.cols[i]
+		if col.Type != "" {
+			for fv.Kind() == reflect.Pointer && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+		}
+
+		if !fv.IsValid() || ((fv.Kind() == reflect.Pointer || fv.Kind() == reflect.Interface ||
+			fv.Kind() == reflect.Map || fv.Kind() == reflect.Slice) && fv.IsNil()) {
+			col.Nulls = append(col.Nulls, uint32(it.n))
+			continue
+		}
+
+		if !it.append(i, fv) {
+			it.any(i)
+			it.append(i, fv)
+		}
+	}
+
+	it.n += 1
+}
+
+// append appends the value of a row to the column i, it returns false if the
+// value is not of the kind of the column type.
+func (it *DataColWriter) append(i int, fv reflect.Value) bool {
+
+	col := it.cols[i]
+
+	var (
+		iv int64
+		ok bool
+	)
+
+	switch col.Type {
+	case FieldSpec_Int:
+		switch fv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			iv, ok = fv.Int(), true
+		}
+
+	case FieldSpec_Uint:
+		switch fv.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			iv, ok = int64(fv.Uint()), true
+		}
+
+	case FieldSpec_Bool:
+		if ok = fv.Kind() == reflect.Bool; ok && fv.Bool() {
+			iv = 1
+		}
+
+	case FieldSpec_Float:
+		switch fv.Kind() {
+		case reflect.Float32:
+			// as the json encoding of a float32
+			f, _ := strconv.ParseFloat(strconv.FormatFloat(fv.Float(), 'g', -1, 32), 64)
+			col.DoubleValues = append(col.DoubleValues, f)
+			return true
+		case reflect.Float64:
+			col.DoubleValues = append(col.DoubleValues, fv.Float())
+			return true
+		}
+		return false
+
+	case FieldSpec_String:
+		if fv.Kind() == reflect.String {
+			col.StringValues = append(col.StringValues, fv.String())
+			return true
+		}
+		return false
+
+	case FieldSpec_Bytes:
+		if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.Uint8 {
+			col.BytesValues = append(col.BytesValues, bytes.Clone(fv.Bytes()))
+			return true
+		}
+		return false
+
+	default:
+		if !fv.CanInterface() {
+			col.Nulls = append(col.Nulls, uint32(it.n))
+			return true
+		}
+		var v structpb.Value
+		if js, err := json.Marshal(fv.Interface()); err != nil || json.Unmarshal(js, &v) != nil {
+			col.Nulls = append(col.Nulls, uint32(it.n))
+		} else if _, null := v.Kind.(*structpb.Value_NullValue); null {
+			col.Nulls = append(col.Nulls, uint32(it.n))
+		} else {
+			col.Values = append(col.Values, &v)
+		}
+		return true
+	}
+
+	if !ok {
+		return false
+	}
+	if len(col.IntValues) == 0 {
+		col.BaseIntValue, it.prev[i] = iv, iv
+	}
+	col.IntValues = append(col.IntValues, iv-it.prev[i])
+	it.prev[i] = iv
+	return true
+}
+
+// any turns the column i into a column of values of any type.
+func (it *DataColWriter) any(i int) {
+	col := it.cols[i]
+	if col.Type == "" {
+		return
+	}
+	var values []*structpb.Value
+	for _, v := range col.ValueList() {
+		if v != nil {
+			values = append(values, v)
+		}
+	}
+	it.cols[i] = &DataCol{
+		Field:  col.Field,
+		Values: values,
+		Nulls:  col.Nulls,
+	}
+}
+
+// Len returns the number of rows appended.
+func (it *DataColWriter) Len() int {
+	return it.n
+}
+
+// Cols returns the columns of the rows appended.
+func (it *DataColWriter) Cols() []*DataCol {
+	return it.cols
+}
+
+// jsonEmpty returns true if the value is omitted by a json omitempty option.
+func jsonEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
+// dataColumnar returns true if the driver returns the columns of a columnar
+// query.
+func dataColumnar(ds DataService) bool {
+	dc, ok := ds.(DataColumnarService)
+	return ok && dc.Columnar()
+}
@@ -0,0 +1,191 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/lynkdb/lynkapi/go/lynkapi"
+	"github.com/lynkdb/lynkapi/go/oneobject"
+)
+
+func Test_DataCols(t *testing.T) {
+
+	type Item struct {
+		Id     int64             `json:"id" x_attrs:"primary_key"`
+		Name   string            `json:"name,omitempty"`
+		Count  uint32            `json:"count"`
+		Score  float64           `json:"score"`
+		Active bool              `json:"active"`
+		Data   []byte            `json:"data,omitempty"`
+		Labels map[string]string `json:"labels,omitempty"`
+	}
+	type Object struct {
+		Items []*Item `json:"items"`
+	}
+
+	obj := &Object{}
+	for i := 0; i < 50; i++ {
+		item := &Item{
+			Id:     int64(1000 + i*3),
+			Count:  uint32(50 - i),
+			Score:  float64(i) / 3,
+			Active: i%2 == 0,
+		}
+		if i%5 != 0 {
+			item.Name = fmt.Sprintf("item %d", i)
+		}
+		if i%7 == 0 {
+			item.Data = []byte{byte(i), 0, 255}
+			item.Labels = map[string]string{"k": "v"}
+		}
+		obj.Items = append(obj.Items, item)
+	}
+
+	inst, err := oneobject.NewInstance("test", obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("items"); err != nil {
+		t.Fatal(err)
+	}
+	s := lynkapi.NewService()
+	if err := s.RegisterDataService(inst); err != nil {
+		t.Fatal(err)
+	}
+
+	q := &lynkapi.DataQuery{
+		InstanceName: "test",
+		TableName:    "items",
+		Limit:        100,
+	}
+	rs, err := s.DataQuery(context.Background(), q)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q.Columnar = true
+	crs, err := s.DataQuery(context.Background(), q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(crs.Rows) != 0 || len(crs.Cols) == 0 {
+		t.Fatalf("invalid columnar result")
+	}
+	if proto.Size(crs) >= proto.Size(rs) {
+		t.Fatalf("columnar size %d >= %d", proto.Size(crs), proto.Size(rs))
+	}
+
+	// ids are encoded as deltas
+	if col := crs.Col("id"); col.Type != lynkapi.FieldSpec_Int || col.BaseIntValue != 1000 || col.IntValues[1] != 3 {
+		t.Fatalf("invalid id column %v", col)
+	}
+	if col := crs.Col("name"); len(col.Nulls) != 10 || col.Strings()[0] != "" || col.Strings()[1] != "item 1" {
+		t.Fatalf("invalid name column %v", col)
+	}
+	if v := crs.Col("count").Uint64s(); v[0] != 50 || v[49] != 1 {
+		t.Fatalf("invalid count column %v", v)
+	}
+	if v := crs.Col("active").Bools(); !v[0] || v[1] {
+		t.Fatalf("invalid active column %v", v)
+	}
+	if v := crs.Col("score").Float64s(); v[3] != 1 {
+		t.Fatalf("invalid score column %v", v)
+	}
+	if col := crs.Col("data"); len(col.BytesValues) != 8 || !slices.Equal(col.BytesValues[1], []byte{7, 0, 255}) {
+		t.Fatalf("invalid data column %v", col)
+	}
+	if col := crs.Col("labels"); col.Type != "" || len(col.Values) != 8 {
+		t.Fatalf("invalid labels column %v", col)
+	}
+
+	rows, err := crs.ColRows()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != len(rs.Rows) {
+		t.Fatalf("rows %d, expected %d", len(rows), len(rs.Rows))
+	}
+	for i, row := range rows {
+		if !proto.Equal(row, rs.Rows[i]) {
+			t.Fatalf("row %d: %v, expected %v", i, row, rs.Rows[i])
+		}
+	}
+
+	// the columns are returned by the driver, not built from its rows
+	q.Explain = true
+	if ers, err := s.DataQuery(context.Background(), q); err != nil || len(ers.Cols) != len(crs.Cols) ||
+		slices.ContainsFunc(ers.Plan.Stages, func(st *lynkapi.DataQueryPlan_Stage) bool {
+			return st.Name == "columnar"
+		}) {
+		t.Fatalf("invalid driver columns %v %v", ers, err)
+	}
+	q.Explain = false
+
+	// the columns of the driver are the ones of its rows
+	for i, col := range lynkapi.NewDataCols(rs.Spec, rs.Rows) {
+		if !proto.Equal(col, crs.Cols[i]) {
+			t.Fatalf("column %s: %v, expected %v", col.Field, crs.Cols[i], col)
+		}
+	}
+
+	// the instance, depth and display names of the rows
+	prs := &lynkapi.DataResult{
+		Spec: rs.Spec,
+		Rows: []*lynkapi.DataRow{
+			{
+				Fields:       map[string]*structpb.Value{"id": structpb.NewNumberValue(1)},
+				InstanceName: "a",
+			},
+			{
+				Fields:       map[string]*structpb.Value{"id": structpb.NewNumberValue(2), "name": structpb.NewStringValue("n")},
+				DisplayNames: map[string]string{"name": "N"},
+				Depth:        1,
+				InstanceName: "b",
+			},
+		},
+	}
+	expRows := prs.Rows
+	prs.RowsToCols()
+	if len(prs.Rows) != 0 || len(prs.RowInstanceNames) != 2 || len(prs.RowDepths) != 2 ||
+		!slices.Equal(prs.Col("name").DisplayNames, []string{"", "N"}) || prs.Col("id").DisplayNames != nil {
+		t.Fatalf("invalid columns %v", prs)
+	}
+	if rows, err := prs.ColRows(); err != nil || len(rows) != 2 ||
+		!proto.Equal(rows[0], expRows[0]) || !proto.Equal(rows[1], expRows[1]) {
+		t.Fatalf("invalid rows of columns %v %v", rows, err)
+	}
+
+	// a value not of the field type
+	cols := lynkapi.NewDataCols(rs.Spec, []*lynkapi.DataRow{
+		{Fields: map[string]*structpb.Value{"id": structpb.NewNumberValue(1.5)}},
+	})
+	if cols[0].Type != "" || len(cols[0].Values) != 1 {
+		t.Fatalf("invalid column %v", cols[0])
+	}
+
+	if _, err := (&lynkapi.DataResult{Cols: []*lynkapi.DataCol{
+		{Field: "a", Type: lynkapi.FieldSpec_String, StringValues: []string{"x"}},
+		{Field: "b", Type: lynkapi.FieldSpec_String, Nulls: []uint32{0, 5}},
+	}}).ColRows(); err == nil {
+		t.Fatal("invalid columns decoded")
+	}
+}
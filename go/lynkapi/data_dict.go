@@ -0,0 +1,117 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi
+
+import (
+	"fmt"
+)
+
+// DataDictService is a DataService holding dictionary items, the values of
+// fields with the tag x_dict_ns are validated and resolved by it.
+type DataDictService interface {
+	DataService
+
+	DictItem(ns, name string) *DataDict
+}
+
+func (it *DataDict) Title() string {
+	if it.DisplayName != "" {
+		return it.DisplayName
+	}
+	return it.Name
+}
+
+func (it *dataProjectManager) dictServices() []DataDictService {
+	it.mu.RLock()
+	defer it.mu.RUnlock()
+
+	var dss []DataDictService
+	for _, ds := range it.services {
+		if dds, ok := ds.(DataDictService); ok {
+			dss = append(dss, dds)
+		}
+	}
+	return dss
+}
+
+func (it *dataProjectManager) dictItem(dictNs []string, name string) *DataDict {
+	for _, ds := range it.dictServices() {
+		for _, ns := range dictNs {
+			if item := ds.DictItem(ns, name); item != nil {
+				return item
+			}
+		}
+	}
+	return nil
+}
+
+// dictCheck validates the values of the dictionary fields of a write.
+func (it *dataProjectManager) dictCheck(req *DataInsert) error {
+
+	_, spec := it.tableSpec(req.InstanceName, req.TableName)
+	if spec == nil {
+		return nil
+	}
+
+	for i, name := range req.Fields {
+
+		field := spec.field(name)
+		if field == nil || len(field.DictNs) == 0 || i >= len(req.Values) {
+			continue
+		}
+
+		value := req.Values[i].GetStringValue()
+		if value == "" {
+			continue
+		}
+
+		if it.dictItem(field.DictNs, value) == nil {
+			return NewBadRequestError(fmt.Sprintf("field (%s) value (%s) not found in dictionary %v",
+				name, value, field.DictNs))
+		}
+	}
+
+	return nil
+}
+
+// dictDisplay fills the display names of the dictionary fields of the rows.
+func (it *dataProjectManager) dictDisplay(rs *DataResult) {
+
+	if rs == nil || rs.Spec == nil {
+		return
+	}
+
+	for _, field := range rs.Spec.Fields {
+
+		if len(field.DictNs) == 0 {
+			continue
+		}
+
+		for _, row := range rs.Rows {
+
+			value := row.Fields[field.TagName].GetStringValue()
+			if value == "" {
+				continue
+			}
+
+			if item := it.dictItem(field.DictNs, value); item != nil {
+				if row.DisplayNames == nil {
+					row.DisplayNames = map[string]string{}
+				}
+				row.DisplayNames[field.TagName] = item.Title()
+			}
+		}
+	}
+}
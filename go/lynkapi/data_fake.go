@@ -0,0 +1,483 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	dataFakeArrayMax = 3
+	dataFakeMapMax   = 2
+	dataFakeDepthMax = 8
+
+	// the max number of rows of a table already seeded, picked as values of
+	// the fake references to it
+	dataSeedRefRows = 100
+)
+
+var (
+	dataFakeFirstNames = []string{"Alice", "Bruno", "Chen", "Diana", "Emil", "Fatima", "Giulia", "Hiro",
+		"Ines", "Jonas", "Kemal", "Lena", "Mateo", "Nadia", "Omar", "Priya", "Rosa", "Sven", "Tariq", "Yuki"}
+	dataFakeLastNames = []string{"Anders", "Brown", "Costa", "Dubois", "Evans", "Fischer", "Garcia", "Haddad",
+		"Ito", "Jensen", "Kowalski", "Li", "Moreau", "Novak", "Okafor", "Patel", "Rossi", "Silva", "Tanaka", "Weber"}
+	dataFakeWords = []string{"alpha", "amber", "bridge", "cloud", "copper", "delta", "ember", "field", "forest",
+		"harbor", "lake", "lumen", "maple", "meadow", "nova", "orbit", "pixel", "quartz", "river", "signal",
+		"solar", "stone", "summit", "timber", "vector", "willow"}
+	dataFakeCities = []string{"Amsterdam", "Austin", "Berlin", "Buenos Aires", "Cape Town", "Chengdu", "Dublin",
+		"Lisbon", "Melbourne", "Montreal", "Nairobi", "Osaka", "Seoul", "Stockholm", "Toronto", "Valencia"}
+	dataFakeCountries = []string{"AR", "AU", "BR", "CA", "CN", "DE", "ES", "FR", "IE", "JP", "KE", "KR", "NL",
+		"PT", "SE", "US", "ZA"}
+
+	// the base of the fake times: 2024-01-01
+	dataFakeTimeBase = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// DataFaker generates fake values of the fields of a spec: the values of the
+// enums, within the x_value_limits of numbers, rand_hex/object_id ids, and
+// strings by the styles and name of the field (email, name, title, url ...).
+// The same seed generates the same values.
+type DataFaker struct {
+	rand *rand.Rand
+	seq  int64
+}
+
+func NewDataFaker(seed int64) *DataFaker {
+	return &DataFaker{
+		rand: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Rows returns n rows of the fields of a type spec.
+func (it *DataFaker) Rows(spec *TypeSpec, n int) []*DataRow {
+	var rows []*DataRow
+	for i := 0; i < n; i++ {
+		rows = append(rows, &DataRow{
+			Fields: it.Fields(spec.Fields),
+		})
+	}
+	return rows
+}
+
+// TableRows returns n rows of a table, the values of the primary and unique
+// keys are unique in the rows.
+func (it *DataFaker) TableRows(spec *TableSpec, n int) []*DataRow {
+	var rows []*DataRow
+	for i := 0; i < n; i++ {
+		fields := it.Fields(spec.Fields)
+		rows = append(rows, &DataRow{
+			Id:     spec.PrimaryId(fields),
+			Fields: fields,
+		})
+	}
+	return rows
+}
+
+// DemoRows fills the demo_rows of the table spec with n fake rows.
+func (it *DataFaker) DemoRows(spec *TableSpec, n int) {
+	spec.DemoRows = it.TableRows(spec, n)
+}
+
+func (it *DataFaker) Fields(fields []*FieldSpec) map[string]*structpb.Value {
+	it.seq += 1
+	return it.fields(fields, 0)
+}
+
+func (it *DataFaker) fields(fields []*FieldSpec, depth int) map[string]*structpb.Value {
+	values := map[string]*structpb.Value{}
+	for _, field := range fields {
+		if v := it.value(field, field.Type, depth); v != nil {
+			values[field.TagName] = v
+		}
+	}
+	return values
+}
+
+// Value returns a fake value of the field.
+func (it *DataFaker) Value(field *FieldSpec) *structpb.Value {
+	return it.value(field, field.Type, 0)
+}
+
+func (it *DataFaker) value(field *FieldSpec, typ string, depth int) *structpb.Value {
+
+	if depth > dataFakeDepthMax {
+		return nil
+	}
+
+	if field.HasAttr("deleted") {
+		return structpb.NewNumberValue(0)
+	}
+
+	key := field.HasAttr("primary_key") || field.HasAttr("unique_key")
+
+	if strings.HasPrefix(typ, fieldSpec_Array) {
+		var (
+			etyp = typ[len(fieldSpec_Array):]
+			ls   = &structpb.ListValue{}
+			n    = 1 + it.rand.Intn(dataFakeArrayMax)
+		)
+		for i := 0; i < n; i++ {
+			if v := it.value(field, etyp, depth+1); v != nil {
+				ls.Values = append(ls.Values, v)
+			}
+		}
+		return structpb.NewListValue(ls)
+	}
+
+	if n := strings.Index(typ, ":"); n > 0 {
+		var (
+			ktyp, vtyp = typ[:n], typ[n+1:]
+			st         = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+			m          = 1 + it.rand.Intn(dataFakeMapMax)
+		)
+		for i := 0; i < m; i++ {
+			k := it.word()
+			if ktyp != FieldSpec_String {
+				k = strconv.Itoa(1 + it.rand.Intn(100))
+			}
+			if vtyp == fieldSpec_Any {
+				st.Fields[k] = structpb.NewStringValue(it.word())
+			} else if v := it.value(&FieldSpec{TagName: k, Fields: field.Fields}, vtyp, depth+1); v != nil {
+				st.Fields[k] = v
+			}
+		}
+		return structpb.NewStructValue(st)
+	}
+
+	if len(field.Enums) > 0 && typ == field.Type {
+		v := field.Enums[it.rand.Intn(len(field.Enums))]
+		switch typ {
+		case FieldSpec_Int, FieldSpec_Uint, FieldSpec_Float:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return structpb.NewNumberValue(f)
+			}
+		case FieldSpec_Bool:
+			return structpb.NewBoolValue(v == "true")
+		}
+		return structpb.NewStringValue(v)
+	}
+
+	switch typ {
+
+	case FieldSpec_String:
+		for _, attr := range field.Attrs {
+			if fa := fieldSpecFuncAttrMatcher(attr); fa != nil {
+				return structpb.NewStringValue(it.id(fa))
+			}
+		}
+		s := it.text(field)
+		if key {
+			s = fmt.Sprintf("%s-%04d", s, it.seq)
+		}
+		return structpb.NewStringValue(s)
+
+	case FieldSpec_Int, FieldSpec_Uint, FieldSpec_Float:
+		if key && typ != FieldSpec_Float {
+			return structpb.NewNumberValue(float64(it.seq))
+		}
+		return structpb.NewNumberValue(it.number(field, typ))
+
+	case FieldSpec_Bool:
+		return structpb.NewBoolValue(it.rand.Intn(2) == 1)
+
+	case FieldSpec_Bytes:
+		b := make([]byte, 16)
+		it.rand.Read(b)
+		return structpb.NewStringValue(base64.StdEncoding.EncodeToString(b))
+
+	case FieldSpec_Struct:
+		return structpb.NewStructValue(&structpb.Struct{
+			Fields: it.fields(field.Fields, depth+1),
+		})
+
+	case fieldSpec_Any:
+		return structpb.NewStringValue(it.word())
+	}
+
+	return nil
+}
+
+// id returns a rand_hex or object_id value, an object id starts with the
+// (fake) time in seconds as the ones of RandObjectId.
+func (it *DataFaker) id(fa *FieldSpec_FuncAttr) string {
+	n := 16
+	if len(fa.intArgs) > 0 {
+		n = fa.intArgs[0]
+	}
+	b := make([]byte, (n+1)/2)
+	it.rand.Read(b)
+	if fa.name == "object_id" && len(b) >= 4 {
+		t := dataFakeTimeBase.Unix() + it.seq*60
+		b[0], b[1], b[2], b[3] = byte(t>>24), byte(t>>16), byte(t>>8), byte(t)
+	}
+	return hex.EncodeToString(b)[:n]
+}
+
+func (it *DataFaker) number(field *FieldSpec, typ string) float64 {
+
+	var minValue, maxValue float64 = 0, 1000
+	if typ == FieldSpec_Int && field.Opts["min_value"] == nil {
+		minValue = -1000
+	}
+
+	switch field.Styles["unit"].GetStringValue() {
+	case "unix-seconds":
+		return float64(dataFakeTimeBase.Unix() + it.rand.Int63n(365*86400))
+	case "unix-milliseconds":
+		return float64(dataFakeTimeBase.UnixMilli() + it.rand.Int63n(365*86400*1000))
+	case "unix-microseconds":
+		return float64(dataFakeTimeBase.UnixMicro() + it.rand.Int63n(365*86400*1000000))
+	}
+
+	if v, ok := field.Opts["min_value"]; ok {
+		minValue = v.GetNumberValue()
+	}
+	if v, ok := field.Opts["max_value"]; ok {
+		maxValue = v.GetNumberValue()
+	}
+	if typ == FieldSpec_Uint && minValue < 0 {
+		minValue = 0
+	}
+	if maxValue < minValue {
+		maxValue = minValue
+	}
+
+	if typ == FieldSpec_Float {
+		v := minValue + it.rand.Float64()*(maxValue-minValue)
+		return math.Trunc(v*100) / 100
+	}
+
+	// a range wider than the exact integers of a float64 (or than an
+	// int64) is drawn in float, below the max of the field type
+	if span := maxValue - minValue; span >= 1<<53 {
+		v := min(math.Floor(minValue+it.rand.Float64()*span), maxValue)
+		if typ == FieldSpec_Uint {
+			return min(v, math.Nextafter(1<<64, 0))
+		}
+		return min(v, math.Nextafter(1<<63, 0))
+	}
+	return minValue + float64(it.rand.Int63n(int64(maxValue-minValue)+1))
+}
+
+func (it *DataFaker) word() string {
+	return dataFakeWords[it.rand.Intn(len(dataFakeWords))]
+}
+
+func (it *DataFaker) pick(ls []string) string {
+	return ls[it.rand.Intn(len(ls))]
+}
+
+func (it *DataFaker) sentence() string {
+	n := 5 + it.rand.Intn(8)
+	ws := make([]string, n)
+	for i := range ws {
+		ws[i] = it.word()
+	}
+	s := strings.Join(ws, " ")
+	return strings.ToUpper(s[:1]) + s[1:] + "."
+}
+
+// text returns a string by the styles and the name of the field.
+func (it *DataFaker) text(field *FieldSpec) string {
+
+	switch field.Styles["text_type"].GetStringValue() {
+	case "md":
+		return fmt.Sprintf("## %s\n\n%s %s\n\n- %s\n- %s",
+			strings.TrimSuffix(it.sentence(), "."), it.sentence(), it.sentence(), it.word(), it.word())
+	case "html":
+		return fmt.Sprintf("<h2>%s</h2><p>%s %s</p>",
+			strings.TrimSuffix(it.sentence(), "."), it.sentence(), it.sentence())
+	}
+
+	if v, ok := field.Styles["textarea_rows"]; ok {
+		ls := make([]string, max(1, int(v.GetNumberValue())))
+		for i := range ls {
+			ls[i] = it.sentence()
+		}
+		return strings.Join(ls, "\n")
+	}
+
+	name := strings.ToLower(field.TagName)
+
+	switch {
+	case strings.Contains(name, "email"):
+		return strings.ToLower(fmt.Sprintf("%s.%s@example.com",
+			it.pick(dataFakeFirstNames), it.pick(dataFakeLastNames)))
+
+	case strings.Contains(name, "url") || strings.Contains(name, "link") || strings.Contains(name, "homepage"):
+		return fmt.Sprintf("https://%s.example.com/%s", it.word(), it.word())
+
+	case strings.Contains(name, "phone") || strings.Contains(name, "mobile"):
+		return fmt.Sprintf("+1-555-01%02d", it.rand.Intn(100))
+
+	case strings.Contains(name, "city"):
+		return it.pick(dataFakeCities)
+
+	case strings.Contains(name, "country"):
+		return it.pick(dataFakeCountries)
+
+	case name == "ip" || strings.HasSuffix(name, "_ip"):
+		return fmt.Sprintf("10.%d.%d.%d", it.rand.Intn(256), it.rand.Intn(256), 1+it.rand.Intn(254))
+
+	case strings.Contains(name, "title") || strings.Contains(name, "subject"):
+		return strings.TrimSuffix(it.sentence(), ".")
+
+	case strings.Contains(name, "desc") || strings.Contains(name, "content") ||
+		strings.Contains(name, "comment") || strings.Contains(name, "note") || strings.Contains(name, "text"):
+		return it.sentence() + " " + it.sentence()
+
+	case strings.Contains(name, "user") || strings.Contains(name, "owner") || strings.Contains(name, "login"):
+		return strings.ToLower(it.pick(dataFakeFirstNames))
+
+	case strings.Contains(name, "name"):
+		return it.pick(dataFakeFirstNames) + " " + it.pick(dataFakeLastNames)
+	}
+
+	return it.word() + "-" + it.word()
+}
+
+// DataSeedFakeRows sets the number of fake rows seeded into the empty tables
+// which have no demo_rows.
+type DataSeedFakeRows int
+
+// DataSeedRandSeed sets the seed of the fake rows.
+type DataSeedRandSeed int64
+
+// DataSeed loads the demo_rows of the table specs into the empty tables of
+// the data service, and returns the number of the rows seeded in each table.
+// Tables which already have rows, or rows in the trash, are skipped. The
+// referenced tables are seeded first, and the references of the fake rows
+// are the keys of the rows of the referenced tables.
+func DataSeed(ds DataService, args ...any) (map[string]int, error) {
+
+	var (
+		fakeRows int
+		seed     int64
+	)
+	for _, arg := range args {
+		switch arg := arg.(type) {
+		case DataSeedFakeRows:
+			fakeRows = int(arg)
+		case DataSeedRandSeed:
+			seed = int64(arg)
+		}
+	}
+
+	inst := ds.Instance()
+	if inst == nil || inst.Spec == nil {
+		return nil, errors.New("data instance spec not found")
+	}
+
+	var (
+		faker     = NewDataFaker(seed)
+		counts    = map[string]int{}
+		tableRows = map[string][]*DataRow{}
+	)
+
+	for _, spec := range refOrder(inst.Name, inst.Spec.Tables) {
+
+		rs, err := ds.Query(&DataQuery{
+			InstanceName: inst.Name,
+			TableName:    spec.Name,
+			Limit:        dataSeedRefRows,
+		})
+		if err != nil {
+			return counts, fmt.Errorf("table (%s) query: %s", spec.Name, err.Error())
+		}
+		if len(rs.Rows) > 0 {
+			tableRows[spec.Name] = rs.Rows
+			continue
+		}
+
+		// a seeded row would be merged into a trashed row of the same key
+		if spec.Option(TableSpec_Option_SoftDelete) != "" {
+			rs, err := ds.Query(&DataQuery{
+				InstanceName: inst.Name,
+				TableName:    spec.Name,
+				Trash:        true,
+				Limit:        1,
+			})
+			if err != nil {
+				return counts, fmt.Errorf("table (%s) query: %s", spec.Name, err.Error())
+			}
+			if len(rs.Rows) > 0 {
+				continue
+			}
+		}
+
+		rows, fake := spec.DemoRows, false
+		if len(rows) == 0 {
+			if fakeRows <= 0 {
+				continue
+			}
+			rows, fake = faker.TableRows(spec, fakeRows), true
+		}
+
+		for _, row := range rows {
+			if fake {
+				faker.refValues(inst.Name, spec, row.Fields, tableRows)
+			}
+			req, err := dataImportRow(spec, row.Fields)
+			if err != nil {
+				return counts, fmt.Errorf("table (%s) row: %s", spec.Name, err.Error())
+			}
+			req.InstanceName = inst.Name
+			req.TableName = spec.Name
+			if _, err = ds.Upsert(req); err != nil {
+				return counts, fmt.Errorf("table (%s) insert: %s", spec.Name, err.Error())
+			}
+			counts[spec.Name] += 1
+			tableRows[spec.Name] = append(tableRows[spec.Name], row)
+		}
+	}
+
+	return counts, nil
+}
+
+// refValues sets the references of the fake fields to the keys of the rows
+// of the referenced tables, the references to a table without rows (or of
+// another instance) are removed.
+func (it *DataFaker) refValues(instanceName string, spec *TableSpec,
+	fields map[string]*structpb.Value, tableRows map[string][]*DataRow) {
+
+	for _, field := range spec.Fields {
+		if field.Ref == nil {
+			continue
+		}
+		var rows []*DataRow
+		if field.Ref.Instance == "" || field.Ref.Instance == instanceName {
+			rows = tableRows[field.Ref.Table]
+		}
+		if len(rows) == 0 {
+			delete(fields, field.TagName)
+			continue
+		}
+		if v, ok := rows[it.rand.Intn(len(rows))].Fields[field.Ref.Field]; ok {
+			fields[field.TagName] = v
+		} else {
+			delete(fields, field.TagName)
+		}
+	}
+}
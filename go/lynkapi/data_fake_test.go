@@ -0,0 +1,222 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/lynkdb/lynkapi/go/lynkapi"
+	"github.com/lynkdb/lynkapi/go/oneobject"
+)
+
+func Test_DataFake(t *testing.T) {
+
+	type Address struct {
+		City    string `json:"city"`
+		Country string `json:"country"`
+	}
+
+	type User struct {
+		Id      string            `json:"id" x_attrs:"primary_key,object_id(12)"`
+		Email   string            `json:"email"`
+		Status  string            `json:"status" x_enums:"active,disabled"`
+		Age     int64             `json:"age" x_value_limits:"18,30,60"`
+		Score   float64           `json:"score" x_value_limits:"1,2,5"`
+		Address *Address          `json:"address"`
+		Tags    []string          `json:"tags"`
+		Labels  map[string]string `json:"labels"`
+	}
+
+	spec, _, err := lynkapi.NewSpecFromStruct(User{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := lynkapi.NewDataFaker(7).Rows(spec, 20)
+	if len(rows) != 20 {
+		t.Fatalf("rows %d", len(rows))
+	}
+	for i, row := range lynkapi.NewDataFaker(7).Rows(spec, 20) {
+		if !proto.Equal(row, rows[i]) {
+			t.Fatalf("rows of the same seed not equal")
+		}
+	}
+	if rows2 := lynkapi.NewDataFaker(8).Rows(spec, 1); proto.Equal(rows2[0], rows[0]) {
+		t.Fatalf("rows of the other seed are equal")
+	}
+
+	ids := map[string]bool{}
+	for _, row := range rows {
+		f := row.Fields
+		if id := f["id"].GetStringValue(); len(id) != 12 || ids[id] {
+			t.Fatalf("id %q", id)
+		} else {
+			ids[id] = true
+		}
+		if s := f["status"].GetStringValue(); s != "active" && s != "disabled" {
+			t.Fatalf("status %q", s)
+		}
+		if v := f["age"].GetNumberValue(); v < 18 || v > 60 || v != float64(int64(v)) {
+			t.Fatalf("age %v", v)
+		}
+		if v := f["score"].GetNumberValue(); v < 1 || v > 5 {
+			t.Fatalf("score %v", v)
+		}
+		if addr := f["address"].GetStructValue(); addr == nil || addr.Fields["city"].GetStringValue() == "" {
+			t.Fatalf("address %v", f["address"])
+		}
+		if ls := f["tags"].GetListValue(); ls == nil || len(ls.Values) == 0 {
+			t.Fatalf("tags %v", f["tags"])
+		}
+		if m := f["labels"].GetStructValue(); m == nil || len(m.Fields) == 0 {
+			t.Fatalf("labels %v", f["labels"])
+		}
+	}
+
+	{ // the value limits of the full int64 and uint64 ranges
+		type Wide struct {
+			Int  int64  `json:"int" x_value_limits:"-9223372036854775808,1,9223372036854775807"`
+			Uint uint64 `json:"uint" x_value_limits:"1,1,18446744073709551615"`
+		}
+		spec, _, err := lynkapi.NewSpecFromStruct(Wide{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range lynkapi.NewDataFaker(7).Rows(spec, 100) {
+			if v := row.Fields["int"].GetNumberValue(); v < math.MinInt64 || v >= math.MaxInt64 {
+				t.Fatalf("int %v", v)
+			}
+			if v := row.Fields["uint"].GetNumberValue(); v < 1 || v >= math.MaxUint64 {
+				t.Fatalf("uint %v", v)
+			}
+		}
+	}
+
+	// seed
+	type Comment struct {
+		Id       string `json:"id" x_attrs:"primary_key,object_id(12)"`
+		UserId   string `json:"user_id" x_ref:"users.id"`
+		ParentId string `json:"parent_id" x_ref:"comments.id"`
+	}
+	type Note struct {
+		Id      string `json:"id" x_attrs:"primary_key"`
+		Deleted int64  `json:"deleted" x_attrs:"deleted"`
+	}
+	type Object struct {
+		Comments []*Comment `json:"comments"`
+		Users    []*User    `json:"users"`
+		Tasks    []*struct {
+			Id    string `json:"id" x_attrs:"primary_key"`
+			Title string `json:"title"`
+		} `json:"tasks"`
+		Notes []*Note `json:"notes"`
+	}
+
+	inst, err := oneobject.NewInstance("test", &Object{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"comments", "users", "tasks"} {
+		if err := inst.TableSetup(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := inst.TableSetup("notes", lynkapi.NewTableOption(lynkapi.TableSpec_Option_SoftDelete, "true")); err != nil {
+		t.Fatal(err)
+	}
+
+	{ // a table with rows in the trash only
+		req := &lynkapi.DataInsert{TableName: "notes"}
+		req.SetField("id", "n1")
+		if _, err := inst.Upsert(req); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := inst.Delete(&lynkapi.DataDelete{
+			TableName: "notes",
+			Filter:    (&lynkapi.DataQuery_Filter{}).And("id", "n1"),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, tbl := range inst.Instance().Spec.Tables {
+		if tbl.Name != "tasks" {
+			continue
+		}
+		for _, title := range []string{"one", "two"} {
+			tbl.DemoRows = append(tbl.DemoRows, &lynkapi.DataRow{
+				Fields: map[string]*structpb.Value{
+					"id":    structpb.NewStringValue(fmt.Sprintf("t%d", len(tbl.DemoRows)+1)),
+					"title": structpb.NewStringValue(title),
+				},
+			})
+		}
+	}
+
+	counts, err := lynkapi.DataSeed(inst, lynkapi.DataSeedFakeRows(5), lynkapi.DataSeedRandSeed(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts["users"] != 5 || counts["comments"] != 5 || counts["tasks"] != 2 || counts["notes"] != 0 {
+		t.Fatalf("seed counts %v", counts)
+	}
+
+	{ // references of the fake rows
+		ids := map[string]bool{}
+		rs, err := inst.Query(&lynkapi.DataQuery{
+			TableName: "users",
+			Limit:     10,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range rs.Rows {
+			ids["users."+row.Fields["id"].GetStringValue()] = true
+		}
+		if rs, err = inst.Query(&lynkapi.DataQuery{
+			TableName: "comments",
+			Limit:     10,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range rs.Rows {
+			ids["comments."+row.Fields["id"].GetStringValue()] = true
+		}
+		for _, row := range rs.Rows {
+			if !ids["users."+row.Fields["user_id"].GetStringValue()] {
+				t.Fatalf("dangling reference user_id %v", row.Fields)
+			}
+			if v := row.Fields["parent_id"].GetStringValue(); v != "" && !ids["comments."+v] {
+				t.Fatalf("dangling reference parent_id %v", row.Fields)
+			}
+		}
+	}
+	rs, err := inst.Query(&lynkapi.DataQuery{
+		TableName: "tasks",
+		Limit:     10,
+	})
+	if err != nil || len(rs.Rows) != 2 {
+		t.Fatalf("seed tasks %v %v", rs, err)
+	}
+
+	// tables with rows are skipped
+	if counts, err = lynkapi.DataSeed(inst, lynkapi.DataSeedFakeRows(5)); err != nil || len(counts) != 0 {
+		t.Fatalf("seed again %v %v", counts, err)
+	}
+}
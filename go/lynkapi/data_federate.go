@@ -0,0 +1,458 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	DataQuery_Join_Inner = "inner"
+	DataQuery_Join_Left  = "left"
+
+	dataFederatedLimit    = 10
+	dataFederatedPageRows = 100
+	dataFederatedRowsMax  = 10000
+)
+
+// dataFederatedQuery runs the query of a table in several instances, and/or
+// joins its rows with another table. The rows are merged in the order of the
+// instances, sorted by the sort field (ties in instance and row id order) and
+// paged over the merged rows, so the pages are the same whatever the number
+// of instances.
+func (it *LynkService) dataFederatedQuery(
+	ctx context.Context,
+	req *DataQuery,
+) (*DataResult, error) {
+
+	if req.Tree != nil || req.History {
+		return nil, NewBadRequestError("tree or history query can not be federated or joined")
+	}
+
+	names := req.InstanceNames
+	if len(names) == 0 {
+		names = []string{req.InstanceName}
+	}
+
+	var spec *TableSpec
+	for i, name := range names {
+		if slices.Contains(names[:i], name) {
+			return nil, NewBadRequestError(fmt.Sprintf("duplicate instance (%s)", name))
+		}
+		// the sort and join are not sent to the query of each instance
+		if _, err := it.dataAccessQuery(ctx, &DataQuery{
+			InstanceName: name,
+			TableName:    req.TableName,
+			Sort:         req.Sort,
+			Join:         req.Join,
+		}); err != nil {
+			return nil, err
+		}
+		_, s := it.dataProject.tableSpec(name, req.TableName)
+		if s == nil {
+			return nil, NewNotFoundError(fmt.Sprintf("table (%s) not found in instance (%s)", req.TableName, name))
+		}
+		if spec == nil {
+			spec = s
+		} else if err := dataFederatedSpecCheck(spec, s); err != nil {
+			return nil, NewBadRequestError(fmt.Sprintf("instance (%s) table (%s): %s", name, req.TableName, err.Error()))
+		}
+	}
+
+	join := req.Join
+	if join != nil {
+		switch join.Type {
+		case "", DataQuery_Join_Inner, DataQuery_Join_Left:
+		default:
+			return nil, NewBadRequestError("invalid join type (inner or left)")
+		}
+		if join.TableName == "" || spec.field(join.Field) == nil {
+			return nil, NewBadRequestError("join table or field not found")
+		}
+	}
+
+	var (
+		offset = max(int(req.Offset), 0)
+		limit  = int(req.Limit)
+		need   = 0
+	)
+	if limit <= 0 {
+		limit = dataFederatedLimit
+	}
+
+	// without a sort and rows dropped by the join, the rows after the page
+	// are not needed
+	if req.Sort == nil && (join == nil || join.Type == DataQuery_Join_Left) {
+		need = offset + limit
+	}
+
+	var (
+		rs = &DataResult{
+			Spec: spec,
+		}
+		start   = time.Now()
+		rowsHit int64
+	)
+	if req.Explain {
+		rs.Plan = &DataQueryPlan{
+			Driver: "federated",
+		}
+	}
+
+	for _, name := range names {
+		t := time.Now()
+		irs, err := it.dataFederatedRows(ctx, req, name, need)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range irs.Rows {
+			row.InstanceName = name
+		}
+		rs.Rows = append(rs.Rows, irs.Rows...)
+		if len(rs.Rows) > dataFederatedRowsMax {
+			return nil, NewBadRequestError(fmt.Sprintf("query hits more than %d rows", dataFederatedRowsMax))
+		}
+		rowsHit += irs.Stats.RowsHit
+		if irs.Plan != nil {
+			rs.Plan.AddStage("instance", name+" ("+irs.Plan.Driver+")", len(irs.Rows), t)
+			rs.Plan.RowsExamined += irs.Plan.RowsExamined
+		}
+	}
+
+	if join != nil {
+		t := time.Now()
+		var err error
+		if rs.Rows, rs.Spec, err = it.dataJoin(ctx, spec, rs.Rows, join); err != nil {
+			return nil, err
+		}
+		rs.Plan.AddStage("join", join.TableName+" on "+join.Field, len(rs.Rows), t)
+	}
+
+	// all the rows are read, so the rows hit are the ones after the join
+	if need == 0 {
+		rowsHit = int64(len(rs.Rows))
+	}
+
+	if req.Sort != nil {
+		t := time.Now()
+		dataSortRows(rs.Rows, req.Sort)
+		rs.Plan.AddStage("sort", req.Sort.Field, len(rs.Rows), t)
+	}
+
+	t := time.Now()
+	if offset < len(rs.Rows) {
+		rs.Rows = rs.Rows[offset:min(offset+limit, len(rs.Rows))]
+	} else {
+		rs.Rows = nil
+	}
+	rs.Plan.AddStage("page", fmt.Sprintf("offset %d limit %d", offset, limit), len(rs.Rows), t)
+
+	rs.Stats = &DataResult_Stats{
+		RowsReturned: int32(len(rs.Rows)),
+		RowsHit:      rowsHit,
+		Offset:       int32(offset),
+		Limit:        int32(limit),
+	}
+
+	if req.Columnar {
+		t := time.Now()
+		rs.Plan.AddStage("columnar", "", len(rs.Rows), t)
+		rs.RowsToCols()
+	}
+
+	if rs.Plan != nil {
+		rs.Plan.RowsReturned = int64(rs.Stats.RowsReturned)
+		rs.Plan.TimeUs = time.Since(start).Microseconds()
+	}
+
+	if len(rs.Rows) == 0 {
+		rs.Status = NewServiceStatus(StatusCode_NotFound, "")
+	} else {
+		rs.Status = NewServiceStatusOK()
+	}
+
+	return rs, nil
+}
+
+// dataFederatedRows reads the rows of the query in an instance, the first
+// need rows, or all if need is 0. The rows hit are the ones of the first
+// page if the driver counts them.
+func (it *LynkService) dataFederatedRows(
+	ctx context.Context,
+	req *DataQuery,
+	instanceName string,
+	need int,
+) (*DataResult, error) {
+
+	ret := &DataResult{
+		Stats: &DataResult_Stats{},
+	}
+	if req.Explain {
+		ret.Plan = &DataQueryPlan{}
+	}
+
+	for offset := 0; ; {
+		q := proto.Clone(req).(*DataQuery)
+		q.InstanceName = instanceName
+		q.InstanceNames = nil
+		q.Join = nil
+		q.Sort = nil
+		q.Columnar = false
+		q.Offset = int32(offset)
+		q.Limit = dataFederatedPageRows
+
+		rs, err := it.dataQuery(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		ret.Rows = append(ret.Rows, rs.Rows...)
+
+		if offset == 0 && rs.Stats != nil {
+			ret.Stats.RowsHit = rs.Stats.RowsHit
+		}
+		if ret.Plan != nil && rs.Plan != nil {
+			ret.Plan.Driver = rs.Plan.Driver
+			ret.Plan.RowsExamined += rs.Plan.RowsExamined
+		}
+
+		if len(rs.Rows) < dataFederatedPageRows ||
+			(need > 0 && len(ret.Rows) >= need) ||
+			len(ret.Rows) > dataFederatedRowsMax {
+			break
+		}
+		offset += len(rs.Rows)
+	}
+
+	if need > 0 && len(ret.Rows) > need {
+		ret.Rows = ret.Rows[:need]
+	}
+	ret.Stats.RowsHit = max(ret.Stats.RowsHit, int64(len(ret.Rows)))
+
+	return ret, nil
+}
+
+// dataJoin sets the fields of the joined row on every row, and drops the rows
+// without one in an inner join. The returned spec has the joined fields.
+func (it *LynkService) dataJoin(
+	ctx context.Context,
+	spec *TableSpec,
+	rows []*DataRow,
+	join *DataQuery_Join,
+) ([]*DataRow, *TableSpec, error) {
+
+	var (
+		joinSpec  *TableSpec
+		joinField = join.JoinField
+		fields    = join.Fields
+		matches   = map[string]*DataRow{}
+		checked   = map[string]bool{}
+		joined    []*DataRow
+	)
+
+	for _, row := range rows {
+
+		instanceName := join.InstanceName
+		if instanceName == "" {
+			instanceName = row.InstanceName
+		}
+
+		_, s := it.dataProject.tableSpec(instanceName, join.TableName)
+		if s == nil {
+			return nil, nil, NewNotFoundError(fmt.Sprintf("table (%s) not found in instance (%s)", join.TableName, instanceName))
+		}
+		if joinSpec == nil {
+			joinSpec = s
+			if joinField == "" {
+				joinField = s.primaryField()
+			}
+			if s.field(joinField) == nil {
+				return nil, nil, NewBadRequestError(fmt.Sprintf("join field (%s) not found", joinField))
+			}
+			if len(fields) == 0 {
+				for _, field := range s.Fields {
+					fields = append(fields, field.TagName)
+				}
+			}
+		} else if err := dataFederatedSpecCheck(joinSpec, s); err != nil {
+			return nil, nil, NewBadRequestError(fmt.Sprintf("instance (%s) table (%s): %s", instanceName, join.TableName, err.Error()))
+		}
+		if !checked[instanceName] {
+			if err := it.dataAccessJoin(ctx, instanceName, join, joinField); err != nil {
+				return nil, nil, err
+			}
+			checked[instanceName] = true
+		}
+
+		var (
+			key   = row.Fields[join.Field]
+			match *DataRow
+		)
+
+		if key != nil {
+			kb, _ := key.MarshalJSON()
+			mkey := instanceName + "\x00" + string(kb)
+			if v, ok := matches[mkey]; ok {
+				match = v
+			} else {
+				rs, err := it.dataQuery(ctx, &DataQuery{
+					InstanceName: instanceName,
+					TableName:    join.TableName,
+					Filter: &DataQuery_Filter{
+						Field: joinField,
+						Value: key,
+					},
+					Limit: 1,
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+				// drivers may not filter on every field type
+				if len(rs.Rows) > 0 && proto.Equal(rs.Rows[0].Fields[joinField], key) {
+					match = rs.Rows[0]
+				}
+				matches[mkey] = match
+			}
+		}
+
+		if match == nil {
+			if join.Type != DataQuery_Join_Left {
+				continue
+			}
+		} else {
+			if row.Fields == nil {
+				row.Fields = map[string]*structpb.Value{}
+			}
+			for _, name := range fields {
+				if v, ok := match.Fields[name]; ok {
+					row.Fields[join.TableName+"."+name] = v
+				}
+			}
+		}
+		joined = append(joined, row)
+	}
+
+	if joinSpec == nil {
+		return joined, spec, nil
+	}
+
+	spec = proto.Clone(spec).(*TableSpec)
+	for _, name := range fields {
+		field := joinSpec.field(name)
+		if field == nil {
+			return nil, nil, NewBadRequestError(fmt.Sprintf("join field (%s) not found", name))
+		}
+		field = proto.Clone(field).(*FieldSpec)
+		field.TagName = join.TableName + "." + field.TagName
+		spec.Fields = append(spec.Fields, field)
+	}
+
+	return joined, spec, nil
+}
+
+// dataAccessJoin checks the read access of the join field and of the fields
+// asked from the joined table (without a list, the joined rows are read with
+// the granted fields only).
+func (it *LynkService) dataAccessJoin(ctx context.Context, instanceName string, join *DataQuery_Join, joinField string) error {
+
+	fields, err := it.dataAccess(ctx, instanceName, join.TableName, DataAccess_Read)
+	if err != nil || fields == nil {
+		return err
+	}
+
+	for _, name := range append([]string{joinField}, join.Fields...) {
+		if !fields[dataAccessPath(name)] {
+			return NewAuthDeniedError(fmt.Sprintf("read access denied on field (%s.%s)", join.TableName, name))
+		}
+	}
+
+	return nil
+}
+
+// dataFederatedSpecCheck checks the tables of two instances have the same
+// primary and typed fields.
+func dataFederatedSpecCheck(a, b *TableSpec) error {
+	if err := dataBackupSpecCheck(a, b); err != nil {
+		return err
+	}
+	return dataBackupSpecCheck(b, a)
+}
+
+// dataSortRows sorts the rows by the value of the sort field (the row id if
+// not set), the rows of the same value are sorted by instance and row id.
+func dataSortRows(rows []*DataRow, sf *DataQuery_SortFilter) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		var n int
+		if sf.Field != "" {
+			n = dataValueCompare(a.Fields[sf.Field], b.Fields[sf.Field])
+		} else {
+			n = cmp.Compare(a.Id, b.Id)
+		}
+		if sf.Type == "desc" {
+			n = -n
+		}
+		if n == 0 {
+			n = cmp.Or(cmp.Compare(a.InstanceName, b.InstanceName), cmp.Compare(a.Id, b.Id))
+		}
+		return n < 0
+	})
+}
+
+// dataValueCompare orders the values: null (or not set), bool, number,
+// string, then the other kinds by their json encoding.
+func dataValueCompare(a, b *structpb.Value) int {
+	rank := func(v *structpb.Value) int {
+		switch v.GetKind().(type) {
+		case nil, *structpb.Value_NullValue:
+			return 0
+		case *structpb.Value_BoolValue:
+			return 1
+		case *structpb.Value_NumberValue:
+			return 2
+		case *structpb.Value_StringValue:
+			return 3
+		}
+		return 4
+	}
+	if n := cmp.Compare(rank(a), rank(b)); n != 0 {
+		return n
+	}
+	switch a.GetKind().(type) {
+	case *structpb.Value_BoolValue:
+		if a.GetBoolValue() == b.GetBoolValue() {
+			return 0
+		} else if a.GetBoolValue() {
+			return 1
+		}
+		return -1
+	case *structpb.Value_NumberValue:
+		return cmp.Compare(a.GetNumberValue(), b.GetNumberValue())
+	case *structpb.Value_StringValue:
+		return cmp.Compare(a.GetStringValue(), b.GetStringValue())
+	case *structpb.Value_StructValue, *structpb.Value_ListValue:
+		ab, _ := a.MarshalJSON()
+		bb, _ := b.MarshalJSON()
+		return cmp.Compare(string(ab), string(bb))
+	}
+	return 0
+}
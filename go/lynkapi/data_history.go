@@ -0,0 +1,188 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	DataRowChange_Create  = "create"
+	DataRowChange_Update  = "update"
+	DataRowChange_Delete  = "delete"
+	DataRowChange_Restore = "restore"
+)
+
+func NewDataRowChange(action, id string, prev, next map[string]*structpb.Value) *DataRowChange {
+	ch := &DataRowChange{
+		Id:      id,
+		Action:  action,
+		Created: time.Now().UnixMilli(),
+		Changes: DataFieldChanges(prev, next),
+	}
+	if action == DataRowChange_Delete {
+		ch.Fields = prev
+	} else {
+		ch.Fields = next
+	}
+	return ch
+}
+
+// DataFieldChanges returns the field level diff of two row values, struct
+// values are compared field by field with dotted paths (ex: `sub.name`).
+func DataFieldChanges(prev, next map[string]*structpb.Value) []*DataFieldChange {
+
+	var (
+		changes []*DataFieldChange
+		diff    func(path string, prev, next map[string]*structpb.Value)
+	)
+
+	diff = func(path string, prev, next map[string]*structpb.Value) {
+
+		keys := make([]string, 0, len(prev)+len(next))
+		for k := range prev {
+			keys = append(keys, k)
+		}
+		for k := range next {
+			if _, ok := prev[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+
+			var (
+				pv, nv = prev[k], next[k]
+				p      = k
+			)
+			if path != "" {
+				p = path + "." + k
+			}
+
+			if ps, ns := pv.GetStructValue(), nv.GetStructValue(); ps != nil && ns != nil {
+				diff(p, ps.Fields, ns.Fields)
+				continue
+			}
+
+			if proto.Equal(pv, nv) {
+				continue
+			}
+
+			changes = append(changes, &DataFieldChange{
+				Path:     p,
+				OldValue: pv,
+				NewValue: nv,
+			})
+		}
+	}
+
+	diff("", prev, next)
+
+	return changes
+}
+
+// DataRowChangesAsOf rebuilds the fields of one row at the point in time asOf
+// (unix milliseconds) from its versions (in ascending order) and its current
+// fields (nil if the row does not exist now).
+func DataRowChangesAsOf(
+	versions []*DataRowChange,
+	current map[string]*structpb.Value,
+	asOf int64,
+) (map[string]*structpb.Value, bool) {
+
+	if len(versions) == 0 {
+		return current, current != nil
+	}
+
+	for i := len(versions) - 1; i >= 0; i-- {
+		if versions[i].Created > asOf {
+			continue
+		}
+		if versions[i].Action == DataRowChange_Delete {
+			return nil, false
+		}
+		return versions[i].Fields, true
+	}
+
+	// the row as it was before the first recorded version
+	switch first := versions[0]; first.Action {
+	case DataRowChange_Create:
+		return nil, false
+
+	case DataRowChange_Delete:
+		return first.Fields, true
+
+	default:
+		if fields := dataFieldsRevert(first.Fields, first.Changes); len(fields) > 0 {
+			return fields, true
+		}
+	}
+
+	return nil, false
+}
+
+func DataRowChangeVersion(versions []*DataRowChange, version uint64) *DataRowChange {
+	for _, v := range versions {
+		if v.Version == version {
+			return v
+		}
+	}
+	return nil
+}
+
+func dataFieldsRevert(fields map[string]*structpb.Value, changes []*DataFieldChange) map[string]*structpb.Value {
+
+	st := proto.Clone(&structpb.Struct{
+		Fields: fields,
+	}).(*structpb.Struct)
+
+	for _, ch := range changes {
+
+		var (
+			keys = strings.Split(ch.Path, ".")
+			up   = st
+		)
+
+		for _, k := range keys[:len(keys)-1] {
+			if up.Fields == nil {
+				up.Fields = map[string]*structpb.Value{}
+			}
+			sv := up.Fields[k].GetStructValue()
+			if sv == nil {
+				sv = &structpb.Struct{}
+				up.Fields[k] = structpb.NewStructValue(sv)
+			}
+			up = sv
+		}
+
+		if up.Fields == nil {
+			up.Fields = map[string]*structpb.Value{}
+		}
+
+		if ch.OldValue == nil {
+			delete(up.Fields, keys[len(keys)-1])
+		} else {
+			up.Fields[keys[len(keys)-1]] = ch.OldValue
+		}
+	}
+
+	return st.Fields
+}
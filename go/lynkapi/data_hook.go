@@ -0,0 +1,151 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	DataHook_Upsert  = "upsert"
+	DataHook_Igsert  = "igsert"
+	DataHook_Update  = "update"
+	DataHook_Delete  = "delete"
+	DataHook_Restore = "restore"
+)
+
+// DataHookEvent is a write on a table, Insert is set for an upsert or igsert,
+// Update for an update, Delete for a delete and Restore for a restore. Rows
+// holds the committed rows in after-hooks, as returned by the driver for this
+// write: the rows as written, or the rows removed by a delete. The after-hooks
+// do not run for the writes with dry_run.
+//
+// The writes applied by the service on behalf of a request run the hooks of
+// their own table too: the cascade delete (Delete) and set_null (Insert) of
+// the rows referencing deleted rows, and each row of a backup restore
+// (Insert).
+type DataHookEvent struct {
+	Context      context.Context
+	Action       string
+	InstanceName string
+	TableName    string
+
+	Insert  *DataInsert
+	Update  *DataUpdate
+	Delete  *DataDelete
+	Restore *DataRestore
+
+	Rows []*DataRow
+}
+
+// DataBeforeHook runs before a write, it can modify the request, or reject it
+// by returning an error (e.g. NewBadRequestError).
+type DataBeforeHook func(ev *DataHookEvent) error
+
+// DataAfterHook runs once a write is committed.
+type DataAfterHook func(ev *DataHookEvent)
+
+type dataHook struct {
+	instanceName string
+	tableName    string
+	before       DataBeforeHook
+	after        DataAfterHook
+}
+
+func (it *dataHook) match(instanceName, tableName string) bool {
+	return (it.instanceName == "" || it.instanceName == "*" || it.instanceName == instanceName) &&
+		(it.tableName == "" || it.tableName == "*" || it.tableName == tableName)
+}
+
+// RegisterDataHook adds the hooks (DataBeforeHook or DataAfterHook, or a func
+// literal of their signatures) of the writes on a table, an empty or "*"
+// instance or table name matches any.
+// Hooks run in the order they are registered, for any driver.
+func (it *LynkService) RegisterDataHook(instanceName, tableName string, args ...any) error {
+
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	n := len(it.dataHooks)
+
+	for _, arg := range args {
+		if arg == nil {
+			continue
+		}
+		hook := &dataHook{
+			instanceName: instanceName,
+			tableName:    tableName,
+		}
+		switch arg.(type) {
+		case DataBeforeHook:
+			hook.before = arg.(DataBeforeHook)
+		case func(*DataHookEvent) error:
+			hook.before = arg.(func(*DataHookEvent) error)
+		case DataAfterHook:
+			hook.after = arg.(DataAfterHook)
+		case func(*DataHookEvent):
+			hook.after = arg.(func(*DataHookEvent))
+		default:
+			it.dataHooks = it.dataHooks[:n]
+			return errors.New("invalid hook type (DataBeforeHook or DataAfterHook)")
+		}
+		it.dataHooks = append(it.dataHooks, hook)
+	}
+
+	return nil
+}
+
+func (it *LynkService) dataHookList(instanceName, tableName string) (before, after []*dataHook) {
+	it.mu.RLock()
+	defer it.mu.RUnlock()
+
+	for _, hook := range it.dataHooks {
+		if !hook.match(instanceName, tableName) {
+			continue
+		}
+		if hook.before != nil {
+			before = append(before, hook)
+		} else {
+			after = append(after, hook)
+		}
+	}
+	return
+}
+
+// dataHookBefore runs the before-hooks of a write, and returns the event for
+// the after-hooks, nil if there are none.
+func (it *LynkService) dataHookBefore(ev *DataHookEvent) (*DataHookEvent, error) {
+
+	before, after := it.dataHookList(ev.InstanceName, ev.TableName)
+
+	for _, hook := range before {
+		if err := hook.before(ev); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(after) == 0 {
+		return nil, nil
+	}
+	return ev, nil
+}
+
+func (it *LynkService) dataHookAfter(ev *DataHookEvent) {
+	_, after := it.dataHookList(ev.InstanceName, ev.TableName)
+	for _, hook := range after {
+		hook.after(ev)
+	}
+}
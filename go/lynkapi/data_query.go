@@ -15,9 +15,17 @@
 package lynkapi
 
 import (
+	"time"
+
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
+const (
+	DataQueryPlan_FullScan = "full_scan"
+	DataQueryPlan_Range    = "range"
+	DataQueryPlan_Key      = "key"
+)
+
 func NewDataQuery() *DataQuery {
 	return &DataQuery{}
 }
@@ -105,3 +113,17 @@ func (it *DataQuery_Filter) StringValue() string {
 	}
 	return ""
 }
+
+// AddStage appends a stage started at start to the plan, it does nothing on
+// a nil plan (the query is not explained).
+func (it *DataQueryPlan) AddStage(name, detail string, rows int, start time.Time) {
+	if it == nil {
+		return
+	}
+	it.Stages = append(it.Stages, &DataQueryPlan_Stage{
+		Name:   name,
+		Detail: detail,
+		Rows:   int64(rows),
+		TimeUs: time.Since(start).Microseconds(),
+	})
+}
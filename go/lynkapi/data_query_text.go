@@ -0,0 +1,621 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	DataQuery_Filter_Eq   = "eq"
+	DataQuery_Filter_Ne   = "ne"
+	DataQuery_Filter_Gt   = "gt"
+	DataQuery_Filter_Gte  = "gte"
+	DataQuery_Filter_Lt   = "lt"
+	DataQuery_Filter_Lte  = "lte"
+	DataQuery_Filter_In   = "in"
+	DataQuery_Filter_Like = "like"
+)
+
+var dataQueryTextOps = map[string]string{
+	"=":  DataQuery_Filter_Eq,
+	"!=": DataQuery_Filter_Ne,
+	"<>": DataQuery_Filter_Ne,
+	">":  DataQuery_Filter_Gt,
+	">=": DataQuery_Filter_Gte,
+	"<":  DataQuery_Filter_Lt,
+	"<=": DataQuery_Filter_Lte,
+}
+
+// DataQueryTextError is an error in a query text, Column is the 1-based
+// position (in characters) of the mistake.
+type DataQueryTextError struct {
+	Column  int
+	Message string
+}
+
+func (it *DataQueryTextError) Error() string {
+	return fmt.Sprintf("column %d: %s", it.Column, it.Message)
+}
+
+type dataQueryToken struct {
+	kind  byte // i: identifier or keyword, n: number, s: string, p: punctuation, 0: end
+	text  string
+	pos   int
+	value string // the unquoted string
+}
+
+type dataQueryParser struct {
+	text   string
+	tokens []*dataQueryToken
+	next   int
+}
+
+// ParseDataQuery compiles a query text into a DataQuery, e.g.
+//
+//	select id,name from inst.users where age > 30 and status in ('a','b')
+//	order by name desc limit 20 offset 40
+//
+// The keywords are case insensitive, the strings are quoted with ' or "
+// (doubled inside), and a filter function is written fn(value).
+func ParseDataQuery(text string) (*DataQuery, error) {
+
+	p := &dataQueryParser{
+		text: text,
+	}
+	if err := p.scan(); err != nil {
+		return nil, err
+	}
+
+	q := &DataQuery{}
+
+	if err := p.keyword("select"); err != nil {
+		return nil, err
+	}
+	if p.peek().text == "*" {
+		p.next++
+	} else {
+		for {
+			tk, err := p.ident("field name")
+			if err != nil {
+				return nil, err
+			}
+			q.Fields = append(q.Fields, tk.text)
+			if p.peek().text != "," {
+				break
+			}
+			p.next++
+		}
+	}
+
+	if err := p.keyword("from"); err != nil {
+		return nil, err
+	}
+	tk, err := p.ident("table name")
+	if err != nil {
+		return nil, err
+	}
+	if n := strings.Index(tk.text, "."); n >= 0 {
+		q.InstanceName, q.TableName = tk.text[:n], tk.text[n+1:]
+		if q.InstanceName == "" || q.TableName == "" || strings.Contains(q.TableName, ".") {
+			return nil, p.errorAt(tk, "expected instance.table")
+		}
+	} else {
+		q.TableName = tk.text
+	}
+
+	if p.isKeyword("where") {
+		p.next++
+		if q.Filter, err = p.parseOr(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.isKeyword("order") {
+		p.next++
+		if err := p.keyword("by"); err != nil {
+			return nil, err
+		}
+		tk, err := p.ident("sort field")
+		if err != nil {
+			return nil, err
+		}
+		q.Sort = &DataQuery_SortFilter{
+			Field: tk.text,
+		}
+		if p.isKeyword("asc") || p.isKeyword("desc") {
+			q.Sort.Type = strings.ToLower(p.peek().text)
+			p.next++
+		}
+	}
+
+	if p.isKeyword("limit") {
+		p.next++
+		if q.Limit, err = p.int32Value(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.isKeyword("offset") {
+		p.next++
+		if q.Offset, err = p.int32Value(); err != nil {
+			return nil, err
+		}
+	}
+
+	if tk := p.peek(); tk.kind != 0 {
+		return nil, p.errorAt(tk, fmt.Sprintf("unexpected %q", tk.text))
+	}
+
+	return q, nil
+}
+
+func (it *dataQueryParser) scan() error {
+
+	for pos := 0; pos < len(it.text); {
+
+		c := it.text[pos]
+		r, _ := utf8.DecodeRuneInString(it.text[pos:])
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			pos++
+
+		case c == '_' || unicode.IsLetter(r):
+			n := pos + 1
+			for n < len(it.text) && isDataQueryLetter(it.text[n:]) {
+				_, size := utf8.DecodeRuneInString(it.text[n:])
+				n += size
+			}
+			it.tokens = append(it.tokens, &dataQueryToken{kind: 'i', text: it.text[pos:n], pos: pos})
+			pos = n
+
+		case c >= '0' && c <= '9' || c == '-' && pos+1 < len(it.text) && it.text[pos+1] >= '0' && it.text[pos+1] <= '9':
+			n := pos + 1
+			for n < len(it.text) && (it.text[n] >= '0' && it.text[n] <= '9' || strings.IndexByte(".eE+-", it.text[n]) >= 0) {
+				if (it.text[n] == '+' || it.text[n] == '-') && it.text[n-1] != 'e' && it.text[n-1] != 'E' {
+					break
+				}
+				n++
+			}
+			it.tokens = append(it.tokens, &dataQueryToken{kind: 'n', text: it.text[pos:n], pos: pos})
+			pos = n
+
+		case c == '\'' || c == '"':
+			var sb strings.Builder
+			n := pos + 1
+			for {
+				if n >= len(it.text) {
+					return it.errorPos(pos, "unterminated string")
+				}
+				if it.text[n] == c {
+					if n+1 < len(it.text) && it.text[n+1] == c {
+						sb.WriteByte(c)
+						n += 2
+						continue
+					}
+					break
+				}
+				sb.WriteByte(it.text[n])
+				n++
+			}
+			it.tokens = append(it.tokens, &dataQueryToken{kind: 's', text: it.text[pos : n+1], pos: pos, value: sb.String()})
+			pos = n + 1
+
+		default:
+			n := pos + 1
+			if n < len(it.text) {
+				if _, ok := dataQueryTextOps[it.text[pos:n+1]]; ok {
+					n++
+				}
+			}
+			tk := &dataQueryToken{kind: 'p', text: it.text[pos:n], pos: pos}
+			if _, ok := dataQueryTextOps[tk.text]; !ok && strings.IndexByte("*,()", c) < 0 {
+				return it.errorPos(pos, fmt.Sprintf("unexpected character %q", r))
+			}
+			it.tokens = append(it.tokens, tk)
+			pos = n
+		}
+	}
+
+	it.tokens = append(it.tokens, &dataQueryToken{pos: len(it.text)})
+
+	return nil
+}
+
+// isDataQueryLetter reports whether s starts with a character of the
+// identifiers (the first one is a letter or '_').
+func isDataQueryLetter(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r == '_' || r == '.' || unicode.IsDigit(r) || unicode.IsLetter(r)
+}
+
+func (it *dataQueryParser) errorPos(pos int, msg string) error {
+	return &DataQueryTextError{
+		Column:  utf8.RuneCountInString(it.text[:pos]) + 1,
+		Message: msg,
+	}
+}
+
+func (it *dataQueryParser) errorAt(tk *dataQueryToken, msg string) error {
+	return it.errorPos(tk.pos, msg)
+}
+
+func (it *dataQueryParser) expected(what string) error {
+	tk := it.peek()
+	if tk.kind == 0 {
+		return it.errorAt(tk, fmt.Sprintf("expected %s, got end of query", what))
+	}
+	return it.errorAt(tk, fmt.Sprintf("expected %s, got %q", what, tk.text))
+}
+
+func (it *dataQueryParser) peek() *dataQueryToken {
+	return it.tokens[it.next]
+}
+
+func (it *dataQueryParser) isKeyword(kw string) bool {
+	tk := it.peek()
+	return tk.kind == 'i' && strings.EqualFold(tk.text, kw)
+}
+
+func (it *dataQueryParser) keyword(kw string) error {
+	if !it.isKeyword(kw) {
+		return it.expected(strings.ToUpper(kw))
+	}
+	it.next++
+	return nil
+}
+
+var dataQueryKeywords = map[string]bool{
+	"select": true, "from": true, "where": true, "and": true, "or": true,
+	"in": true, "like": true, "order": true, "by": true, "asc": true,
+	"desc": true, "limit": true, "offset": true, "true": true, "false": true,
+	"null": true,
+}
+
+func (it *dataQueryParser) ident(what string) (*dataQueryToken, error) {
+	tk := it.peek()
+	if tk.kind != 'i' || dataQueryKeywords[strings.ToLower(tk.text)] {
+		return nil, it.expected(what)
+	}
+	it.next++
+	return tk, nil
+}
+
+func (it *dataQueryParser) int32Value() (int32, error) {
+	tk := it.peek()
+	if tk.kind == 'n' {
+		if v, err := strconv.ParseInt(tk.text, 10, 32); err == nil && v >= 0 {
+			it.next++
+			return int32(v), nil
+		}
+	}
+	return 0, it.expected("a positive integer")
+}
+
+// parseOr parses: and-filter {OR and-filter}
+func (it *dataQueryParser) parseOr() (*DataQuery_Filter, error) {
+	var ls []*DataQuery_Filter
+	for {
+		fr, err := it.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if fr.Type == "or" {
+			ls = append(ls, fr.Inner...)
+		} else {
+			ls = append(ls, fr)
+		}
+		if !it.isKeyword("or") {
+			break
+		}
+		it.next++
+	}
+	if len(ls) == 1 {
+		return ls[0], nil
+	}
+	return &DataQuery_Filter{Type: "or", Inner: ls}, nil
+}
+
+// parseAnd parses: condition {AND condition}
+func (it *dataQueryParser) parseAnd() (*DataQuery_Filter, error) {
+	var ls []*DataQuery_Filter
+	for {
+		fr, err := it.parseCond()
+		if err != nil {
+			return nil, err
+		}
+		if fr.Field == "" && (fr.Type == "" || fr.Type == "and") {
+			ls = append(ls, fr.Inner...)
+		} else {
+			ls = append(ls, fr)
+		}
+		if !it.isKeyword("and") {
+			break
+		}
+		it.next++
+	}
+	if len(ls) == 1 {
+		return ls[0], nil
+	}
+	return &DataQuery_Filter{Inner: ls}, nil
+}
+
+// parseCond parses: ( or-filter ) | field op value | field IN (values) |
+// field LIKE value | fn(value)
+func (it *dataQueryParser) parseCond() (*DataQuery_Filter, error) {
+
+	if it.peek().text == "(" {
+		it.next++
+		fr, err := it.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if it.peek().text != ")" {
+			return nil, it.expected("\")\"")
+		}
+		it.next++
+		return fr, nil
+	}
+
+	tk, err := it.ident("field name or \"(\"")
+	if err != nil {
+		return nil, err
+	}
+
+	fr := &DataQuery_Filter{
+		Field: tk.text,
+	}
+
+	switch op := it.peek(); {
+	case op.text == "(":
+		it.next++
+		fr.Type = "func"
+		if fr.Value, err = it.value(); err != nil {
+			return nil, err
+		}
+		if it.peek().text != ")" {
+			return nil, it.expected("\")\"")
+		}
+		it.next++
+
+	case it.isKeyword("in"):
+		it.next++
+		if it.peek().text != "(" {
+			return nil, it.expected("\"(\"")
+		}
+		it.next++
+		ls := &structpb.ListValue{}
+		for {
+			v, err := it.value()
+			if err != nil {
+				return nil, err
+			}
+			ls.Values = append(ls.Values, v)
+			if it.peek().text != "," {
+				break
+			}
+			it.next++
+		}
+		if it.peek().text != ")" {
+			return nil, it.expected("\",\" or \")\"")
+		}
+		it.next++
+		fr.Op = DataQuery_Filter_In
+		fr.Value = structpb.NewListValue(ls)
+
+	case it.isKeyword("like"):
+		it.next++
+		fr.Op = DataQuery_Filter_Like
+		if it.peek().kind != 's' {
+			return nil, it.expected("a string")
+		}
+		if fr.Value, err = it.value(); err != nil {
+			return nil, err
+		}
+
+	case op.kind == 'p' && dataQueryTextOps[op.text] != "":
+		it.next++
+		if fr.Op = dataQueryTextOps[op.text]; fr.Op == DataQuery_Filter_Eq {
+			fr.Op = ""
+		}
+		if fr.Value, err = it.value(); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, it.expected("an operator")
+	}
+
+	return fr, nil
+}
+
+func (it *dataQueryParser) value() (*structpb.Value, error) {
+	tk := it.peek()
+	switch tk.kind {
+	case 's':
+		it.next++
+		return structpb.NewStringValue(tk.value), nil
+
+	case 'n':
+		v, err := strconv.ParseFloat(tk.text, 64)
+		if err != nil || math.IsInf(v, 0) {
+			return nil, it.errorAt(tk, fmt.Sprintf("invalid number %q", tk.text))
+		}
+		it.next++
+		return structpb.NewNumberValue(v), nil
+
+	case 'i':
+		switch strings.ToLower(tk.text) {
+		case "true", "false":
+			it.next++
+			return structpb.NewBoolValue(strings.EqualFold(tk.text, "true")), nil
+		case "null":
+			it.next++
+			return structpb.NewNullValue(), nil
+		}
+	}
+	return nil, it.expected("a value")
+}
+
+// FormatDataQuery formats a DataQuery into the text of ParseDataQuery.
+func FormatDataQuery(q *DataQuery) (string, error) {
+
+	if q.TableName == "" {
+		return "", fmt.Errorf("table name not set")
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("select ")
+	if len(q.Fields) == 0 {
+		sb.WriteString("*")
+	} else {
+		sb.WriteString(strings.Join(q.Fields, ","))
+	}
+
+	sb.WriteString(" from ")
+	if q.InstanceName != "" {
+		sb.WriteString(q.InstanceName + ".")
+	}
+	sb.WriteString(q.TableName)
+
+	if q.Filter != nil {
+		s, err := formatDataQueryFilter(q.Filter, false)
+		if err != nil {
+			return "", err
+		}
+		if s != "" {
+			sb.WriteString(" where " + s)
+		}
+	}
+
+	if q.Sort != nil && q.Sort.Field != "" {
+		sb.WriteString(" order by " + q.Sort.Field)
+		if q.Sort.Type != "" {
+			sb.WriteString(" " + q.Sort.Type)
+		}
+	}
+
+	if q.Limit > 0 {
+		fmt.Fprintf(&sb, " limit %d", q.Limit)
+	}
+	if q.Offset > 0 {
+		fmt.Fprintf(&sb, " offset %d", q.Offset)
+	}
+
+	return sb.String(), nil
+}
+
+// formatDataQueryFilter formats a filter, an or-filter inside an and-filter
+// is enclosed in parentheses.
+func formatDataQueryFilter(fr *DataQuery_Filter, inAnd bool) (string, error) {
+
+	if fr.Field == "" {
+		var (
+			sep = " and "
+			ls  []string
+		)
+		switch fr.Type {
+		case "", "and":
+		case "or":
+			sep = " or "
+		default:
+			return "", fmt.Errorf("invalid filter type (%s)", fr.Type)
+		}
+		for _, sub := range fr.Inner {
+			s, err := formatDataQueryFilter(sub, fr.Type != "or")
+			if err != nil {
+				return "", err
+			}
+			if s != "" {
+				ls = append(ls, s)
+			}
+		}
+		s := strings.Join(ls, sep)
+		if fr.Type == "or" && inAnd && len(ls) > 1 {
+			s = "(" + s + ")"
+		}
+		return s, nil
+	}
+
+	if fr.Type == "func" {
+		v, err := formatDataQueryValue(fr.Value)
+		if err != nil {
+			return "", err
+		}
+		return fr.Field + "(" + v + ")", nil
+	}
+
+	switch fr.Op {
+	case DataQuery_Filter_In:
+		var ls []string
+		for _, item := range fr.Value.GetListValue().GetValues() {
+			v, err := formatDataQueryValue(item)
+			if err != nil {
+				return "", err
+			}
+			ls = append(ls, v)
+		}
+		if len(ls) == 0 {
+			return "", fmt.Errorf("filter (%s) in: empty list", fr.Field)
+		}
+		return fr.Field + " in (" + strings.Join(ls, ",") + ")", nil
+	}
+
+	op := ""
+	switch fr.Op {
+	case "", DataQuery_Filter_Eq:
+		op = "="
+	case DataQuery_Filter_Like:
+		op = "like"
+	default:
+		for k, v := range dataQueryTextOps {
+			if v == fr.Op && k != "<>" {
+				op = k
+			}
+		}
+		if op == "" {
+			return "", fmt.Errorf("filter (%s): invalid op (%s)", fr.Field, fr.Op)
+		}
+	}
+
+	v, err := formatDataQueryValue(fr.Value)
+	if err != nil {
+		return "", err
+	}
+	return fr.Field + " " + op + " " + v, nil
+}
+
+func formatDataQueryValue(v *structpb.Value) (string, error) {
+	switch v.GetKind().(type) {
+	case nil, *structpb.Value_NullValue:
+		return "null", nil
+	case *structpb.Value_BoolValue:
+		return strconv.FormatBool(v.GetBoolValue()), nil
+	case *structpb.Value_NumberValue:
+		return strconv.FormatFloat(v.GetNumberValue(), 'f', -1, 64), nil
+	case *structpb.Value_StringValue:
+		return "'" + strings.ReplaceAll(v.GetStringValue(), "'", "''") + "'", nil
+	}
+	return "", fmt.Errorf("value of type %T not supported in a query text", v.GetKind())
+}
@@ -0,0 +1,92 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lynkdb/lynkapi/go/lynkapi"
+)
+
+func Test_DataQueryText(t *testing.T) {
+
+	q, err := lynkapi.ParseDataQuery(`SELECT id,name FROM inst.users ` +
+		`WHERE age > 30 and status in ('a','b') and (name like 'jo%' or nick = 'it''s') and active = true ` +
+		`ORDER BY name DESC LIMIT 20 OFFSET 40`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if q.InstanceName != "inst" || q.TableName != "users" || len(q.Fields) != 2 ||
+		q.Sort.Field != "name" || q.Sort.Type != "desc" || q.Limit != 20 || q.Offset != 40 {
+		t.Fatalf("invalid query %v", q)
+	}
+
+	if fr := q.Filter; fr.Field != "" || len(fr.Inner) != 4 {
+		t.Fatalf("invalid filter %v", fr)
+	}
+	if fr := q.Filter.Inner[0]; fr.Field != "age" || fr.Op != lynkapi.DataQuery_Filter_Gt || fr.Value.GetNumberValue() != 30 {
+		t.Fatalf("invalid filter %v", fr)
+	}
+	if fr := q.Filter.Inner[1]; fr.Op != lynkapi.DataQuery_Filter_In || len(fr.Value.GetListValue().GetValues()) != 2 {
+		t.Fatalf("invalid filter %v", fr)
+	}
+	if fr := q.Filter.Inner[2]; fr.Type != "or" || len(fr.Inner) != 2 || fr.Inner[1].Value.GetStringValue() != "it's" {
+		t.Fatalf("invalid filter %v", fr)
+	}
+
+	text, err := lynkapi.FormatDataQuery(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != `select id,name from inst.users where age > 30 and status in ('a','b') and `+
+		`(name like 'jo%' or nick = 'it''s') and active = true order by name desc limit 20 offset 40` {
+		t.Fatalf("invalid text %s", text)
+	}
+
+	// formatted text parses back to the same query
+	if q2, err := lynkapi.ParseDataQuery(text); err != nil {
+		t.Fatal(err)
+	} else if text2, _ := lynkapi.FormatDataQuery(q2); text2 != text {
+		t.Fatalf("invalid round trip %s", text2)
+	}
+
+	if q, err := lynkapi.ParseDataQuery("select * from users where id = 'a'"); err != nil ||
+		len(q.Fields) != 0 || q.Filter.Field != "id" {
+		t.Fatalf("invalid query %v %v", q, err)
+	}
+
+	for _, v := range []struct {
+		text   string
+		column int
+	}{
+		{"select from users", 8},
+		{"select id from users where", 27},
+		{"select id from users where age >> 3", 33},
+		{"select id from users where name = 'abc", 35},
+		{"select id from users where (a = 1", 34},
+		{"select id from users where a in ()", 34},
+		{"select id from users limit -1", 28},
+		{"select id from users where a = 1 order name", 40},
+		{"select id from users where nom = 'é' ☃", 38},
+	} {
+		_, err := lynkapi.ParseDataQuery(v.text)
+		var terr *lynkapi.DataQueryTextError
+		if !errors.As(err, &terr) || terr.Column != v.column {
+			t.Fatalf("text %q: expected error at column %d, got %v", v.text, v.column, err)
+		}
+	}
+}
@@ -0,0 +1,473 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const dataRefQueryLimit = 1000
+
+type dataRefField struct {
+	ds       DataService
+	instance string
+	spec     *TableSpec
+	field    *FieldSpec
+}
+
+type dataRefAction struct {
+	ds       DataService
+	instance string
+	spec     *TableSpec
+	id       string
+	key      *structpb.Value // value of the primary-key
+	field    string          // set_null if not empty, or delete the row
+}
+
+func (it *TableSpec) primaryField() string {
+	for _, field := range it.Fields {
+		if field.HasAttr("primary_key") {
+			return field.TagName
+		}
+	}
+	return ""
+}
+
+func (it *TableSpec) field(tagName string) *FieldSpec {
+	for _, field := range it.Fields {
+		if field.TagName == tagName {
+			return field
+		}
+	}
+	return nil
+}
+
+func (it *dataProjectManager) tableSpec(instanceName, tableName string) (DataService, *TableSpec) {
+	ds := it.service(instanceName)
+	if ds == nil {
+		return nil, nil
+	}
+	inst := ds.Instance()
+	if inst == nil {
+		return nil, nil
+	}
+	return ds, inst.TableSpec(tableName)
+}
+
+// refValue returns the reference value formatted as string, numbers and
+// strings of the same id (ex: 12 and "12") are equal. It returns an empty
+// string for null, zero and the kinds not used as reference.
+func refValue(v *structpb.Value) string {
+	switch v.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		return v.GetStringValue()
+	case *structpb.Value_NumberValue:
+		if n := v.GetNumberValue(); n != 0 {
+			return strconv.FormatFloat(n, 'f', -1, 64)
+		}
+	}
+	return ""
+}
+
+// refQuery returns all rows of the query, it pages through the results so
+// the checks and actions are never truncated.
+func refQuery(ds DataService, q *DataQuery) ([]*DataRow, error) {
+
+	var rows []*DataRow
+
+	for offset := 0; ; {
+		q.Offset = int32(offset)
+		q.Limit = dataRefQueryLimit
+		rs, err := ds.Query(q)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, rs.Rows...)
+		if len(rs.Rows) < dataRefQueryLimit {
+			break
+		}
+		offset += len(rs.Rows)
+	}
+
+	return rows, nil
+}
+
+// refOrder returns the table specs of an instance ordered so that a table
+// comes after the tables it references, the order of specs is kept
+// otherwise (and for reference cycles).
+func refOrder(instanceName string, specs []*TableSpec) []*TableSpec {
+
+	var (
+		ordered = make([]*TableSpec, 0, len(specs))
+		state   = map[string]int{} // 1: visiting, 2: done
+		names   = map[string]*TableSpec{}
+		visit   func(spec *TableSpec)
+	)
+	for _, spec := range specs {
+		names[spec.Name] = spec
+	}
+
+	visit = func(spec *TableSpec) {
+		if state[spec.Name] != 0 {
+			return
+		}
+		state[spec.Name] = 1
+		for _, field := range spec.Fields {
+			if field.Ref == nil || (field.Ref.Instance != "" && field.Ref.Instance != instanceName) {
+				continue
+			}
+			if ref, ok := names[field.Ref.Table]; ok {
+				visit(ref)
+			}
+		}
+		state[spec.Name] = 2
+		ordered = append(ordered, spec)
+	}
+
+	for _, spec := range specs {
+		visit(spec)
+	}
+
+	return ordered
+}
+
+// refFields returns the fields of all instances referencing the table.
+func (it *dataProjectManager) refFields(instanceName, tableName string) []*dataRefField {
+
+	it.mu.RLock()
+	services := make(map[string]DataService, len(it.services))
+	for name, ds := range it.services {
+		services[name] = ds
+	}
+	it.mu.RUnlock()
+
+	var refs []*dataRefField
+
+	for name, ds := range services {
+		inst := ds.Instance()
+		if inst == nil || inst.Spec == nil {
+			continue
+		}
+		for _, spec := range inst.Spec.Tables {
+			for _, field := range spec.Fields {
+				if field.Ref == nil || field.Ref.Table != tableName {
+					continue
+				}
+				if refInstance := field.Ref.Instance; refInstance == instanceName ||
+					(refInstance == "" && name == instanceName) {
+					refs = append(refs, &dataRefField{
+						ds:       ds,
+						instance: name,
+						spec:     spec,
+						field:    field,
+					})
+				}
+			}
+		}
+	}
+
+	return refs
+}
+
+// refCheck validates that the referenced rows of a write exist.
+func (it *dataProjectManager) refCheck(req *DataInsert) error {
+
+	_, spec := it.tableSpec(req.InstanceName, req.TableName)
+	if spec == nil {
+		return nil
+	}
+
+	for i, name := range req.Fields {
+
+		field := spec.field(name)
+		if field == nil || field.Ref == nil || i >= len(req.Values) {
+			continue
+		}
+
+		value := req.Values[i]
+		if refValue(value) == "" {
+			continue
+		}
+
+		refInstance := field.Ref.Instance
+		if refInstance == "" {
+			refInstance = req.InstanceName
+		}
+
+		ds := it.service(refInstance)
+		if ds == nil {
+			return NewBadRequestError(fmt.Sprintf("field (%s) reference instance (%s) not found",
+				name, refInstance))
+		}
+
+		rs, err := ds.Query(&DataQuery{
+			InstanceName: refInstance,
+			TableName:    field.Ref.Table,
+			Filter: &DataQuery_Filter{
+				Field: field.Ref.Field,
+				Value: value,
+			},
+			Limit: 1,
+		})
+		if err != nil {
+			return err
+		}
+		if len(rs.Rows) == 0 || refValue(rs.Rows[0].Fields[field.Ref.Field]) != refValue(value) {
+			return NewBadRequestError(fmt.Sprintf("field (%s) reference %s.%s.%s (%s) not found",
+				name, refInstance, field.Ref.Table, field.Ref.Field, refValue(value)))
+		}
+	}
+
+	return nil
+}
+
+// refDelete returns the on_delete actions of the rows referencing the rows to
+// be deleted, cascades included, in the order to apply them. It returns a
+// Conflict error if a row is referenced with restrict.
+func (it *dataProjectManager) refDelete(req *DataDelete) ([]*dataRefAction, error) {
+
+	var (
+		actions []*dataRefAction
+		deletes = map[string]bool{}
+		plan    func(instanceName, tableName string, filter *DataQuery_Filter) error
+	)
+
+	plan = func(instanceName, tableName string, filter *DataQuery_Filter) error {
+
+		ds := it.service(instanceName)
+		if ds == nil {
+			return nil
+		}
+
+		rows, err := refQuery(ds, &DataQuery{
+			InstanceName: instanceName,
+			TableName:    tableName,
+			Filter:       filter,
+		})
+		if err != nil || len(rows) == 0 {
+			return err
+		}
+
+		for _, ref := range it.refFields(instanceName, tableName) {
+
+			for _, row := range rows {
+
+				value := row.Fields[ref.field.Ref.Field]
+				if refValue(value) == "" {
+					continue
+				}
+
+				refRows, err := refQuery(ref.ds, &DataQuery{
+					InstanceName: ref.instance,
+					TableName:    ref.spec.Name,
+					Filter: &DataQuery_Filter{
+						Field: ref.field.TagName,
+						Value: value,
+					},
+				})
+				if err != nil {
+					return err
+				}
+
+				for _, refRow := range refRows {
+
+					var (
+						key = ref.instance + "." + ref.spec.Name + "." + refRow.Id
+						pk  = refRow.Fields[ref.spec.primaryField()]
+					)
+					if pk == nil {
+						pk = structpb.NewStringValue(refRow.Id)
+					}
+
+					switch ref.field.Ref.OnDelete {
+
+					case FieldSpec_Ref_Cascade:
+						if deletes[key] {
+							continue
+						}
+						deletes[key] = true
+						if err := plan(ref.instance, ref.spec.Name, &DataQuery_Filter{
+							Field: ref.spec.primaryField(),
+							Value: pk,
+						}); err != nil {
+							return err
+						}
+						actions = append(actions, &dataRefAction{
+							ds:       ref.ds,
+							instance: ref.instance,
+							spec:     ref.spec,
+							id:       refRow.Id,
+							key:      pk,
+						})
+
+					case FieldSpec_Ref_SetNull:
+						actions = append(actions, &dataRefAction{
+							ds:       ref.ds,
+							instance: ref.instance,
+							spec:     ref.spec,
+							id:       refRow.Id,
+							key:      pk,
+							field:    ref.field.TagName,
+						})
+
+					default:
+						return NewConflictError(fmt.Sprintf("row (%s) referenced by %s.%s.%s (%s)",
+							row.Id, ref.instance, ref.spec.Name, ref.field.TagName, refRow.Id))
+					}
+				}
+			}
+		}
+
+		return nil
+	}
+
+	if err := plan(req.InstanceName, req.TableName, req.Filter); err != nil {
+		return nil, err
+	}
+
+	// a set_null of a row deleted by a cascade is dropped
+	return slices.DeleteFunc(actions, func(act *dataRefAction) bool {
+		return act.field != "" && deletes[act.instance+"."+act.spec.Name+"."+act.id]
+	}), nil
+}
+
+// dataRefDelete applies the on_delete actions of a delete, and returns the
+// changes of the rows they delete or set_null, with their table name. The
+// access of every action is checked and the before-hooks of every action run
+// before any write, so a restrict reference, a denied table or a rejecting
+// hook anywhere in the cascade leaves the data untouched. A delete with
+// dry_run writes nothing but returns the same changes.
+func (it *LynkService) dataRefDelete(ctx context.Context, req *DataDelete) ([]*DataRowChange, error) {
+
+	actions, err := it.dataProject.refDelete(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		deletes = make([]*DataDelete, len(actions))
+		inserts = make([]*DataInsert, len(actions))
+		events  = make([]*DataHookEvent, len(actions))
+	)
+
+	for i, act := range actions {
+
+		if err := dataDryRun(act.ds, req.DryRun); err != nil {
+			return nil, err
+		}
+		if err := it.dataAccessRef(ctx, act); err != nil {
+			return nil, err
+		}
+
+		ev := &DataHookEvent{
+			Context:      ctx,
+			InstanceName: act.instance,
+			TableName:    act.spec.Name,
+		}
+
+		if act.field == "" {
+			deletes[i] = &DataDelete{
+				InstanceName: act.instance,
+				TableName:    act.spec.Name,
+				Filter: &DataQuery_Filter{
+					Field: act.spec.primaryField(),
+					Value: act.key,
+				},
+				Operator: req.Operator,
+				Purge:    req.Purge,
+				DryRun:   req.DryRun,
+			}
+			ev.Action, ev.Delete = DataHook_Delete, deletes[i]
+		} else {
+			inserts[i] = &DataInsert{
+				InstanceName: act.instance,
+				TableName:    act.spec.Name,
+				Fields:       []string{act.spec.primaryField(), act.field},
+				Values: []*structpb.Value{
+					act.key,
+					structpb.NewNullValue(),
+				},
+				Operator: req.Operator,
+				DryRun:   req.DryRun,
+			}
+			ev.Action, ev.Insert = DataHook_Upsert, inserts[i]
+		}
+
+		if events[i], err = it.dataHookBefore(ev); err != nil {
+			return nil, err
+		}
+	}
+
+	// the writes are validated (by a dry run if the driver has one) before
+	// the first one is applied
+	for i, act := range actions {
+		if req.DryRun || dataDryRun(act.ds, true) != nil {
+			continue
+		}
+		if deletes[i] != nil {
+			dry := proto.Clone(deletes[i]).(*DataDelete)
+			dry.DryRun = true
+			_, err = act.ds.Delete(dry)
+		} else {
+			dry := proto.Clone(inserts[i]).(*DataInsert)
+			dry.DryRun = true
+			_, err = act.ds.Upsert(dry)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var changes []*DataRowChange
+
+	for i, act := range actions {
+
+		var rs *DataResult
+		if deletes[i] != nil {
+			rs, err = act.ds.Delete(deletes[i])
+		} else {
+			rs, err = act.ds.Upsert(inserts[i])
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if events[i] != nil {
+			events[i].Rows = rs.Rows
+		}
+
+		it.dataAccessWriteResult(ctx, act.instance, act.spec.Name, rs)
+		for _, ch := range rs.Changes {
+			ch.TableName = act.spec.Name
+			changes = append(changes, ch)
+		}
+	}
+
+	if !req.DryRun {
+		for _, ev := range events {
+			if ev != nil {
+				it.dataHookAfter(ev)
+			}
+		}
+	}
+
+	return changes, nil
+}
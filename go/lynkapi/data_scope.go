@@ -0,0 +1,178 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// dataScope returns the owner field of the table and the authenticated user,
+// the field is empty if the table is not scoped to the owner.
+func (it *LynkService) dataScope(ctx context.Context, instanceName, tableName string) (string, string, error) {
+
+	_, spec := it.dataProject.tableSpec(instanceName, tableName)
+	if spec == nil {
+		return "", "", nil
+	}
+
+	field := spec.Option(TableSpec_Option_OwnerField)
+	if field == "" {
+		return "", "", nil
+	}
+
+	id := it.authIdentity(ctx)
+	if id == nil {
+		return "", "", NewUnAuthError("auth identity not found")
+	}
+
+	return field, id.User, nil
+}
+
+// dataScopeInsert stamps the owner on a write, and refuses to overwrite a
+// row of another owner. A write may match an existing row by any of the
+// primary and unique keys, so each of them is checked, in the live rows and
+// in the trash (a write of the key of a trashed row purges it).
+func (it *LynkService) dataScopeInsert(ctx context.Context, ds DataService, req *DataInsert) error {
+
+	field, user, err := it.dataScope(ctx, req.InstanceName, req.TableName)
+	if err != nil || field == "" {
+		return err
+	}
+
+	req.SetField(field, user)
+
+	_, spec := it.dataProject.tableSpec(req.InstanceName, req.TableName)
+
+	trash := []bool{false}
+	if spec.Option(TableSpec_Option_SoftDelete) != "" {
+		trash = append(trash, true)
+	}
+
+	for i, name := range req.Fields {
+		key := spec.field(name)
+		if key == nil || (!key.HasAttr("primary_key") && !key.HasAttr("unique_key")) ||
+			i >= len(req.Values) || refValue(req.Values[i]) == "" {
+			continue
+		}
+		for _, t := range trash {
+			rs, err := ds.Query(&DataQuery{
+				InstanceName: req.InstanceName,
+				TableName:    req.TableName,
+				Filter: &DataQuery_Filter{
+					Field: name,
+					Value: req.Values[i],
+				},
+				Limit: 1,
+				Trash: t,
+			})
+			if err != nil {
+				return err
+			}
+			if len(rs.Rows) > 0 && rs.Rows[0].Fields[field].GetStringValue() != user {
+				return NewAuthDeniedError("row owned by another user")
+			}
+		}
+	}
+
+	return nil
+}
+
+// dataScopeFilter restricts the filter of a query or delete to the rows of
+// the authenticated user.
+func (it *LynkService) dataScopeFilter(
+	ctx context.Context,
+	instanceName, tableName string,
+	filter *DataQuery_Filter,
+) (*DataQuery_Filter, error) {
+
+	field, user, err := it.dataScope(ctx, instanceName, tableName)
+	if err != nil || field == "" {
+		return filter, err
+	}
+
+	return filterAnd(filter, field, structpb.NewStringValue(user)), nil
+}
+
+// dataScopeChanges drops the versions of another owner from the history of a
+// query, the key of a purged row may be reused by the row of another user.
+func (it *LynkService) dataScopeChanges(ctx context.Context, instanceName, tableName string, rs *DataResult) error {
+
+	field, user, err := it.dataScope(ctx, instanceName, tableName)
+	if err != nil || field == "" {
+		return err
+	}
+
+	rs.Changes = slices.DeleteFunc(rs.Changes, func(ch *DataRowChange) bool {
+		return ch.Fields[field].GetStringValue() != user
+	})
+
+	return nil
+}
+
+// dataScopeRestore refuses to restore a row of another owner.
+func (it *LynkService) dataScopeRestore(ctx context.Context, hs DataHistoryService, req *DataRestore) error {
+
+	field, user, err := it.dataScope(ctx, req.InstanceName, req.TableName)
+	if err != nil || field == "" {
+		return err
+	}
+
+	_, spec := it.dataProject.tableSpec(req.InstanceName, req.TableName)
+
+	q := &DataQuery{
+		InstanceName: req.InstanceName,
+		TableName:    req.TableName,
+		Filter:       filterAnd(req.Filter, field, structpb.NewStringValue(user)),
+		Limit:        1,
+	}
+	if req.Version > 0 || req.AsOf > 0 {
+		q.History = true
+	} else {
+		q.Trash = spec.Option(TableSpec_Option_SoftDelete) != ""
+	}
+
+	rs, err := hs.Query(q)
+	if err != nil {
+		return err
+	}
+	if len(rs.Rows) == 0 && len(rs.Changes) == 0 {
+		return NewNotFoundError("row not found")
+	}
+
+	// the version restored must be of the owner too, the key of a purged
+	// row may be reused by the row of another user
+	if req.Version > 0 || req.AsOf > 0 {
+		if err := dataDryRun(hs, true); err != nil {
+			return err
+		}
+		dry := proto.Clone(req).(*DataRestore)
+		dry.DryRun = true
+		rs, err := hs.Restore(dry)
+		if err != nil {
+			return err
+		}
+		for _, row := range rs.Rows {
+			if row.Fields[field].GetStringValue() != user {
+				return NewNotFoundError("row not found")
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	dataQueryStreamRows = 500
+)
+
+func (it *LynkService) DataQueryStream(
+	req *DataQuery,
+	stream LynkService_DataQueryStreamServer,
+) error {
+	return it.dataQueryStream(stream.Context(), req, stream.Send)
+}
+
+// dataQueryStream sends the rows of a query in batches, req.Limit is the max
+// number of rows (0 for all). A batch is read once the previous one is sent,
+// and sending blocks while the client does not receive, so a stream holds at
+// most one batch. The batches are read by offset, rows written during the
+// stream may be skipped or sent twice. The spec is sent in the first batch
+// only.
+func (it *LynkService) dataQueryStream(
+	ctx context.Context,
+	req *DataQuery,
+	send func(rs *DataResult) error,
+) error {
+
+	if req.Tree != nil {
+		rs, err := it.DataQuery(ctx, req)
+		if err != nil {
+			return err
+		}
+		return send(rs)
+	}
+
+	var (
+		limit = int(req.Limit)
+		sent  = 0
+	)
+
+	for offset := int(req.Offset); ; {
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		q := proto.Clone(req).(*DataQuery)
+		q.Offset = int32(offset)
+		q.Limit = dataQueryStreamRows
+		if limit > 0 && limit-sent < dataQueryStreamRows {
+			q.Limit = int32(limit - sent)
+		}
+
+		rs, err := it.DataQuery(ctx, q)
+		if err != nil {
+			return err
+		}
+
+		n := rs.rowCount()
+
+		if sent > 0 {
+			if n == 0 {
+				break
+			}
+			rs.Spec = nil
+		}
+		if err := send(rs); err != nil {
+			return err
+		}
+		sent += n
+
+		if n < int(q.Limit) || (limit > 0 && sent >= limit) {
+			break
+		}
+		offset += n
+	}
+
+	return nil
+}
+
+// DataQueryIterator iterates the rows of a query stream.
+type DataQueryIterator struct {
+	recv   func() (*DataResult, error)
+	cancel func()
+	spec   *TableSpec
+	rows   []*DataRow
+	row    *DataRow
+	err    error
+	done   bool
+}
+
+// NewDataQueryIterator returns an iterator of the rows of the results from
+// recv, which returns io.EOF at the end. cancel (optional) is called once
+// the iteration ends.
+func NewDataQueryIterator(recv func() (*DataResult, error), cancel func()) *DataQueryIterator {
+	return &DataQueryIterator{
+		recv:   recv,
+		cancel: cancel,
+	}
+}
+
+// Next receives the next row, it returns false at the end of the rows or on
+// an error (see Err).
+func (it *DataQueryIterator) Next() bool {
+
+	for len(it.rows) == 0 {
+
+		if it.done {
+			return false
+		}
+
+		rs, err := it.recv()
+		if err == io.EOF {
+			it.Close()
+			return false
+		} else if err != nil {
+			it.err = err
+			it.Close()
+			return false
+		}
+
+		if rs.Spec != nil {
+			it.spec = rs.Spec
+		}
+		if rs.Status != nil && rs.Status.Code != StatusCode_OK {
+			if rs.Status.Code != StatusCode_NotFound {
+				it.err = rs.Status.Err()
+			}
+			it.Close()
+			return false
+		}
+
+		if len(rs.Cols) > 0 {
+			crs := &DataResult{
+				Spec:             it.spec,
+				Cols:             rs.Cols,
+				RowInstanceNames: rs.RowInstanceNames,
+				RowDepths:        rs.RowDepths,
+			}
+			if it.rows, err = crs.ColRows(); err != nil {
+				it.err = err
+				it.Close()
+				return false
+			}
+		} else {
+			it.rows = rs.Rows
+		}
+	}
+
+	it.row, it.rows = it.rows[0], it.rows[1:]
+
+	return true
+}
+
+func (it *DataQueryIterator) Row() *DataRow {
+	return it.row
+}
+
+// Spec returns the spec of the table, once the first row is received.
+func (it *DataQueryIterator) Spec() *TableSpec {
+	return it.spec
+}
+
+func (it *DataQueryIterator) Err() error {
+	return it.err
+}
+
+// Close ends the iteration, the stream is canceled if not at its end.
+func (it *DataQueryIterator) Close() {
+	if !it.done {
+		it.done = true
+		it.rows = nil
+		if it.cancel != nil {
+			it.cancel()
+		}
+	}
+}
@@ -27,6 +27,54 @@ var tableSpec_Index_Types = map[string]string{
 	TableSpec_Index_FullTextSearch: "Full Text Search Index",
 }
 
+const (
+	// keep every version of a row, value `on` or the max versions per row
+	TableSpec_Option_History = "history"
+
+	// mark rows deleted in the field with attr `deleted` instead of removing
+	// them, value `on` or the retention of rows in the trash (ex: `720h`),
+	// expired rows are purged by the next write of the table
+	TableSpec_Option_SoftDelete = "soft_delete"
+
+	// the parent-id field of a tree table, default `pid`
+	TableSpec_Option_Tree = "tree"
+
+	// the field holding the owner (user) of a row, rows are scoped to the
+	// authenticated caller
+	TableSpec_Option_OwnerField = "owner_field"
+)
+
+type TableOption struct {
+	Name  string
+	Value string
+}
+
+func NewTableOption(name, value string) TableOption {
+	return TableOption{
+		Name:  name,
+		Value: value,
+	}
+}
+
+func (it *TableSpec) SetOption(name, value string) *TableSpec {
+	if it.Options == nil {
+		it.Options = map[string]string{}
+	}
+	if value == "" {
+		delete(it.Options, name)
+	} else {
+		it.Options[name] = value
+	}
+	return it
+}
+
+func (it *TableSpec) Option(name string) string {
+	if it != nil && it.Options != nil {
+		return it.Options[name]
+	}
+	return ""
+}
+
 func (it *TableSpec) SetField(tagName, typ string) (*FieldSpec, error) {
 
 	if !NameIdentifier.MatchString(tagName) {
@@ -0,0 +1,528 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	DataFormat_CSV   = "csv"
+	DataFormat_JSONL = "jsonl"
+
+	dataExportPageLimit = 100
+	dataImportLineMax   = 16 << 20
+)
+
+// DataTransferService is the part of a DataService used by export and
+// import, a Client is adapted with NewClientDataTransfer.
+type DataTransferService interface {
+	Query(q *DataQuery) (*DataResult, error)
+	Upsert(q *DataInsert) (*DataResult, error)
+	Igsert(q *DataInsert) (*DataResult, error)
+}
+
+type clientDataTransfer struct {
+	c Client
+}
+
+// dataQueryStreamer is a DataTransferService which streams the rows of a
+// query.
+type dataQueryStreamer interface {
+	DataQueryStream(q *DataQuery) *DataQueryIterator
+}
+
+func NewClientDataTransfer(c Client) DataTransferService {
+	return &clientDataTransfer{c: c}
+}
+
+// Query reads the rows in columns, which are smaller to transfer.
+func (it *clientDataTransfer) Query(q *DataQuery) (*DataResult, error) {
+	q = proto.Clone(q).(*DataQuery)
+	q.Columnar = true
+	rs := it.c.DataQuery(q)
+	if rs.Status != nil && rs.Status.Code == StatusCode_NotFound {
+		return rs, nil
+	}
+	if err := rs.Err(); err != nil {
+		return rs, err
+	}
+	if len(rs.Cols) > 0 {
+		rows, err := rs.ColRows()
+		if err != nil {
+			return nil, err
+		}
+		rs.Rows, rs.Cols = rows, nil
+	}
+	return rs, nil
+}
+
+func (it *clientDataTransfer) DataQueryStream(q *DataQuery) *DataQueryIterator {
+	q = proto.Clone(q).(*DataQuery)
+	q.Columnar = true
+	return it.c.DataQueryStream(q)
+}
+
+func (it *clientDataTransfer) Upsert(q *DataInsert) (*DataResult, error) {
+	rs := it.c.DataUpsert(q)
+	return rs, rs.Err()
+}
+
+func (it *clientDataTransfer) Igsert(q *DataInsert) (*DataResult, error) {
+	rs := it.c.DataIgsert(q)
+	return rs, rs.Err()
+}
+
+func DataFormatFromFile(name string) string {
+	switch {
+	case strings.HasSuffix(name, ".csv"):
+		return DataFormat_CSV
+	case strings.HasSuffix(name, ".jsonl"), strings.HasSuffix(name, ".ndjson"):
+		return DataFormat_JSONL
+	}
+	return ""
+}
+
+// DataExport writes the rows of the query q to w in the format (csv or jsonl),
+// all rows of the table are exported if q has no filter, q.Limit is the max
+// number of rows (0 for all). CSV columns are the fields of the TableSpec.
+// The rows are read from a stream if ds supports it, else by pages.
+func DataExport(ds DataTransferService, q *DataQuery, w io.Writer, format string) (int, error) {
+
+	var (
+		n      = 0
+		fields []*FieldSpec
+		cw     *csv.Writer
+	)
+
+	switch format {
+	case DataFormat_CSV:
+		cw = csv.NewWriter(w)
+	case DataFormat_JSONL:
+	default:
+		return 0, fmt.Errorf("invalid format (%s)", format)
+	}
+
+	header := func(spec *TableSpec) error {
+		if cw == nil || fields != nil {
+			return nil
+		}
+		if spec == nil || len(spec.Fields) == 0 {
+			return errors.New("table spec not found")
+		}
+		fields = spec.Fields
+		header := make([]string, len(fields))
+		for i, field := range fields {
+			header[i] = field.TagName
+		}
+		return cw.Write(header)
+	}
+
+	write := func(spec *TableSpec, row *DataRow) error {
+		if cw != nil {
+			if err := header(spec); err != nil {
+				return err
+			}
+			record := make([]string, len(fields))
+			for i, field := range fields {
+				record[i] = dataExportCell(field, row.Fields[field.TagName])
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		} else {
+			b, err := json.Marshal(row.Fields)
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(append(b, '\n')); err != nil {
+				return err
+			}
+		}
+		n += 1
+		return nil
+	}
+
+	q = proto.Clone(q).(*DataQuery)
+
+	if st, ok := ds.(dataQueryStreamer); ok {
+
+		iter := st.DataQueryStream(q)
+		defer iter.Close()
+
+		for iter.Next() {
+			if err := write(iter.Spec(), iter.Row()); err != nil {
+				return n, err
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return n, err
+		}
+		if n == 0 && iter.Spec() != nil {
+			if err := header(iter.Spec()); err != nil {
+				return n, err
+			}
+		}
+
+	} else {
+
+		limit, offset := int(q.Limit), int(q.Offset)
+
+		for {
+
+			q.Offset = int32(offset)
+			q.Limit = dataExportPageLimit
+			if limit > 0 && limit-n < dataExportPageLimit {
+				q.Limit = int32(limit - n)
+			}
+
+			rs, err := ds.Query(q)
+			if err != nil {
+				return n, err
+			}
+
+			if len(rs.Rows) == 0 && rs.Spec == nil {
+				break
+			}
+			if err := header(rs.Spec); err != nil {
+				return n, err
+			}
+
+			for _, row := range rs.Rows {
+				if err := write(rs.Spec, row); err != nil {
+					return n, err
+				}
+			}
+
+			offset += len(rs.Rows)
+
+			if len(rs.Rows) < int(q.Limit) || (limit > 0 && n >= limit) {
+				break
+			}
+		}
+	}
+
+	if cw != nil {
+		cw.Flush()
+		return n, cw.Error()
+	}
+
+	return n, nil
+}
+
+func dataExportCell(field *FieldSpec, v *structpb.Value) string {
+	if v == nil {
+		return ""
+	}
+	switch v.Kind.(type) {
+	case nil, *structpb.Value_NullValue:
+		return ""
+
+	case *structpb.Value_StringValue:
+		return v.GetStringValue()
+
+	case *structpb.Value_NumberValue:
+		switch field.Type {
+		case FieldSpec_Int:
+			return strconv.FormatInt(int64(v.GetNumberValue()), 10)
+		case FieldSpec_Uint:
+			return strconv.FormatUint(uint64(v.GetNumberValue()), 10)
+		}
+		return strconv.FormatFloat(v.GetNumberValue(), 'f', -1, 64)
+
+	case *structpb.Value_BoolValue:
+		return strconv.FormatBool(v.GetBoolValue())
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+type DataImportOptions struct {
+	InstanceName string
+	TableName    string
+	Format       string
+
+	// insert new rows only (Igsert), existing rows are kept
+	Igsert bool
+
+	// validate and coerce the rows without writing
+	DryRun bool
+}
+
+type DataImportError struct {
+	Line  int    `json:"line"`
+	Id    string `json:"id,omitempty"`
+	Error string `json:"error"`
+}
+
+type DataImportReport struct {
+	Rows    int                `json:"rows"`
+	Valid   int                `json:"valid"`
+	Written int                `json:"written"`
+	Errors  []*DataImportError `json:"errors,omitempty"`
+}
+
+func (it *DataImportReport) rowError(line int, id string, err error) {
+	it.Errors = append(it.Errors, &DataImportError{
+		Line:  line,
+		Id:    id,
+		Error: err.Error(),
+	})
+}
+
+// DataImport reads the rows from r in the format of opts (csv with a header
+// line, or jsonl), coerces the values to the field types of spec and writes
+// every row by Upsert (or Igsert). A row which fails is recorded in the
+// report and the import continues, the error is returned only if the input
+// can not be read.
+func DataImport(ds DataTransferService, spec *TableSpec, r io.Reader, opts *DataImportOptions) (*DataImportReport, error) {
+
+	if spec == nil {
+		return nil, errors.New("table spec not found")
+	}
+	if opts == nil {
+		opts = &DataImportOptions{}
+	}
+
+	var (
+		report = &DataImportReport{}
+		pk     = spec.primaryField()
+	)
+
+	write := func(line int, fields map[string]*structpb.Value, err error) {
+
+		report.Rows += 1
+
+		id := fields[pk].GetStringValue()
+		if err != nil {
+			report.rowError(line, id, err)
+			return
+		}
+
+		req, err := dataImportRow(spec, fields)
+		if err != nil {
+			report.rowError(line, id, err)
+			return
+		}
+		req.InstanceName = opts.InstanceName
+		req.TableName = opts.TableName
+
+		report.Valid += 1
+		if opts.DryRun {
+			return
+		}
+
+		if opts.Igsert {
+			_, err = ds.Igsert(req)
+		} else {
+			_, err = ds.Upsert(req)
+		}
+		if err != nil {
+			report.rowError(line, id, err)
+			return
+		}
+		report.Written += 1
+	}
+
+	switch opts.Format {
+
+	case DataFormat_CSV:
+
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = -1
+
+		header, err := cr.Read()
+		if err == io.EOF {
+			return report, nil
+		} else if err != nil {
+			return report, err
+		}
+		for _, name := range header {
+			if spec.field(name) == nil {
+				return report, fmt.Errorf("column (%s) not found in table spec", name)
+			}
+		}
+
+		for {
+			record, err := cr.Read()
+			if err == io.EOF {
+				break
+			}
+			var line int
+			if pe, ok := err.(*csv.ParseError); ok {
+				line = pe.StartLine
+			} else if err != nil {
+				return report, err
+			} else {
+				line, _ = cr.FieldPos(0)
+			}
+			if err == nil && len(record) != len(header) {
+				err = fmt.Errorf("wrong number of fields (%d, expected %d)", len(record), len(header))
+			}
+			fields := map[string]*structpb.Value{}
+			if err == nil {
+				for i, name := range header {
+					if record[i] != "" {
+						fields[name] = structpb.NewStringValue(record[i])
+					}
+				}
+			}
+			write(line, fields, err)
+		}
+
+	case DataFormat_JSONL:
+
+		sc := bufio.NewScanner(r)
+		sc.Buffer(nil, dataImportLineMax)
+
+		for line := 1; sc.Scan(); line++ {
+			b := sc.Bytes()
+			if len(strings.TrimSpace(string(b))) == 0 {
+				continue
+			}
+			var fields map[string]*structpb.Value
+			err := json.Unmarshal(b, &fields)
+			write(line, fields, err)
+		}
+		if err := sc.Err(); err != nil {
+			return report, err
+		}
+
+	default:
+		return nil, fmt.Errorf("invalid format (%s)", opts.Format)
+	}
+
+	return report, nil
+}
+
+func dataImportRow(spec *TableSpec, fields map[string]*structpb.Value) (*DataInsert, error) {
+
+	req := &DataInsert{}
+
+	for _, field := range spec.Fields {
+		v, ok := fields[field.TagName]
+		if !ok || v == nil {
+			continue
+		}
+		if _, ok := v.Kind.(*structpb.Value_NullValue); ok {
+			continue
+		}
+		v, err := dataImportValue(field, v)
+		if err != nil {
+			return nil, fmt.Errorf("field (%s): %s", field.TagName, err.Error())
+		}
+		req.Fields = append(req.Fields, field.TagName)
+		req.Values = append(req.Values, v)
+	}
+
+	if len(req.Fields) < len(fields) {
+		for name := range fields {
+			if spec.field(name) == nil {
+				return nil, fmt.Errorf("field (%s) not found in table spec", name)
+			}
+		}
+	}
+
+	if pk := spec.primaryField(); pk != "" && fields[pk] == nil {
+		return nil, fmt.Errorf("primary key (%s) not setup", pk)
+	}
+
+	return req, nil
+}
+
+// dataImportValue coerces a value (string from a csv cell, or json value) to
+// the field type.
+func dataImportValue(field *FieldSpec, v *structpb.Value) (*structpb.Value, error) {
+
+	s, isString := v.Kind.(*structpb.Value_StringValue)
+
+	switch field.Type {
+
+	case FieldSpec_String, FieldSpec_StringTerm, FieldSpec_StringText, FieldSpec_Bytes:
+		switch v.Kind.(type) {
+		case *structpb.Value_StringValue:
+			return v, nil
+		case *structpb.Value_NumberValue:
+			return structpb.NewStringValue(strconv.FormatFloat(v.GetNumberValue(), 'f', -1, 64)), nil
+		case *structpb.Value_BoolValue:
+			return structpb.NewStringValue(strconv.FormatBool(v.GetBoolValue())), nil
+		}
+
+	case FieldSpec_Int, FieldSpec_Uint, FieldSpec_Float:
+		num := v.GetNumberValue()
+		if isString {
+			f, err := strconv.ParseFloat(strings.TrimSpace(s.StringValue), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s value (%s)", field.Type, s.StringValue)
+			}
+			num = f
+		} else if _, ok := v.Kind.(*structpb.Value_NumberValue); !ok {
+			break
+		}
+		if field.Type != FieldSpec_Float && num != math.Trunc(num) {
+			return nil, fmt.Errorf("invalid %s value (%v)", field.Type, num)
+		}
+		if field.Type == FieldSpec_Uint && num < 0 {
+			return nil, fmt.Errorf("invalid %s value (%v)", field.Type, num)
+		}
+		return structpb.NewNumberValue(num), nil
+
+	case FieldSpec_Bool:
+		if isString {
+			b, err := strconv.ParseBool(strings.TrimSpace(s.StringValue))
+			if err != nil {
+				return nil, fmt.Errorf("invalid bool value (%s)", s.StringValue)
+			}
+			return structpb.NewBoolValue(b), nil
+		}
+		if _, ok := v.Kind.(*structpb.Value_BoolValue); ok {
+			return v, nil
+		}
+
+	default:
+		if isString {
+			var jv structpb.Value
+			if err := json.Unmarshal([]byte(s.StringValue), &jv); err != nil {
+				return nil, fmt.Errorf("invalid %s value (%s)", field.Type, err.Error())
+			}
+			v = &jv
+		}
+		switch {
+		case strings.HasPrefix(field.Type, fieldSpec_Array):
+			if _, ok := v.Kind.(*structpb.Value_ListValue); ok {
+				return v, nil
+			}
+		case field.Type == FieldSpec_Struct || strings.Contains(field.Type, ":"):
+			if _, ok := v.Kind.(*structpb.Value_StructValue); ok {
+				return v, nil
+			}
+		default:
+			return v, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid %s value", field.Type)
+}
@@ -0,0 +1,148 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/lynkdb/lynkapi/go/lynkapi"
+	"github.com/lynkdb/lynkapi/go/oneobject"
+)
+
+func Test_DataTransfer(t *testing.T) {
+
+	type Item struct {
+		Id     string            `json:"id" x_attrs:"primary_key"`
+		Name   string            `json:"name"`
+		Count  int64             `json:"count"`
+		Active bool              `json:"active"`
+		Labels map[string]string `json:"labels"`
+	}
+	type Object struct {
+		Items []*Item `json:"items"`
+	}
+
+	newTable := func() *oneobject.Instance {
+		inst, err := oneobject.NewInstance("test", &Object{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := inst.TableSetup("items"); err != nil {
+			t.Fatal(err)
+		}
+		return inst
+	}
+
+	src := newTable()
+	spec := src.Instance().TableSpec("items")
+
+	csvIn := "id,name,count,active,labels\n" +
+		"a,Alpha,1,true,\"{\"\"k\"\":\"\"v\"\"}\"\n" +
+		"b,Beta,x,false,\n" +
+		",Gamma,3,false,\n" +
+		"c,\"Gamma, C\",3,false,\n"
+
+	{ // dry-run
+		report, err := lynkapi.DataImport(src, spec, strings.NewReader(csvIn), &lynkapi.DataImportOptions{
+			InstanceName: "test",
+			TableName:    "items",
+			Format:       lynkapi.DataFormat_CSV,
+			DryRun:       true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if report.Rows != 4 || report.Valid != 2 || report.Written != 0 || len(report.Errors) != 2 {
+			t.Fatalf("invalid report %+v", report)
+		}
+		if e := report.Errors[0]; e.Line != 3 || e.Id != "b" {
+			t.Fatalf("invalid row error %+v", e)
+		}
+		if n, _ := lynkapi.DataExport(src, &lynkapi.DataQuery{TableName: "items"}, &bytes.Buffer{}, lynkapi.DataFormat_CSV); n != 0 {
+			t.Fatalf("dry-run rows written %d", n)
+		}
+	}
+
+	report, err := lynkapi.DataImport(src, spec, strings.NewReader(csvIn), &lynkapi.DataImportOptions{
+		InstanceName: "test",
+		TableName:    "items",
+		Format:       lynkapi.DataFormat_CSV,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Written != 2 || len(report.Errors) != 2 {
+		t.Fatalf("invalid report %+v", report)
+	}
+
+	for _, format := range []string{lynkapi.DataFormat_CSV, lynkapi.DataFormat_JSONL} {
+
+		var buf bytes.Buffer
+		n, err := lynkapi.DataExport(src, &lynkapi.DataQuery{TableName: "items"}, &buf, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 2 {
+			t.Fatalf("%s: invalid export rows %d", format, n)
+		}
+
+		dst := newTable()
+		report, err := lynkapi.DataImport(dst, spec, &buf, &lynkapi.DataImportOptions{
+			InstanceName: "test",
+			TableName:    "items",
+			Format:       format,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if report.Written != 2 || len(report.Errors) != 0 {
+			t.Fatalf("%s: invalid report %+v", format, report)
+		}
+
+		rs, err := dst.Query((&lynkapi.DataQuery{TableName: "items"}).AddFilter("id", "a"))
+		if err != nil || len(rs.Rows) != 1 {
+			t.Fatalf("%s: row not imported %v", format, err)
+		}
+		row := rs.Rows[0]
+		if row.Fields["count"].GetNumberValue() != 1 || !row.Fields["active"].GetBoolValue() ||
+			row.Fields["labels"].GetStructValue().GetFields()["k"].GetStringValue() != "v" {
+			t.Fatalf("%s: invalid row %v", format, row.Fields)
+		}
+	}
+
+	{ // paging
+		dst := newTable()
+		for i := 0; i < 250; i++ {
+			req := &lynkapi.DataInsert{TableName: "items"}
+			req.SetField("id", fmt.Sprintf("%04d", i))
+			if _, err := dst.Upsert(req); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, limit := range []int32{0, 120} {
+			n, err := lynkapi.DataExport(dst, &lynkapi.DataQuery{TableName: "items", Limit: limit},
+				&bytes.Buffer{}, lynkapi.DataFormat_JSONL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (limit == 0 && n != 250) || (limit > 0 && n != int(limit)) {
+				t.Fatalf("invalid export rows %d (limit %d)", n, limit)
+			}
+		}
+	}
+}
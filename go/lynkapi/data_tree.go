@@ -0,0 +1,247 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	DataQuery_Tree_Children  = "children"
+	DataQuery_Tree_Subtree   = "subtree"
+	DataQuery_Tree_Ancestors = "ancestors"
+
+	dataTreeQueryLimit = 1000
+	dataTreeMaxDepth   = 100
+)
+
+func (it *TableSpec) pidField() string {
+	if v := it.Option(TableSpec_Option_Tree); v != "" {
+		return v
+	}
+	if it.field("pid") != nil {
+		return "pid"
+	}
+	return ""
+}
+
+// filterAnd returns the filter fr (kept as a whole, with its op and nested
+// groups) and field = value.
+func filterAnd(fr *DataQuery_Filter, field string, value *structpb.Value) *DataQuery_Filter {
+	and := &DataQuery_Filter{}
+	if fr != nil {
+		and.Inner = append(and.Inner, fr)
+	}
+	and.Inner = append(and.Inner, &DataQuery_Filter{
+		Field: field,
+		Value: value,
+	})
+	return and
+}
+
+// treeQuery runs the tree query modes over the parent-id field of a table:
+// the direct children of a node, its subtree (in depth-first order) up to a
+// depth, or its ancestors from the parent to the root. Each row has its
+// depth relative to the node.
+func (it *dataProjectManager) treeQuery(ds DataService, req *DataQuery) (*DataResult, error) {
+
+	inst := ds.Instance()
+	if inst == nil {
+		return nil, NewNotFoundError("instance not found")
+	}
+	spec := inst.TableSpec(req.TableName)
+	if spec == nil {
+		return nil, NewNotFoundError("table not found")
+	}
+
+	tree := req.Tree
+
+	pidField := tree.PidField
+	if pidField == "" {
+		pidField = spec.pidField()
+	}
+	idField := spec.primaryField()
+	if pidField == "" || idField == "" {
+		return nil, NewBadRequestError("table is not a tree (pid or primary-key field not found)")
+	}
+
+	var (
+		rs = &DataResult{
+			Spec: spec,
+		}
+		visited = map[string]bool{}
+		limit   = int(req.Limit)
+	)
+	if limit <= 0 {
+		limit = dataTreeQueryLimit
+	}
+
+	query := func(filter *DataQuery_Filter, limit int) ([]*DataRow, error) {
+		q := proto.Clone(req).(*DataQuery)
+		q.Tree = nil
+		q.Filter = filter
+		q.Offset = 0
+		q.Limit = int32(limit)
+		qrs, err := ds.Query(q)
+		if err != nil {
+			return nil, err
+		}
+		return qrs.Rows, nil
+	}
+
+	switch tree.Type {
+
+	case DataQuery_Tree_Children, DataQuery_Tree_Subtree:
+
+		maxDepth := 1
+		if tree.Type == DataQuery_Tree_Subtree {
+			maxDepth = int(tree.Depth)
+			if maxDepth <= 0 || maxDepth > dataTreeMaxDepth {
+				maxDepth = dataTreeMaxDepth
+			}
+		}
+
+		var walk func(pid string, depth int) error
+		walk = func(pid string, depth int) error {
+			rows, err := query(filterAnd(req.Filter, pidField, structpb.NewStringValue(pid)), dataTreeQueryLimit)
+			if err != nil {
+				return err
+			}
+			for _, row := range rows {
+				if len(rs.Rows) >= limit {
+					return nil
+				}
+				if visited[row.Id] || row.Id == tree.Id {
+					continue
+				}
+				visited[row.Id] = true
+				row.Depth = int32(depth)
+				rs.Rows = append(rs.Rows, row)
+				if depth < maxDepth {
+					if err := walk(row.Id, depth+1); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}
+
+		if err := walk(tree.Id, 1); err != nil {
+			return nil, err
+		}
+
+	case DataQuery_Tree_Ancestors:
+
+		if tree.Id == "" {
+			return nil, NewBadRequestError("tree id not setup")
+		}
+
+		visited[tree.Id] = true
+
+		for id, depth := tree.Id, 0; depth <= dataTreeMaxDepth && len(rs.Rows) < limit; depth++ {
+			rows, err := query(filterAnd(req.Filter, idField, structpb.NewStringValue(id)), 1)
+			if err != nil {
+				return nil, err
+			}
+			if len(rows) == 0 {
+				break
+			}
+			if depth > 0 {
+				rows[0].Depth = int32(depth)
+				rs.Rows = append(rs.Rows, rows[0])
+			}
+			id = rows[0].Fields[pidField].GetStringValue()
+			if id == "" || visited[id] {
+				break
+			}
+			visited[id] = true
+		}
+
+	default:
+		return nil, NewBadRequestError(fmt.Sprintf("invalid tree type (%s)", tree.Type))
+	}
+
+	if len(rs.Rows) == 0 {
+		rs.Status = NewServiceStatus(StatusCode_NotFound, "")
+	} else {
+		rs.Status = NewServiceStatusOK()
+	}
+
+	return rs, nil
+}
+
+// treeCheck refuses a write which makes a row an ancestor of itself.
+func (it *dataProjectManager) treeCheck(req *DataInsert) error {
+
+	ds, spec := it.tableSpec(req.InstanceName, req.TableName)
+	if spec == nil {
+		return nil
+	}
+
+	var (
+		pidField = spec.pidField()
+		idField  = spec.primaryField()
+		id, pid  string
+	)
+	if pidField == "" || idField == "" {
+		return nil
+	}
+
+	for i, name := range req.Fields {
+		if i >= len(req.Values) {
+			break
+		}
+		switch name {
+		case idField:
+			id = req.Values[i].GetStringValue()
+		case pidField:
+			pid = req.Values[i].GetStringValue()
+		}
+	}
+
+	if id == "" || pid == "" {
+		return nil
+	}
+
+	visited := map[string]bool{}
+
+	for depth := 0; pid != "" && !visited[pid] && depth <= dataTreeMaxDepth; depth++ {
+		if pid == id {
+			return NewBadRequestError(fmt.Sprintf("pid cycle detected (%s)", id))
+		}
+		visited[pid] = true
+		rs, err := ds.Query(&DataQuery{
+			InstanceName: req.InstanceName,
+			TableName:    req.TableName,
+			Filter: &DataQuery_Filter{
+				Field: idField,
+				Value: structpb.NewStringValue(pid),
+			},
+			Limit: 1,
+		})
+		if err != nil {
+			return err
+		}
+		if len(rs.Rows) == 0 {
+			break
+		}
+		pid = rs.Rows[0].Fields[pidField].GetStringValue()
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkapi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	dataUpdateLimit = 1000
+)
+
+// dataDryRun refuses a write with dry_run on a service which would commit it.
+func dataDryRun(ds DataService, dryRun bool) error {
+	if !dryRun {
+		return nil
+	}
+	if dr, ok := ds.(DataDryRunService); ok && dr.DryRun() {
+		return nil
+	}
+	return NewNotImplementedError("instance dry run not supported")
+}
+
+// DataUpdate sets the fields of the rows matching the filter, zero values
+// included (the fields are the field mask if not set). Each row is written by
+// an update of its primary key, so the validation, references and scope of an
+// upsert apply to every row, and a row deleted after the rows are matched is
+// skipped, never created again. All the rows are validated before the first
+// one is written.
+func (it *LynkService) DataUpdate(
+	ctx context.Context,
+	req *DataUpdate,
+) (*DataResult, error) {
+	ds := it.dataProject.service(req.InstanceName)
+	if ds == nil {
+		return nil, NewNotFoundError("instance not found")
+	}
+	us, ok := ds.(DataUpdateService)
+	if !ok {
+		return nil, NewNotImplementedError("instance update not supported")
+	}
+	if err := dataDryRun(ds, req.DryRun); err != nil {
+		return nil, err
+	}
+	if len(req.Fields) == 0 || len(req.Fields) != len(req.Values) {
+		return nil, NewBadRequestError("invalid request (fields != values)")
+	}
+	_, spec := it.dataProject.tableSpec(req.InstanceName, req.TableName)
+	if spec == nil {
+		return nil, NewNotFoundError("table not found")
+	}
+	pk := spec.primaryField()
+	if pk == "" {
+		return nil, NewBadRequestError("primary key not found")
+	}
+	if slices.Contains(req.Fields, pk) {
+		return nil, NewBadRequestError(fmt.Sprintf("primary key (%s) can not be updated", pk))
+	}
+	if err := it.dataAccessInsert(ctx, &DataInsert{
+		InstanceName: req.InstanceName,
+		TableName:    req.TableName,
+		Fields:       req.Fields,
+		FieldMask:    req.FieldMask,
+	}); err != nil {
+		return nil, err
+	}
+	var err error
+	if req.Filter, err = it.dataScopeFilter(ctx, req.InstanceName, req.TableName, req.Filter); err != nil {
+		return nil, err
+	}
+	req.Operator = it.authOperator(ctx)
+	hev, err := it.dataHookBefore(&DataHookEvent{
+		Context:      ctx,
+		Action:       DataHook_Update,
+		InstanceName: req.InstanceName,
+		TableName:    req.TableName,
+		Update:       req,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := ds.Query(&DataQuery{
+		InstanceName: req.InstanceName,
+		TableName:    req.TableName,
+		Filter:       req.Filter,
+		Limit:        dataUpdateLimit + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rs.Rows) > dataUpdateLimit {
+		return nil, NewBadRequestError(fmt.Sprintf("too many rows to update (> %d)", dataUpdateLimit))
+	}
+
+	// the same value of a unique key on two rows fails on the second write
+	if len(rs.Rows) > 1 {
+		for _, name := range req.Fields {
+			if field := spec.field(name); field != nil && field.HasAttr("unique_key") {
+				return nil, NewConflictError(fmt.Sprintf("unique key (%s) can not be set on %d rows",
+					name, len(rs.Rows)))
+			}
+		}
+	}
+
+	result := &DataResult{
+		Status: NewServiceStatusOK(),
+		Stats: &DataResult_Stats{
+			RowsHit: int64(len(rs.Rows)),
+		},
+	}
+
+	var writes []*DataInsert
+	for _, row := range rs.Rows {
+		ins := &DataInsert{
+			InstanceName: req.InstanceName,
+			TableName:    req.TableName,
+			Fields:       append([]string{pk}, req.Fields...),
+			Values:       append([]*structpb.Value{row.Fields[pk]}, req.Values...),
+			Operator:     req.Operator,
+			DryRun:       true,
+			FieldMask:    req.FieldMask,
+		}
+		if len(ins.FieldMask) == 0 {
+			ins.FieldMask = req.Fields
+		}
+		if err := it.dataScopeInsert(ctx, ds, ins); err != nil {
+			return nil, err
+		}
+		if err := it.dataProject.writeCheck(ins); err != nil {
+			return nil, err
+		}
+		writes = append(writes, ins)
+	}
+
+	// every row is validated (by a dry run if the driver has one) before the
+	// first row is written, so a row refused by the driver writes none
+	if dataDryRun(ds, true) == nil {
+		for _, ins := range writes {
+			wrs, err := us.Update(ins)
+			if err != nil {
+				return nil, err
+			}
+			if req.DryRun {
+				result.Rows = append(result.Rows, wrs.Rows...)
+				result.Changes = append(result.Changes, wrs.Changes...)
+			}
+		}
+	}
+
+	if !req.DryRun {
+		for _, ins := range writes {
+			ins.DryRun = false
+			wrs, err := us.Update(ins)
+			if err != nil {
+				return nil, err
+			}
+			result.Rows = append(result.Rows, wrs.Rows...)
+			result.Changes = append(result.Changes, wrs.Changes...)
+		}
+	}
+
+	if !req.DryRun && hev != nil {
+		hev.Rows = result.Rows
+		it.dataHookAfter(hev)
+	}
+	it.dataAccessWriteResult(ctx, req.InstanceName, req.TableName, result)
+
+	return result, nil
+}
@@ -79,6 +79,14 @@ func (it *LynkServer) SetupIdentityAuthService(s hauth2.IdentityAuthService) {
 	it.Service.identityAuthService = s
 }
 
+func (it *LynkServer) SetupAuthIdentityResolver(fn AuthIdentityResolver) {
+	it.Service.SetupAuthIdentityResolver(fn)
+}
+
+func (it *LynkServer) SetupDataAccessPolicy(p *DataAccessPolicy) {
+	it.Service.SetupDataAccessPolicy(p)
+}
+
 func (it *LynkServer) grpcSetup() error {
 
 	host, port, err := net.SplitHostPort(it.cfg.Bind)
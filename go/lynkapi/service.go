@@ -20,15 +20,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	hauth2 "github.com/hooto/hauth/v2/hauth"
 	"github.com/hooto/hlog4g/hlog"
 	"github.com/hooto/httpsrv"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -45,6 +49,12 @@ type LynkService struct {
 
 	identityAuthService hauth2.IdentityAuthService
 
+	authIdentityResolver AuthIdentityResolver
+
+	dataAccessPolicy *DataAccessPolicy
+
+	dataHooks []*dataHook
+
 	dataProject *dataProjectManager
 }
 
@@ -359,7 +369,7 @@ func (it *LynkService) Exec(
 			ctx = ctx2.(context.Context)
 		}
 		if err := prs[1].Interface(); err != nil {
-			return NewResponseError(StatusCode_BadRequest, err.(error).Error()), nil
+			return newResponseError(StatusCode_BadRequest, err.(error)), nil
 		}
 	}
 
@@ -421,11 +431,92 @@ func (it *LynkService) DataQuery(
 	req *DataQuery,
 ) (*DataResult, error) {
 	// hlog.Printf("info", "data-query %s", jsonEncode(req))
+	if len(req.InstanceNames) > 0 || req.Join != nil {
+		return it.dataFederatedQuery(ctx, req)
+	}
+	return it.dataQuery(ctx, req)
+}
+
+func (it *LynkService) dataQuery(
+	ctx context.Context,
+	req *DataQuery,
+) (*DataResult, error) {
 	ds := it.dataProject.service(req.InstanceName)
 	if ds == nil {
 		return nil, NewNotFoundError("instance not found")
 	}
-	return ds.Query(req)
+	start := time.Now()
+	fields, err := it.dataAccessQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var rs *DataResult
+	if req.Filter, err = it.dataScopeFilter(ctx, req.InstanceName, req.TableName, req.Filter); err != nil {
+		return nil, err
+	}
+	queried := time.Now()
+	// the driver returns the columns unless the service reads the rows
+	columnar := req.Columnar
+	req.Columnar = columnar && dataColumnar(ds) &&
+		req.Tree == nil && !req.History && !req.DictDisplay
+	if req.Tree != nil {
+		rs, err = it.dataProject.treeQuery(ds, req)
+	} else {
+		rs, err = ds.Query(req)
+	}
+	req.Columnar = columnar
+	if err != nil {
+		return rs, err
+	}
+	if req.History {
+		if err := it.dataScopeChanges(ctx, req.InstanceName, req.TableName, rs); err != nil {
+			return nil, err
+		}
+	}
+	if req.Explain {
+		dataQueryPlan(ds, req, rs, start, queried)
+	}
+	if req.DictDisplay {
+		t := time.Now()
+		it.dataProject.dictDisplay(rs)
+		rs.Plan.AddStage("dict_display", "", len(rs.Rows), t)
+	}
+	dataAccessResult(rs, fields)
+	if req.Columnar && len(rs.Cols) == 0 {
+		t := time.Now()
+		rs.RowsToCols()
+		rs.Plan.AddStage("columnar", "", len(rs.Cols), t)
+	}
+	if rs.Plan != nil {
+		rs.Plan.TimeUs = time.Since(start).Microseconds()
+	}
+	return rs, err
+}
+
+// dataQueryPlan sets the service stages in the plan of a query, the driver
+// stages are kept if the driver explains its queries.
+func dataQueryPlan(ds DataService, req *DataQuery, rs *DataResult, start, queried time.Time) {
+
+	plan := rs.Plan
+	if plan == nil || req.Tree != nil {
+		plan = &DataQueryPlan{
+			RowsReturned: int64(rs.rowCount()),
+		}
+		if inst := ds.Instance(); inst != nil && inst.Spec != nil {
+			plan.Driver = inst.Spec.Driver
+		}
+		if req.Tree != nil {
+			plan.AddStage("tree", req.Tree.Type, rs.rowCount(), queried)
+		} else {
+			plan.AddStage("driver", "not explained", rs.rowCount(), queried)
+		}
+		rs.Plan = plan
+	}
+
+	plan.Stages = append([]*DataQueryPlan_Stage{{
+		Name:   "access",
+		TimeUs: queried.Sub(start).Microseconds(),
+	}}, plan.Stages...)
 }
 
 func (it *LynkService) DataUpsert(
@@ -436,7 +527,54 @@ func (it *LynkService) DataUpsert(
 	if ds == nil {
 		return nil, NewNotFoundError("instance not found")
 	}
-	return ds.Upsert(req)
+	if err := dataDryRun(ds, req.DryRun); err != nil {
+		return nil, err
+	}
+	return it.dataUpsert(ctx, ds, req, false)
+}
+
+// dataUpsert is the write path of DataUpsert, also used by a backup restore:
+// access, write hooks, scope, references, dictionary and tree checks. The
+// references are not checked for a row of the trash (trashed) of a backup.
+func (it *LynkService) dataUpsert(
+	ctx context.Context,
+	ds DataService,
+	req *DataInsert,
+	trashed bool,
+) (*DataResult, error) {
+	if err := it.dataAccessInsert(ctx, req); err != nil {
+		return nil, err
+	}
+	hev, err := it.dataHookBefore(&DataHookEvent{
+		Context:      ctx,
+		Action:       DataHook_Upsert,
+		InstanceName: req.InstanceName,
+		TableName:    req.TableName,
+		Insert:       req,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := it.dataScopeInsert(ctx, ds, req); err != nil {
+		return nil, err
+	}
+	check := it.dataProject.writeCheck
+	if trashed {
+		check = it.dataProject.trashCheck
+	}
+	if err := check(req); err != nil {
+		return nil, err
+	}
+	req.Operator = it.authOperator(ctx)
+	rs, err := ds.Upsert(req)
+	if err == nil && hev != nil && !req.DryRun {
+		hev.Rows = rs.Rows
+		it.dataHookAfter(hev)
+	}
+	if err == nil {
+		it.dataAccessWriteResult(ctx, req.InstanceName, req.TableName, rs)
+	}
+	return rs, err
 }
 
 func (it *LynkService) DataIgsert(
@@ -447,7 +585,38 @@ func (it *LynkService) DataIgsert(
 	if ds == nil {
 		return nil, NewNotFoundError("instance not found")
 	}
-	return ds.Igsert(req)
+	if err := dataDryRun(ds, req.DryRun); err != nil {
+		return nil, err
+	}
+	if err := it.dataAccessInsert(ctx, req); err != nil {
+		return nil, err
+	}
+	hev, err := it.dataHookBefore(&DataHookEvent{
+		Context:      ctx,
+		Action:       DataHook_Igsert,
+		InstanceName: req.InstanceName,
+		TableName:    req.TableName,
+		Insert:       req,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := it.dataScopeInsert(ctx, ds, req); err != nil {
+		return nil, err
+	}
+	if err := it.dataProject.writeCheck(req); err != nil {
+		return nil, err
+	}
+	req.Operator = it.authOperator(ctx)
+	rs, err := ds.Igsert(req)
+	if err == nil && hev != nil && !req.DryRun {
+		hev.Rows = rs.Rows
+		it.dataHookAfter(hev)
+	}
+	if err == nil {
+		it.dataAccessWriteResult(ctx, req.InstanceName, req.TableName, rs)
+	}
+	return rs, err
 }
 
 func (it *LynkService) DataDelete(
@@ -458,7 +627,131 @@ func (it *LynkService) DataDelete(
 	if ds == nil {
 		return nil, NewNotFoundError("instance not found")
 	}
-	return ds.Delete(req)
+	if err := dataDryRun(ds, req.DryRun); err != nil {
+		return nil, err
+	}
+	if err := it.dataAccessTable(ctx, req.InstanceName, req.TableName, DataAccess_Delete); err != nil {
+		return nil, err
+	}
+	var err error
+	if req.Filter, err = it.dataScopeFilter(ctx, req.InstanceName, req.TableName, req.Filter); err != nil {
+		return nil, err
+	}
+	req.Operator = it.authOperator(ctx)
+	hev, err := it.dataHookBefore(&DataHookEvent{
+		Context:      ctx,
+		Action:       DataHook_Delete,
+		InstanceName: req.InstanceName,
+		TableName:    req.TableName,
+		Delete:       req,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the delete is validated before the references apply their cascades
+	// and set_null
+	if !req.DryRun && dataDryRun(ds, true) == nil {
+		dry := proto.Clone(req).(*DataDelete)
+		dry.DryRun = true
+		if _, err := ds.Delete(dry); err != nil {
+			return nil, err
+		}
+	}
+	refChanges, err := it.dataRefDelete(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := ds.Delete(req)
+	if err == nil && hev != nil && !req.DryRun {
+		hev.Rows = rs.Rows
+		it.dataHookAfter(hev)
+	}
+	if err == nil {
+		it.dataAccessWriteResult(ctx, req.InstanceName, req.TableName, rs)
+		// the rows deleted or set_null by the references, of their own table
+		rs.Changes = append(rs.Changes, refChanges...)
+	}
+	return rs, err
+}
+
+func (it *LynkService) DataRestore(
+	ctx context.Context,
+	req *DataRestore,
+) (*DataResult, error) {
+	ds := it.dataProject.service(req.InstanceName)
+	if ds == nil {
+		return nil, NewNotFoundError("instance not found")
+	}
+	hs, ok := ds.(DataHistoryService)
+	if !ok {
+		return nil, NewNotImplementedError("instance history not supported")
+	}
+	if err := it.dataAccessTable(ctx, req.InstanceName, req.TableName, DataAccess_Write); err != nil {
+		return nil, err
+	}
+	if err := it.dataScopeRestore(ctx, hs, req); err != nil {
+		return nil, err
+	}
+	req.Operator = it.authOperator(ctx)
+	hev, err := it.dataHookBefore(&DataHookEvent{
+		Context:      ctx,
+		Action:       DataHook_Restore,
+		InstanceName: req.InstanceName,
+		TableName:    req.TableName,
+		Restore:      req,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := it.dataRestoreCheck(hs, req); err != nil {
+		return nil, err
+	}
+	if req.DryRun {
+		rs, err := hs.Restore(req)
+		if err == nil {
+			it.dataAccessWriteResult(ctx, req.InstanceName, req.TableName, rs)
+		}
+		return rs, err
+	}
+	rs, err := hs.Restore(req)
+	if err == nil && hev != nil {
+		hev.Rows = rs.Rows
+		it.dataHookAfter(hev)
+	}
+	return rs, err
+}
+
+// dataRestoreCheck runs the checks of an upsert (references, dictionary and
+// tree) on the row a restore would write, read by a dry_run of the restore.
+func (it *LynkService) dataRestoreCheck(hs DataHistoryService, req *DataRestore) error {
+
+	if err := dataDryRun(hs, true); err != nil {
+		return err
+	}
+
+	dry := proto.Clone(req).(*DataRestore)
+	dry.DryRun = true
+
+	rs, err := hs.Restore(dry)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rs.Rows {
+		ins := &DataInsert{
+			InstanceName: req.InstanceName,
+			TableName:    req.TableName,
+		}
+		for _, name := range slices.Sorted(maps.Keys(row.Fields)) {
+			ins.Fields = append(ins.Fields, name)
+			ins.Values = append(ins.Values, row.Fields[name])
+		}
+		if err := it.dataProject.writeCheck(ins); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (it *LynkService) HttpHandler(w http.ResponseWriter, r *http.Request) {
@@ -469,6 +762,7 @@ func (it *LynkService) HttpHandler(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			return NewResponseError(StatusCode_BadRequest, err.Error())
 		}
+		body := b
 
 		if len(b) == 0 {
 			b = []byte("{}")
@@ -500,7 +794,7 @@ func (it *LynkService) HttpHandler(w http.ResponseWriter, r *http.Request) {
 
 		ctx := context.WithValue(context.TODO(), RequestSpecNameInContext, method.method.RequestSpec)
 
-		ctx = &xContext{Context: ctx, request: r}
+		ctx = &xContext{Context: ctx, request: r, body: body}
 
 		if method.refPreMethod != nil {
 			prs := method.refPreMethod.Func.Call([]reflect.Value{
@@ -542,7 +836,7 @@ func (it *LynkService) HttpHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 		if err := rss[1].Interface(); err != nil {
-			return NewResponseError(StatusCode_InternalServerError, err.(error).Error())
+			return newResponseError(StatusCode_InternalServerError, err.(error))
 		}
 
 		js, err := json.Marshal(rss[0].Interface())
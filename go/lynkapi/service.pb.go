@@ -650,7 +650,7 @@ var file_lynkapi_service_proto_rawDesc = []byte{
 	0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
 	0x12, 0x2b, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
 	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x32, 0xab, 0x03,
+	0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x32, 0xb1, 0x06,
 	0x0a, 0x0b, 0x4c, 0x79, 0x6e, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3e, 0x0a,
 	0x07, 0x41, 0x70, 0x69, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61,
 	0x70, 0x69, 0x2e, 0x41, 0x70, 0x69, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
@@ -670,18 +670,42 @@ var file_lynkapi_service_proto_rawDesc = []byte{
 	0x36, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x12, 0x2e, 0x6c,
 	0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79,
 	0x1a, 0x13, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52,
-	0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0a, 0x44, 0x61, 0x74, 0x61, 0x55,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x0f, 0x44, 0x61, 0x74, 0x61, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x12, 0x2e, 0x6c, 0x79, 0x6e,
+	0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x13,
+	0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x38, 0x0a, 0x0a, 0x44, 0x61, 0x74, 0x61, 0x55,
 	0x70, 0x73, 0x65, 0x72, 0x74, 0x12, 0x13, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e,
 	0x44, 0x61, 0x74, 0x61, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x1a, 0x13, 0x2e, 0x6c, 0x79, 0x6e,
 	0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22,
 	0x00, 0x12, 0x38, 0x0a, 0x0a, 0x44, 0x61, 0x74, 0x61, 0x49, 0x67, 0x73, 0x65, 0x72, 0x74, 0x12,
 	0x13, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x49, 0x6e,
 	0x73, 0x65, 0x72, 0x74, 0x1a, 0x13, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44,
-	0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x00, 0x42, 0x30, 0x48, 0x03, 0x5a,
-	0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x79, 0x6e, 0x6b,
-	0x64, 0x62, 0x2f, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x6f, 0x2f, 0x6c, 0x79,
-	0x6e, 0x6b, 0x61, 0x70, 0x69, 0x3b, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0a, 0x44,
+	0x61, 0x74, 0x61, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x13, 0x2e, 0x6c, 0x79, 0x6e, 0x6b,
+	0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x1a, 0x13,
+	0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0a, 0x44, 0x61, 0x74, 0x61, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x12, 0x13, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61,
+	0x74, 0x61, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x1a, 0x13, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61,
+	0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x00, 0x12,
+	0x3a, 0x0a, 0x0b, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x14,
+	0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x1a, 0x13, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44,
+	0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0a, 0x44,
+	0x61, 0x74, 0x61, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x12, 0x1a, 0x2e, 0x6c, 0x79, 0x6e, 0x6b,
+	0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22,
+	0x00, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x11, 0x44, 0x61, 0x74, 0x61, 0x42, 0x61, 0x63, 0x6b, 0x75,
+	0x70, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x18, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61,
+	0x70, 0x69, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x43, 0x68, 0x75,
+	0x6e, 0x6b, 0x1a, 0x19, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x61, 0x74,
+	0x61, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x00, 0x28,
+	0x01, 0x42, 0x30, 0x48, 0x03, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6c, 0x79, 0x6e, 0x6b, 0x64, 0x62, 0x2f, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69,
+	0x2f, 0x67, 0x6f, 0x2f, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x3b, 0x6c, 0x79, 0x6e, 0x6b,
+	0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -714,7 +738,13 @@ var file_lynkapi_service_proto_goTypes = []interface{}{
 	(*structpb.Struct)(nil),     // 13: google.protobuf.Struct
 	(*DataQuery)(nil),           // 14: lynkapi.DataQuery
 	(*DataInsert)(nil),          // 15: lynkapi.DataInsert
-	(*DataResult)(nil),          // 16: lynkapi.DataResult
+	(*DataUpdate)(nil),          // 16: lynkapi.DataUpdate
+	(*DataDelete)(nil),          // 17: lynkapi.DataDelete
+	(*DataRestore)(nil),         // 18: lynkapi.DataRestore
+	(*DataBackupRequest)(nil),   // 19: lynkapi.DataBackupRequest
+	(*DataBackupChunk)(nil),     // 20: lynkapi.DataBackupChunk
+	(*DataResult)(nil),          // 21: lynkapi.DataResult
+	(*DataBackupReport)(nil),    // 22: lynkapi.DataBackupReport
 }
 var file_lynkapi_service_proto_depIdxs = []int32{
 	10, // 0: lynkapi.ServiceMethod.request_spec:type_name -> lynkapi.TypeSpec
@@ -732,17 +762,29 @@ var file_lynkapi_service_proto_depIdxs = []int32{
 	8,  // 12: lynkapi.LynkService.Exec:input_type -> lynkapi.Request
 	4,  // 13: lynkapi.LynkService.DataProject:input_type -> lynkapi.DataProjectRequest
 	14, // 14: lynkapi.LynkService.DataQuery:input_type -> lynkapi.DataQuery
-	15, // 15: lynkapi.LynkService.DataUpsert:input_type -> lynkapi.DataInsert
-	15, // 16: lynkapi.LynkService.DataIgsert:input_type -> lynkapi.DataInsert
-	3,  // 17: lynkapi.LynkService.ApiList:output_type -> lynkapi.ApiListResponse
-	7,  // 18: lynkapi.LynkService.Auth:output_type -> lynkapi.AuthResponse
-	9,  // 19: lynkapi.LynkService.Exec:output_type -> lynkapi.Response
-	5,  // 20: lynkapi.LynkService.DataProject:output_type -> lynkapi.DataProjectResponse
-	16, // 21: lynkapi.LynkService.DataQuery:output_type -> lynkapi.DataResult
-	16, // 22: lynkapi.LynkService.DataUpsert:output_type -> lynkapi.DataResult
-	16, // 23: lynkapi.LynkService.DataIgsert:output_type -> lynkapi.DataResult
-	17, // [17:24] is the sub-list for method output_type
-	10, // [10:17] is the sub-list for method input_type
+	14, // 15: lynkapi.LynkService.DataQueryStream:input_type -> lynkapi.DataQuery
+	15, // 16: lynkapi.LynkService.DataUpsert:input_type -> lynkapi.DataInsert
+	15, // 17: lynkapi.LynkService.DataIgsert:input_type -> lynkapi.DataInsert
+	16, // 18: lynkapi.LynkService.DataUpdate:input_type -> lynkapi.DataUpdate
+	17, // 19: lynkapi.LynkService.DataDelete:input_type -> lynkapi.DataDelete
+	18, // 20: lynkapi.LynkService.DataRestore:input_type -> lynkapi.DataRestore
+	19, // 21: lynkapi.LynkService.DataBackup:input_type -> lynkapi.DataBackupRequest
+	20, // 22: lynkapi.LynkService.DataBackupRestore:input_type -> lynkapi.DataBackupChunk
+	3,  // 23: lynkapi.LynkService.ApiList:output_type -> lynkapi.ApiListResponse
+	7,  // 24: lynkapi.LynkService.Auth:output_type -> lynkapi.AuthResponse
+	9,  // 25: lynkapi.LynkService.Exec:output_type -> lynkapi.Response
+	5,  // 26: lynkapi.LynkService.DataProject:output_type -> lynkapi.DataProjectResponse
+	21, // 27: lynkapi.LynkService.DataQuery:output_type -> lynkapi.DataResult
+	21, // 28: lynkapi.LynkService.DataQueryStream:output_type -> lynkapi.DataResult
+	21, // 29: lynkapi.LynkService.DataUpsert:output_type -> lynkapi.DataResult
+	21, // 30: lynkapi.LynkService.DataIgsert:output_type -> lynkapi.DataResult
+	21, // 31: lynkapi.LynkService.DataUpdate:output_type -> lynkapi.DataResult
+	21, // 32: lynkapi.LynkService.DataDelete:output_type -> lynkapi.DataResult
+	21, // 33: lynkapi.LynkService.DataRestore:output_type -> lynkapi.DataResult
+	20, // 34: lynkapi.LynkService.DataBackup:output_type -> lynkapi.DataBackupChunk
+	22, // 35: lynkapi.LynkService.DataBackupRestore:output_type -> lynkapi.DataBackupReport
+	23, // [23:36] is the sub-list for method output_type
+	10, // [10:23] is the sub-list for method input_type
 	10, // [10:10] is the sub-list for extension type_name
 	10, // [10:10] is the sub-list for extension extendee
 	0,  // [0:10] is the sub-list for field type_name
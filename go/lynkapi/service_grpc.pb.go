@@ -33,13 +33,19 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	LynkService_ApiList_FullMethodName     = "/lynkapi.LynkService/ApiList"
-	LynkService_Auth_FullMethodName        = "/lynkapi.LynkService/Auth"
-	LynkService_Exec_FullMethodName        = "/lynkapi.LynkService/Exec"
-	LynkService_DataProject_FullMethodName = "/lynkapi.LynkService/DataProject"
-	LynkService_DataQuery_FullMethodName   = "/lynkapi.LynkService/DataQuery"
-	LynkService_DataUpsert_FullMethodName  = "/lynkapi.LynkService/DataUpsert"
-	LynkService_DataIgsert_FullMethodName  = "/lynkapi.LynkService/DataIgsert"
+	LynkService_ApiList_FullMethodName           = "/lynkapi.LynkService/ApiList"
+	LynkService_Auth_FullMethodName              = "/lynkapi.LynkService/Auth"
+	LynkService_Exec_FullMethodName              = "/lynkapi.LynkService/Exec"
+	LynkService_DataProject_FullMethodName       = "/lynkapi.LynkService/DataProject"
+	LynkService_DataQuery_FullMethodName         = "/lynkapi.LynkService/DataQuery"
+	LynkService_DataQueryStream_FullMethodName   = "/lynkapi.LynkService/DataQueryStream"
+	LynkService_DataUpsert_FullMethodName        = "/lynkapi.LynkService/DataUpsert"
+	LynkService_DataIgsert_FullMethodName        = "/lynkapi.LynkService/DataIgsert"
+	LynkService_DataUpdate_FullMethodName        = "/lynkapi.LynkService/DataUpdate"
+	LynkService_DataDelete_FullMethodName        = "/lynkapi.LynkService/DataDelete"
+	LynkService_DataRestore_FullMethodName       = "/lynkapi.LynkService/DataRestore"
+	LynkService_DataBackup_FullMethodName        = "/lynkapi.LynkService/DataBackup"
+	LynkService_DataBackupRestore_FullMethodName = "/lynkapi.LynkService/DataBackupRestore"
 )
 
 // LynkServiceClient is the client API for LynkService service.
@@ -51,8 +57,14 @@ type LynkServiceClient interface {
 	Exec(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error)
 	DataProject(ctx context.Context, in *DataProjectRequest, opts ...grpc.CallOption) (*DataProjectResponse, error)
 	DataQuery(ctx context.Context, in *DataQuery, opts ...grpc.CallOption) (*DataResult, error)
+	DataQueryStream(ctx context.Context, in *DataQuery, opts ...grpc.CallOption) (LynkService_DataQueryStreamClient, error)
 	DataUpsert(ctx context.Context, in *DataInsert, opts ...grpc.CallOption) (*DataResult, error)
 	DataIgsert(ctx context.Context, in *DataInsert, opts ...grpc.CallOption) (*DataResult, error)
+	DataUpdate(ctx context.Context, in *DataUpdate, opts ...grpc.CallOption) (*DataResult, error)
+	DataDelete(ctx context.Context, in *DataDelete, opts ...grpc.CallOption) (*DataResult, error)
+	DataRestore(ctx context.Context, in *DataRestore, opts ...grpc.CallOption) (*DataResult, error)
+	DataBackup(ctx context.Context, in *DataBackupRequest, opts ...grpc.CallOption) (LynkService_DataBackupClient, error)
+	DataBackupRestore(ctx context.Context, opts ...grpc.CallOption) (LynkService_DataBackupRestoreClient, error)
 }
 
 type lynkServiceClient struct {
@@ -108,6 +120,38 @@ func (c *lynkServiceClient) DataQuery(ctx context.Context, in *DataQuery, opts .
 	return out, nil
 }
 
+func (c *lynkServiceClient) DataQueryStream(ctx context.Context, in *DataQuery, opts ...grpc.CallOption) (LynkService_DataQueryStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LynkService_ServiceDesc.Streams[0], LynkService_DataQueryStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lynkServiceDataQueryStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LynkService_DataQueryStreamClient interface {
+	Recv() (*DataResult, error)
+	grpc.ClientStream
+}
+
+type lynkServiceDataQueryStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *lynkServiceDataQueryStreamClient) Recv() (*DataResult, error) {
+	m := new(DataResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *lynkServiceClient) DataUpsert(ctx context.Context, in *DataInsert, opts ...grpc.CallOption) (*DataResult, error) {
 	out := new(DataResult)
 	err := c.cc.Invoke(ctx, LynkService_DataUpsert_FullMethodName, in, out, opts...)
@@ -126,6 +170,99 @@ func (c *lynkServiceClient) DataIgsert(ctx context.Context, in *DataInsert, opts
 	return out, nil
 }
 
+func (c *lynkServiceClient) DataUpdate(ctx context.Context, in *DataUpdate, opts ...grpc.CallOption) (*DataResult, error) {
+	out := new(DataResult)
+	err := c.cc.Invoke(ctx, LynkService_DataUpdate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lynkServiceClient) DataDelete(ctx context.Context, in *DataDelete, opts ...grpc.CallOption) (*DataResult, error) {
+	out := new(DataResult)
+	err := c.cc.Invoke(ctx, LynkService_DataDelete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lynkServiceClient) DataRestore(ctx context.Context, in *DataRestore, opts ...grpc.CallOption) (*DataResult, error) {
+	out := new(DataResult)
+	err := c.cc.Invoke(ctx, LynkService_DataRestore_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lynkServiceClient) DataBackup(ctx context.Context, in *DataBackupRequest, opts ...grpc.CallOption) (LynkService_DataBackupClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LynkService_ServiceDesc.Streams[1], LynkService_DataBackup_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lynkServiceDataBackupClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LynkService_DataBackupClient interface {
+	Recv() (*DataBackupChunk, error)
+	grpc.ClientStream
+}
+
+type lynkServiceDataBackupClient struct {
+	grpc.ClientStream
+}
+
+func (x *lynkServiceDataBackupClient) Recv() (*DataBackupChunk, error) {
+	m := new(DataBackupChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *lynkServiceClient) DataBackupRestore(ctx context.Context, opts ...grpc.CallOption) (LynkService_DataBackupRestoreClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LynkService_ServiceDesc.Streams[2], LynkService_DataBackupRestore_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lynkServiceDataBackupRestoreClient{stream}
+	return x, nil
+}
+
+type LynkService_DataBackupRestoreClient interface {
+	Send(*DataBackupChunk) error
+	CloseAndRecv() (*DataBackupReport, error)
+	grpc.ClientStream
+}
+
+type lynkServiceDataBackupRestoreClient struct {
+	grpc.ClientStream
+}
+
+func (x *lynkServiceDataBackupRestoreClient) Send(m *DataBackupChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *lynkServiceDataBackupRestoreClient) CloseAndRecv() (*DataBackupReport, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(DataBackupReport)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // LynkServiceServer is the server API for LynkService service.
 // All implementations must embed UnimplementedLynkServiceServer
 // for forward compatibility
@@ -135,8 +272,14 @@ type LynkServiceServer interface {
 	Exec(context.Context, *Request) (*Response, error)
 	DataProject(context.Context, *DataProjectRequest) (*DataProjectResponse, error)
 	DataQuery(context.Context, *DataQuery) (*DataResult, error)
+	DataQueryStream(*DataQuery, LynkService_DataQueryStreamServer) error
 	DataUpsert(context.Context, *DataInsert) (*DataResult, error)
 	DataIgsert(context.Context, *DataInsert) (*DataResult, error)
+	DataUpdate(context.Context, *DataUpdate) (*DataResult, error)
+	DataDelete(context.Context, *DataDelete) (*DataResult, error)
+	DataRestore(context.Context, *DataRestore) (*DataResult, error)
+	DataBackup(*DataBackupRequest, LynkService_DataBackupServer) error
+	DataBackupRestore(LynkService_DataBackupRestoreServer) error
 	mustEmbedUnimplementedLynkServiceServer()
 }
 
@@ -159,12 +302,30 @@ func (UnimplementedLynkServiceServer) DataProject(context.Context, *DataProjectR
 func (UnimplementedLynkServiceServer) DataQuery(context.Context, *DataQuery) (*DataResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DataQuery not implemented")
 }
+func (UnimplementedLynkServiceServer) DataQueryStream(*DataQuery, LynkService_DataQueryStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method DataQueryStream not implemented")
+}
 func (UnimplementedLynkServiceServer) DataUpsert(context.Context, *DataInsert) (*DataResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DataUpsert not implemented")
 }
 func (UnimplementedLynkServiceServer) DataIgsert(context.Context, *DataInsert) (*DataResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DataIgsert not implemented")
 }
+func (UnimplementedLynkServiceServer) DataUpdate(context.Context, *DataUpdate) (*DataResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DataUpdate not implemented")
+}
+func (UnimplementedLynkServiceServer) DataDelete(context.Context, *DataDelete) (*DataResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DataDelete not implemented")
+}
+func (UnimplementedLynkServiceServer) DataRestore(context.Context, *DataRestore) (*DataResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DataRestore not implemented")
+}
+func (UnimplementedLynkServiceServer) DataBackup(*DataBackupRequest, LynkService_DataBackupServer) error {
+	return status.Errorf(codes.Unimplemented, "method DataBackup not implemented")
+}
+func (UnimplementedLynkServiceServer) DataBackupRestore(LynkService_DataBackupRestoreServer) error {
+	return status.Errorf(codes.Unimplemented, "method DataBackupRestore not implemented")
+}
 func (UnimplementedLynkServiceServer) mustEmbedUnimplementedLynkServiceServer() {}
 
 // UnsafeLynkServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -268,6 +429,27 @@ func _LynkService_DataQuery_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LynkService_DataQueryStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DataQuery)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LynkServiceServer).DataQueryStream(m, &lynkServiceDataQueryStreamServer{stream})
+}
+
+type LynkService_DataQueryStreamServer interface {
+	Send(*DataResult) error
+	grpc.ServerStream
+}
+
+type lynkServiceDataQueryStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *lynkServiceDataQueryStreamServer) Send(m *DataResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _LynkService_DataUpsert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DataInsert)
 	if err := dec(in); err != nil {
@@ -304,6 +486,107 @@ func _LynkService_DataIgsert_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LynkService_DataUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DataUpdate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LynkServiceServer).DataUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LynkService_DataUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LynkServiceServer).DataUpdate(ctx, req.(*DataUpdate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LynkService_DataDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DataDelete)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LynkServiceServer).DataDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LynkService_DataDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LynkServiceServer).DataDelete(ctx, req.(*DataDelete))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LynkService_DataRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DataRestore)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LynkServiceServer).DataRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LynkService_DataRestore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LynkServiceServer).DataRestore(ctx, req.(*DataRestore))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LynkService_DataBackup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DataBackupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LynkServiceServer).DataBackup(m, &lynkServiceDataBackupServer{stream})
+}
+
+type LynkService_DataBackupServer interface {
+	Send(*DataBackupChunk) error
+	grpc.ServerStream
+}
+
+type lynkServiceDataBackupServer struct {
+	grpc.ServerStream
+}
+
+func (x *lynkServiceDataBackupServer) Send(m *DataBackupChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _LynkService_DataBackupRestore_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(LynkServiceServer).DataBackupRestore(&lynkServiceDataBackupRestoreServer{stream})
+}
+
+type LynkService_DataBackupRestoreServer interface {
+	SendAndClose(*DataBackupReport) error
+	Recv() (*DataBackupChunk, error)
+	grpc.ServerStream
+}
+
+type lynkServiceDataBackupRestoreServer struct {
+	grpc.ServerStream
+}
+
+func (x *lynkServiceDataBackupRestoreServer) SendAndClose(m *DataBackupReport) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *lynkServiceDataBackupRestoreServer) Recv() (*DataBackupChunk, error) {
+	m := new(DataBackupChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // LynkService_ServiceDesc is the grpc.ServiceDesc for LynkService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -339,7 +622,35 @@ var LynkService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DataIgsert",
 			Handler:    _LynkService_DataIgsert_Handler,
 		},
+		{
+			MethodName: "DataUpdate",
+			Handler:    _LynkService_DataUpdate_Handler,
+		},
+		{
+			MethodName: "DataDelete",
+			Handler:    _LynkService_DataDelete_Handler,
+		},
+		{
+			MethodName: "DataRestore",
+			Handler:    _LynkService_DataRestore_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DataQueryStream",
+			Handler:       _LynkService_DataQueryStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DataBackup",
+			Handler:       _LynkService_DataBackup_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DataBackupRestore",
+			Handler:       _LynkService_DataBackupRestore_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "lynkapi/service.proto",
 }
@@ -16,6 +16,11 @@ package lynkapi
 
 import (
 	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
 )
 
 func NewClientError(msg string) error {
@@ -86,6 +91,17 @@ func NewResponseError(code, msg string) *Response {
 	}
 }
 
+// newResponseError returns the error response of err, with the violations of
+// a validation error.
+func newResponseError(code string, err error) *Response {
+	rs := NewResponseError(code, err.Error())
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		rs.Status.Violations = verr.Violations
+	}
+	return rs
+}
+
 func fixCodeMsg(code, msg string) (string, string) {
 	if len(msg) > 6 && msg[0] == '#' && msg[5] == ' ' {
 		code = msg[1:5]
@@ -140,6 +156,10 @@ func ParseError(err error) *ServiceStatus {
 			Code: StatusCode_OK,
 		}
 	}
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		return verr.Status()
+	}
 	if len(err.Error()) >= 6 &&
 		err.Error()[0] == '#' &&
 		err.Error()[5] == ' ' {
@@ -153,3 +173,35 @@ func ParseError(err error) *ServiceStatus {
 		Message: "unknown error",
 	}
 }
+
+// ValidationError is a bad request error with all the violations of a
+// validation, the violations travel to the clients in ServiceStatus.
+type ValidationError struct {
+	Violations []*ServiceStatus_Violation
+}
+
+func (it *ValidationError) Error() string {
+	msgs := make([]string, 0, len(it.Violations))
+	for _, v := range it.Violations {
+		msgs = append(msgs, v.Message)
+	}
+	return "#" + StatusCode_BadRequest + " " + strings.Join(msgs, "; ")
+}
+
+func (it *ValidationError) Status() *ServiceStatus {
+	_, msg := fixCodeMsg(StatusCode_BadRequest, it.Error())
+	return &ServiceStatus{
+		Code:       StatusCode_BadRequest,
+		Message:    msg,
+		Violations: it.Violations,
+	}
+}
+
+// GRPCStatus returns the rpc status with the ServiceStatus in the details.
+func (it *ValidationError) GRPCStatus() *status.Status {
+	st := status.New(codes.InvalidArgument, it.Error())
+	if st2, err := st.WithDetails(protoadapt.MessageV1Of(it.Status())); err == nil {
+		return st2
+	}
+	return st
+}
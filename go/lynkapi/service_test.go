@@ -15,13 +15,21 @@
 package lynkapi_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/lynkdb/lynkapi/go/lynkapi"
+	"github.com/lynkdb/lynkapi/go/oneobject"
 )
 
 type TestService struct{}
@@ -83,3 +91,2087 @@ func Test_Service(t *testing.T) {
 		fmt.Println(rs)
 	}
 }
+
+func Test_Service_DataRef(t *testing.T) {
+
+	type Group struct {
+		Id string `json:"id" x_attrs:"primary_key"`
+		No int64  `json:"no"`
+	}
+	type User struct {
+		Id      string `json:"id" x_attrs:"primary_key"`
+		GroupId string `json:"group_id" x_ref:"groups.id" x_ref_delete:"set_null"`
+	}
+	type Member struct {
+		Id      string `json:"id" x_attrs:"primary_key"`
+		GroupNo int64  `json:"group_no" x_ref:"groups.no" x_ref_delete:"cascade"`
+	}
+	type Tag struct {
+		Id     string `json:"id" x_attrs:"primary_key"`
+		UserId string `json:"user_id" x_ref:"test.users.id" x_ref_delete:"cascade"`
+	}
+	type Post struct {
+		Id      string `json:"id" x_attrs:"primary_key"`
+		GroupId string `json:"group_id" x_ref:"groups.id"`
+	}
+	type Object struct {
+		Groups  []*Group  `json:"groups"`
+		Users   []*User   `json:"users"`
+		Tags    []*Tag    `json:"tags"`
+		Posts   []*Post   `json:"posts"`
+		Members []*Member `json:"members"`
+	}
+
+	obj := &Object{}
+
+	inst, err := oneobject.NewInstance("test", obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"groups", "users", "tags", "posts", "members"} {
+		if err := inst.TableSetup(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := lynkapi.NewService()
+	if err := s.RegisterDataService(inst); err != nil {
+		t.Fatal(err)
+	}
+
+	upsert := func(table string, kvs ...string) error {
+		req := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    table,
+		}
+		for i := 0; i+1 < len(kvs); i += 2 {
+			req.SetField(kvs[i], kvs[i+1])
+		}
+		_, err := s.DataUpsert(context.Background(), req)
+		return err
+	}
+
+	remove := func(table, id string) error {
+		_, err := s.DataDelete(context.Background(), &lynkapi.DataDelete{
+			InstanceName: "test",
+			TableName:    table,
+			Filter: &lynkapi.DataQuery_Filter{
+				Field: "id",
+				Value: structpb.NewStringValue(id),
+			},
+		})
+		return err
+	}
+
+	if err := upsert("users", "id", "u1", "group_id", "g1"); lynkapi.ParseError(err).Code != lynkapi.StatusCode_BadRequest {
+		t.Fatalf("dangling reference accepted: %v", err)
+	}
+
+	for _, kvs := range [][]string{
+		{"groups", "id", "g1"},
+		{"users", "id", "u1", "group_id", "g1"},
+		{"tags", "id", "t1", "user_id", "u1"},
+		{"posts", "id", "p1", "group_id", "g1"},
+	} {
+		if err := upsert(kvs[0], kvs[1:]...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// restrict
+	if err := remove("groups", "g1"); lynkapi.ParseError(err).Code != lynkapi.StatusCode_Conflict {
+		t.Fatalf("restrict reference deleted: %v", err)
+	}
+	if len(obj.Groups) != 1 || obj.Users[0].GroupId != "g1" {
+		t.Fatal("restrict reference changed data")
+	}
+
+	// set_null
+	if err := remove("posts", "p1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := remove("groups", "g1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(obj.Groups) != 0 || obj.Users[0].GroupId != "" {
+		t.Fatal("set_null reference not applied")
+	}
+
+	// cascade
+	if err := remove("users", "u1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(obj.Users) != 0 || len(obj.Tags) != 0 {
+		t.Fatal("cascade reference not applied")
+	}
+
+	{ // int reference
+		insert := func(table string, id string, field string, value float64) error {
+			_, err := s.DataUpsert(context.Background(), &lynkapi.DataInsert{
+				InstanceName: "test",
+				TableName:    table,
+				Fields:       []string{"id", field},
+				Values:       []*structpb.Value{structpb.NewStringValue(id), structpb.NewNumberValue(value)},
+			})
+			return err
+		}
+		if err := insert("members", "m1", "group_no", 7); lynkapi.ParseError(err).Code != lynkapi.StatusCode_BadRequest {
+			t.Fatalf("dangling int reference accepted: %v", err)
+		}
+		if err := insert("groups", "g2", "no", 7); err != nil {
+			t.Fatal(err)
+		}
+		if err := insert("members", "m1", "group_no", 7); err != nil {
+			t.Fatal(err)
+		}
+		if err := remove("groups", "g2"); err != nil {
+			t.Fatal(err)
+		}
+		if len(obj.Members) != 0 {
+			t.Fatal("cascade of int reference not applied")
+		}
+	}
+
+	{ // invalid reference tags
+		type BadRef struct {
+			Id      string `json:"id" x_attrs:"primary_key"`
+			GroupId string `json:"group_id" x_ref:"groups"`
+		}
+		type BadDelete struct {
+			Id      string `json:"id" x_attrs:"primary_key"`
+			GroupId string `json:"group_id" x_ref:"groups.id" x_ref_delete:"drop"`
+		}
+		for _, o := range []any{&BadRef{}, &BadDelete{}} {
+			if _, _, err := lynkapi.NewSpecFromStruct(o); err == nil {
+				t.Fatalf("invalid reference tag of %T accepted", o)
+			}
+		}
+	}
+}
+
+func Test_Service_DataTree(t *testing.T) {
+
+	type Node struct {
+		Id  string `json:"id" x_attrs:"primary_key"`
+		Pid string `json:"pid"`
+	}
+	type Object struct {
+		Nodes []*Node `json:"nodes"`
+	}
+
+	inst, err := oneobject.NewInstance("test", &Object{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("nodes"); err != nil {
+		t.Fatal(err)
+	}
+
+	s := lynkapi.NewService()
+	if err := s.RegisterDataService(inst); err != nil {
+		t.Fatal(err)
+	}
+
+	upsert := func(id, pid string) error {
+		req := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    "nodes",
+		}
+		req.SetField("id", id)
+		req.SetField("pid", pid)
+		_, err := s.DataUpsert(context.Background(), req)
+		return err
+	}
+
+	for _, v := range [][2]string{{"a", ""}, {"b", "a"}, {"c", "b"}, {"d", "a"}} {
+		if err := upsert(v[0], v[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := upsert("a", "c"); lynkapi.ParseError(err).Code != lynkapi.StatusCode_BadRequest {
+		t.Fatalf("pid cycle accepted: %v", err)
+	}
+
+	for _, v := range []struct {
+		tree *lynkapi.DataQuery_Tree
+		want string
+	}{
+		{&lynkapi.DataQuery_Tree{Type: "children", Id: "a"}, "b:1,d:1"},
+		{&lynkapi.DataQuery_Tree{Type: "subtree", Id: "a"}, "b:1,c:2,d:1"},
+		{&lynkapi.DataQuery_Tree{Type: "subtree", Id: "", Depth: 2}, "a:1,b:2,d:2"},
+		{&lynkapi.DataQuery_Tree{Type: "ancestors", Id: "c"}, "b:1,a:2"},
+	} {
+		rs, err := s.DataQuery(context.Background(), &lynkapi.DataQuery{
+			InstanceName: "test",
+			TableName:    "nodes",
+			Tree:         v.tree,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var hits []string
+		for _, row := range rs.Rows {
+			hits = append(hits, fmt.Sprintf("%s:%d", row.Id, row.Depth))
+		}
+		if s := strings.Join(hits, ","); s != v.want {
+			t.Fatalf("tree %s/%s, hits %s, want %s", v.tree.Type, v.tree.Id, s, v.want)
+		}
+	}
+}
+
+func Test_Service_DataScope(t *testing.T) {
+
+	type Note struct {
+		Id     string `json:"id" x_attrs:"primary_key"`
+		Slug   string `json:"slug" x_attrs:"unique_key"`
+		UserId string `json:"user_id"`
+		Text   string `json:"text"`
+	}
+	type Memo struct {
+		Id      string `json:"id" x_attrs:"primary_key"`
+		UserId  string `json:"user_id"`
+		Deleted int64  `json:"deleted" x_attrs:"deleted"`
+	}
+	type Object struct {
+		Notes []*Note `json:"notes"`
+		Memos []*Memo `json:"memos"`
+	}
+
+	inst, err := oneobject.NewInstance("test", &Object{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("notes", lynkapi.NewTableOption(lynkapi.TableSpec_Option_OwnerField, "user_id")); err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("memos",
+		lynkapi.NewTableOption(lynkapi.TableSpec_Option_OwnerField, "user_id"),
+		lynkapi.NewTableOption(lynkapi.TableSpec_Option_SoftDelete, "true"),
+		lynkapi.NewTableOption(lynkapi.TableSpec_Option_History, "on")); err != nil {
+		t.Fatal(err)
+	}
+
+	s := lynkapi.NewService()
+	if err := s.RegisterDataService(inst); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		alice = lynkapi.NewAuthIdentityContext(context.Background(), &lynkapi.AuthIdentity{User: "alice"})
+		bob   = lynkapi.NewAuthIdentityContext(context.Background(), &lynkapi.AuthIdentity{User: "bob"})
+	)
+
+	upsert := func(ctx context.Context, id, text string) error {
+		req := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    "notes",
+		}
+		req.SetField("id", id)
+		req.SetField("slug", "slug-"+id)
+		req.SetField("text", text)
+		req.SetField("user_id", "mallory")
+		_, err := s.DataUpsert(ctx, req)
+		return err
+	}
+
+	query := func(ctx context.Context, filter ...*lynkapi.DataQuery_Filter) []*lynkapi.DataRow {
+		q := &lynkapi.DataQuery{
+			InstanceName: "test",
+			TableName:    "notes",
+		}
+		if len(filter) > 0 {
+			q.Filter = filter[0]
+		}
+		rs, err := s.DataQuery(ctx, q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rs.Rows
+	}
+
+	if err := upsert(context.Background(), "n0", "anonymous"); lynkapi.ParseError(err).Code != lynkapi.StatusCode_UnAuth {
+		t.Fatalf("unauthenticated write accepted: %v", err)
+	}
+
+	if err := upsert(alice, "n1", "alice note"); err != nil {
+		t.Fatal(err)
+	}
+	if err := upsert(bob, "n2", "bob note"); err != nil {
+		t.Fatal(err)
+	}
+
+	if rows := query(alice); len(rows) != 1 || rows[0].Id != "n1" ||
+		rows[0].Fields["user_id"].GetStringValue() != "alice" {
+		t.Fatalf("invalid alice rows %v", rows)
+	}
+
+	if err := upsert(alice, "n2", "overwrite"); lynkapi.ParseError(err).Code != lynkapi.StatusCode_AuthDenied {
+		t.Fatalf("overwrite of another owner accepted: %v", err)
+	}
+
+	{ // the filter of the caller is kept as a whole, and-ed with the owner
+		if rows := query(alice, (&lynkapi.DataQuery_Filter{}).And("user_id", "bob")); len(rows) != 0 {
+			t.Fatalf("rows of another owner returned %v", rows)
+		}
+		nested := &lynkapi.DataQuery_Filter{
+			Inner: []*lynkapi.DataQuery_Filter{
+				(&lynkapi.DataQuery_Filter{}).And("text", "alice note"),
+			},
+		}
+		if rows := query(alice, nested); len(rows) != 1 || rows[0].Id != "n1" {
+			t.Fatalf("invalid nested filter rows %v", rows)
+		}
+		ne := &lynkapi.DataQuery_Filter{
+			Field: "text",
+			Op:    lynkapi.DataQuery_Filter_Ne,
+			Value: structpb.NewStringValue("alice note"),
+		}
+		if rows := query(alice, ne); len(rows) != 0 {
+			t.Fatalf("rows of another owner returned %v", rows)
+		}
+		or := &lynkapi.DataQuery_Filter{
+			Type: "or",
+			Inner: []*lynkapi.DataQuery_Filter{
+				(&lynkapi.DataQuery_Filter{}).And("user_id", "bob"),
+				(&lynkapi.DataQuery_Filter{}).And("id", "n1"),
+			},
+		}
+		if rows := query(alice, or); len(rows) != 1 || rows[0].Id != "n1" {
+			t.Fatalf("invalid or filter rows %v", rows)
+		}
+	}
+
+	{ // a new primary-key with the unique-key of another owner
+		req := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    "notes",
+		}
+		req.SetField("id", "n3")
+		req.SetField("slug", "slug-n2")
+		req.SetField("text", "overwrite")
+		if _, err := s.DataUpsert(alice, req); lynkapi.ParseError(err).Code != lynkapi.StatusCode_AuthDenied {
+			t.Fatalf("overwrite of another owner by unique-key accepted: %v", err)
+		}
+		if rows := query(bob); len(rows) != 1 || rows[0].Fields["text"].GetStringValue() != "bob note" {
+			t.Fatalf("row of another owner changed %v", rows)
+		}
+	}
+
+	{ // delete
+		del := &lynkapi.DataDelete{
+			InstanceName: "test",
+			TableName:    "notes",
+			Filter:       (&lynkapi.DataQuery_Filter{}).And("id", "n2"),
+		}
+		if _, err := s.DataDelete(alice, del); err != nil {
+			t.Fatal(err)
+		}
+		if rows := query(bob); len(rows) != 1 || rows[0].Fields["text"].GetStringValue() != "bob note" {
+			t.Fatalf("row of another owner deleted %v", rows)
+		}
+	}
+
+	{ // the key of a trashed row, and the history of a reused key
+		memo := func(ctx context.Context) error {
+			req := &lynkapi.DataInsert{
+				InstanceName: "test",
+				TableName:    "memos",
+			}
+			req.SetField("id", "m1")
+			_, err := s.DataUpsert(ctx, req)
+			return err
+		}
+		del := func(purge bool) {
+			if _, err := s.DataDelete(alice, &lynkapi.DataDelete{
+				InstanceName: "test",
+				TableName:    "memos",
+				Filter:       (&lynkapi.DataQuery_Filter{}).And("id", "m1"),
+				Purge:        purge,
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		history := func(ctx context.Context) []*lynkapi.DataRowChange {
+			rs, err := s.DataQuery(ctx, &lynkapi.DataQuery{
+				InstanceName: "test",
+				TableName:    "memos",
+				Filter:       (&lynkapi.DataQuery_Filter{}).And("id", "m1"),
+				History:      true,
+				Limit:        100,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return rs.Changes
+		}
+
+		if err := memo(alice); err != nil {
+			t.Fatal(err)
+		}
+		del(false)
+		if err := memo(bob); lynkapi.ParseError(err).Code != lynkapi.StatusCode_AuthDenied {
+			t.Fatalf("trashed row of another owner purged: %v", err)
+		}
+
+		del(true)
+		if err := memo(bob); err != nil {
+			t.Fatal(err)
+		}
+		chs := history(bob)
+		if len(chs) != 1 || chs[0].Fields["user_id"].GetStringValue() != "bob" {
+			t.Fatalf("history of another owner returned %v", chs)
+		}
+
+		rs, err := inst.Query(&lynkapi.DataQuery{
+			TableName: "memos",
+			Filter:    (&lynkapi.DataQuery_Filter{}).And("id", "m1"),
+			History:   true,
+			Limit:     100,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		version := uint64(0)
+		for _, ch := range rs.Changes {
+			if ch.Fields["user_id"].GetStringValue() == "alice" {
+				version = ch.Version
+			}
+		}
+		if version == 0 {
+			t.Fatalf("alice versions not found %v", rs.Changes)
+		}
+		if _, err := s.DataRestore(bob, &lynkapi.DataRestore{
+			InstanceName: "test",
+			TableName:    "memos",
+			Filter:       (&lynkapi.DataQuery_Filter{}).And("id", "m1"),
+			Version:      version,
+		}); lynkapi.ParseError(err).Code != lynkapi.StatusCode_NotFound {
+			t.Fatalf("version of another owner restored: %v", err)
+		}
+	}
+}
+
+func Test_Service_DataAccess(t *testing.T) {
+
+	type User struct {
+		Id    string `json:"id" x_attrs:"primary_key"`
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	type Login struct {
+		Id     string `json:"id" x_attrs:"primary_key"`
+		UserId string `json:"user_id" x_ref:"users.id" x_ref_delete:"cascade"`
+	}
+	type Object struct {
+		Users  []*User  `json:"users"`
+		Logins []*Login `json:"logins"`
+	}
+
+	inst, err := oneobject.NewInstance("test", &Object{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("users",
+		lynkapi.NewTableOption(lynkapi.TableSpec_Option_History, "on")); err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("logins"); err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "access.toml")
+	if err := os.WriteFile(file, []byte(`
+[[rules]]
+roles = ["admin"]
+instance = "*"
+actions = ["read", "write", "delete"]
+
+[[rules]]
+roles = ["guest"]
+instance = "test"
+table = "users"
+fields = ["id", "name"]
+actions = ["read", "write"]
+
+[[rules]]
+roles = ["editor"]
+instance = "test"
+table = "users"
+actions = ["read", "write", "delete"]
+`), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := lynkapi.NewDataAccessPolicyFromFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := lynkapi.NewService()
+	if err := s.RegisterDataService(inst); err != nil {
+		t.Fatal(err)
+	}
+	s.SetupDataAccessPolicy(p)
+
+	var (
+		admin = lynkapi.NewAuthIdentityContext(context.Background(),
+			&lynkapi.AuthIdentity{User: "u1", Roles: []string{"admin"}})
+		guest = lynkapi.NewAuthIdentityContext(context.Background(),
+			&lynkapi.AuthIdentity{User: "u2", Roles: []string{"guest"}})
+		nobody = lynkapi.NewAuthIdentityContext(context.Background(),
+			&lynkapi.AuthIdentity{User: "u3"})
+		editor = lynkapi.NewAuthIdentityContext(context.Background(),
+			&lynkapi.AuthIdentity{User: "u4", Roles: []string{"editor"}})
+	)
+
+	upsert := func(ctx context.Context, kvs ...string) error {
+		req := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    "users",
+		}
+		for i := 0; i+1 < len(kvs); i += 2 {
+			req.SetField(kvs[i], kvs[i+1])
+		}
+		_, err := s.DataUpsert(ctx, req)
+		return err
+	}
+
+	if err := upsert(context.Background(), "id", "a"); lynkapi.ParseError(err).Code != lynkapi.StatusCode_UnAuth {
+		t.Fatalf("unauthenticated write accepted: %v", err)
+	}
+	if err := upsert(nobody, "id", "a"); lynkapi.ParseError(err).Code != lynkapi.StatusCode_AuthDenied {
+		t.Fatalf("write without role accepted: %v", err)
+	}
+	if err := upsert(admin, "id", "a", "name", "Alice", "email", "a@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := upsert(guest, "id", "a", "email", "b@example.com"); lynkapi.ParseError(err).Code != lynkapi.StatusCode_AuthDenied {
+		t.Fatalf("write of denied field accepted: %v", err)
+	}
+	if err := upsert(guest, "id", "a", "name", "Bob"); err != nil {
+		t.Fatal(err)
+	}
+
+	{ // read
+		rs, err := s.DataQuery(guest, &lynkapi.DataQuery{
+			InstanceName: "test",
+			TableName:    "users",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rs.Rows) != 1 || rs.Rows[0].Fields["name"].GetStringValue() != "Bob" ||
+			rs.Rows[0].Fields["email"] != nil {
+			t.Fatalf("invalid rows %v", rs.Rows)
+		}
+
+		_, err = s.DataQuery(guest, &lynkapi.DataQuery{
+			InstanceName: "test",
+			TableName:    "users",
+			Filter:       (&lynkapi.DataQuery_Filter{}).And("email", "a@example.com"),
+		})
+		if lynkapi.ParseError(err).Code != lynkapi.StatusCode_AuthDenied {
+			t.Fatalf("filter on denied field accepted: %v", err)
+		}
+
+		// the sort, tree and join fields are checked as the filter fields
+		for name, q := range map[string]*lynkapi.DataQuery{
+			"sort": {
+				Sort: &lynkapi.DataQuery_SortFilter{Field: "email"},
+			},
+			"tree": {
+				Tree: &lynkapi.DataQuery_Tree{Type: "children", PidField: "email"},
+			},
+			"join": {
+				Join: &lynkapi.DataQuery_Join{TableName: "users", Field: "email"},
+			},
+			"join fields": {
+				Join: &lynkapi.DataQuery_Join{TableName: "users", Field: "id", Fields: []string{"email"}},
+			},
+		} {
+			q.InstanceName, q.TableName = "test", "users"
+			if _, err := s.DataQuery(guest, q); lynkapi.ParseError(err).Code != lynkapi.StatusCode_AuthDenied {
+				t.Fatalf("%s on denied field accepted: %v", name, err)
+			}
+		}
+
+		// the history of a denied field is not returned either
+		rs, err = s.DataQuery(guest, &lynkapi.DataQuery{
+			InstanceName: "test",
+			TableName:    "users",
+			History:      true,
+			Filter:       (&lynkapi.DataQuery_Filter{}).And("id", "a"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rs.Changes) != 2 {
+			t.Fatalf("invalid changes %v", rs.Changes)
+		}
+		for _, ch := range rs.Changes {
+			if ch.Fields["email"] != nil {
+				t.Fatalf("denied field in history %v", ch)
+			}
+			for _, fc := range ch.Changes {
+				if fc.Path == "email" {
+					t.Fatalf("denied field in history %v", ch)
+				}
+			}
+		}
+	}
+
+	{ // delete
+		del := &lynkapi.DataDelete{
+			InstanceName: "test",
+			TableName:    "users",
+			Filter:       (&lynkapi.DataQuery_Filter{}).And("id", "a"),
+		}
+		if _, err := s.DataDelete(guest, del); lynkapi.ParseError(err).Code != lynkapi.StatusCode_AuthDenied {
+			t.Fatalf("delete without grant accepted: %v", err)
+		}
+
+		// the cascade delete of logins is not granted to the editor
+		login := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    "logins",
+		}
+		login.SetField("id", "l1")
+		login.SetField("user_id", "a")
+		if _, err := s.DataUpsert(admin, login); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.DataDelete(editor, del); lynkapi.ParseError(err).Code != lynkapi.StatusCode_AuthDenied {
+			t.Fatalf("cascade delete without grant accepted: %v", err)
+		}
+		if _, err := s.DataDelete(admin, del); err != nil {
+			t.Fatal(err)
+		}
+		rs, err := s.DataQuery(admin, &lynkapi.DataQuery{
+			InstanceName: "test",
+			TableName:    "logins",
+		})
+		if err != nil || len(rs.Rows) != 0 {
+			t.Fatalf("cascade delete not applied %v %v", rs, err)
+		}
+	}
+}
+
+type testBackupStream struct {
+	grpc.ServerStream
+	chunks []*lynkapi.DataBackupChunk
+	report *lynkapi.DataBackupReport
+}
+
+func (it *testBackupStream) Context() context.Context {
+	return context.Background()
+}
+
+func (it *testBackupStream) Send(chunk *lynkapi.DataBackupChunk) error {
+	it.chunks = append(it.chunks, chunk)
+	return nil
+}
+
+func (it *testBackupStream) Recv() (*lynkapi.DataBackupChunk, error) {
+	if len(it.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := it.chunks[0]
+	it.chunks = it.chunks[1:]
+	return chunk, nil
+}
+
+func (it *testBackupStream) SendAndClose(rs *lynkapi.DataBackupReport) error {
+	it.report = rs
+	return nil
+}
+
+func Test_Service_DataBackup(t *testing.T) {
+
+	type Item struct {
+		Id      string `json:"id" x_attrs:"primary_key"`
+		Name    string `json:"name"`
+		Deleted int64  `json:"deleted" x_attrs:"deleted"`
+	}
+	type Object struct {
+		Items []*Item `json:"items"`
+	}
+	type Item2 struct {
+		Id      string `json:"id" x_attrs:"primary_key"`
+		Name    int64  `json:"name"`
+		Deleted int64  `json:"deleted" x_attrs:"deleted"`
+	}
+	type Object2 struct {
+		Items []*Item2 `json:"items"`
+	}
+
+	newInstance := func(name string, obj any) *oneobject.Instance {
+		inst, err := oneobject.NewInstance(name, obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := inst.TableSetup("items", lynkapi.NewTableOption(lynkapi.TableSpec_Option_SoftDelete, "true")); err != nil {
+			t.Fatal(err)
+		}
+		return inst
+	}
+
+	var (
+		s   = lynkapi.NewService()
+		src = newInstance("src", &Object{})
+		dst = newInstance("dst", &Object{})
+		bad = newInstance("bad", &Object2{})
+	)
+	for _, ds := range []lynkapi.DataService{src, dst, bad} {
+		if err := s.RegisterDataService(ds); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 0; i < 150; i++ {
+		req := &lynkapi.DataInsert{
+			InstanceName: "src",
+			TableName:    "items",
+		}
+		req.SetField("id", fmt.Sprintf("%04d", i))
+		req.SetField("name", fmt.Sprintf("item %d", i))
+		if _, err := s.DataUpsert(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.DataDelete(context.Background(), &lynkapi.DataDelete{
+		InstanceName: "src",
+		TableName:    "items",
+		Filter:       (&lynkapi.DataQuery_Filter{}).And("id", "0001"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// backup into an archive file
+	var archive bytes.Buffer
+	{
+		stream := &testBackupStream{}
+		if err := s.DataBackup(&lynkapi.DataBackupRequest{InstanceName: "src"}, stream); err != nil {
+			t.Fatal(err)
+		}
+		write := lynkapi.NewDataBackupWriter(&archive)
+		for _, chunk := range stream.chunks {
+			if err := write(chunk); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	restore := func(instanceName string) (*lynkapi.DataBackupReport, error) {
+		var (
+			read   = lynkapi.NewDataBackupReader(bytes.NewReader(archive.Bytes()))
+			stream = &testBackupStream{}
+		)
+		for {
+			chunk, err := read()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			stream.chunks = append(stream.chunks, chunk)
+		}
+		stream.chunks[0].InstanceName = instanceName
+		err := s.DataBackupRestore(stream)
+		return stream.report, err
+	}
+
+	if _, err := restore("bad"); lynkapi.ParseError(err).Code != lynkapi.StatusCode_BadRequest {
+		t.Fatalf("incompatible spec accepted: %v", err)
+	}
+
+	report, err := restore("dst")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Tables) != 1 || report.Tables[0].Rows != 150 {
+		t.Fatalf("invalid report %v", report)
+	}
+
+	for _, trash := range []bool{false, true} {
+		rs, err := s.DataQuery(context.Background(), &lynkapi.DataQuery{
+			InstanceName: "dst",
+			TableName:    "items",
+			Limit:        200,
+			Trash:        trash,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (trash && len(rs.Rows) != 1) || (!trash && len(rs.Rows) != 149) {
+			t.Fatalf("invalid restored rows %d (trash %v)", len(rs.Rows), trash)
+		}
+	}
+
+	{ // rows are restored through the write path of DataUpsert
+		type Author struct {
+			Id string `json:"id" x_attrs:"primary_key"`
+		}
+		type Book struct {
+			Id       string `json:"id" x_attrs:"primary_key"`
+			AuthorId string `json:"author_id" x_ref:"authors.id"`
+		}
+		type Library struct {
+			Books   []*Book   `json:"books"`
+			Authors []*Author `json:"authors"`
+		}
+		for _, name := range []string{"lib_src", "lib_dst"} {
+			inst, err := oneobject.NewInstance(name, &Library{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, table := range []string{"books", "authors"} {
+				if err := inst.TableSetup(table); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := s.RegisterDataService(inst); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, kvs := range [][]string{
+			{"authors", "id", "a1"},
+			{"books", "id", "b1", "author_id", "a1"},
+		} {
+			req := &lynkapi.DataInsert{
+				InstanceName: "lib_src",
+				TableName:    kvs[0],
+			}
+			for i := 1; i+1 < len(kvs); i += 2 {
+				req.SetField(kvs[i], kvs[i+1])
+			}
+			if _, err := s.DataUpsert(context.Background(), req); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		hooks := 0
+		if err := s.RegisterDataHook("lib_dst", "*", lynkapi.DataBeforeHook(func(ev *lynkapi.DataHookEvent) error {
+			hooks += 1
+			return nil
+		})); err != nil {
+			t.Fatal(err)
+		}
+
+		backup := func() *testBackupStream {
+			stream := &testBackupStream{}
+			if err := s.DataBackup(&lynkapi.DataBackupRequest{InstanceName: "lib_src"}, stream); err != nil {
+				t.Fatal(err)
+			}
+			stream.chunks[0].InstanceName = "lib_dst"
+			return stream
+		}
+
+		// the referenced table is sent first
+		stream := backup()
+		if specs := stream.chunks[0].Specs; len(specs) != 2 || specs[0].Name != "authors" {
+			t.Fatalf("invalid archive table order %v", specs)
+		}
+		if err := s.DataBackupRestore(stream); err != nil {
+			t.Fatal(err)
+		}
+		if hooks != 2 {
+			t.Fatalf("write hooks not run on restore (%d)", hooks)
+		}
+
+		// a row the API would refuse is not restored
+		stream = backup()
+		for _, chunk := range stream.chunks {
+			if chunk.TableName == "books" {
+				chunk.Rows[0].Fields["author_id"] = structpb.NewStringValue("a9")
+			}
+		}
+		if err := s.DataBackupRestore(stream); lynkapi.ParseError(err).Code != lynkapi.StatusCode_BadRequest {
+			t.Fatalf("dangling reference restored: %v", err)
+		}
+	}
+}
+
+func Test_Service_DataHook(t *testing.T) {
+
+	type Task struct {
+		Id      string `json:"id" x_attrs:"primary_key"`
+		Title   string `json:"title"`
+		Updated int64  `json:"updated"`
+	}
+	type Note struct {
+		Id     string `json:"id" x_attrs:"primary_key"`
+		TaskId string `json:"task_id" x_ref:"tasks.id" x_ref_delete:"cascade"`
+	}
+	type Object struct {
+		Tasks []*Task `json:"tasks"`
+		Notes []*Note `json:"notes"`
+	}
+
+	inst, err := oneobject.NewInstance("test", &Object{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("tasks",
+		lynkapi.NewTableOption(lynkapi.TableSpec_Option_History, "on")); err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("notes"); err != nil {
+		t.Fatal(err)
+	}
+
+	s := lynkapi.NewService()
+	if err := s.RegisterDataService(inst); err != nil {
+		t.Fatal(err)
+	}
+
+	var events []*lynkapi.DataHookEvent
+
+	if err := s.RegisterDataHook("test", "tasks",
+		lynkapi.DataBeforeHook(func(ev *lynkapi.DataHookEvent) error {
+			if ev.Insert == nil {
+				return nil
+			}
+			for i, name := range ev.Insert.Fields {
+				if name == "title" && ev.Insert.Values[i].GetStringValue() == "" {
+					return lynkapi.NewBadRequestError("title required")
+				}
+			}
+			ev.Insert.Fields = append(ev.Insert.Fields, "updated")
+			ev.Insert.Values = append(ev.Insert.Values, structpb.NewNumberValue(1700000000))
+			return nil
+		}),
+		lynkapi.DataAfterHook(func(ev *lynkapi.DataHookEvent) {
+			events = append(events, ev)
+		}),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.RegisterDataHook("test", "tasks", func() {}); err == nil {
+		t.Fatal("invalid hook accepted")
+	}
+
+	// func literals of the hook signatures are accepted as the named types
+	var noteEvents, noteBefore []*lynkapi.DataHookEvent
+	if err := s.RegisterDataHook("test", "notes",
+		func(ev *lynkapi.DataHookEvent) error {
+			noteBefore = append(noteBefore, ev)
+			return nil
+		},
+		func(ev *lynkapi.DataHookEvent) {
+			noteEvents = append(noteEvents, ev)
+		},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	upsert := func(id, title string) error {
+		req := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    "tasks",
+		}
+		req.SetField("id", id)
+		req.SetField("title", title)
+		_, err := s.DataUpsert(context.Background(), req)
+		return err
+	}
+
+	if err := upsert("t1", ""); lynkapi.ParseError(err).Code != lynkapi.StatusCode_BadRequest {
+		t.Fatalf("rejected write accepted: %v", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("after-hook of a rejected write")
+	}
+
+	if err := upsert("t1", "first"); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || len(events[0].Rows) != 1 ||
+		events[0].Rows[0].Fields["updated"].GetNumberValue() != 1700000000 {
+		t.Fatalf("invalid after-hook event %v", events)
+	}
+
+	{
+		note := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    "notes",
+		}
+		note.SetField("id", "n1")
+		note.SetField("task_id", "t1")
+		if _, err := s.DataUpsert(context.Background(), note); err != nil {
+			t.Fatal(err)
+		}
+		if len(noteBefore) != 1 || len(noteEvents) != 1 {
+			t.Fatalf("func literal hooks not run %v %v", noteBefore, noteEvents)
+		}
+		noteEvents = nil
+	}
+
+	if _, err := s.DataDelete(context.Background(), &lynkapi.DataDelete{
+		InstanceName: "test",
+		TableName:    "tasks",
+		Filter:       (&lynkapi.DataQuery_Filter{}).And("id", "t1"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[1].Action != lynkapi.DataHook_Delete ||
+		len(events[1].Rows) != 1 || events[1].Rows[0].Id != "t1" {
+		t.Fatalf("invalid delete after-hook event %v", events)
+	}
+
+	// the cascade delete runs the hooks of the referencing table
+	if len(noteEvents) != 1 || noteEvents[0].Action != lynkapi.DataHook_Delete ||
+		len(noteEvents[0].Rows) != 1 || noteEvents[0].Rows[0].Id != "n1" {
+		t.Fatalf("invalid cascade after-hook event %v", noteEvents)
+	}
+
+	if _, err := s.DataRestore(context.Background(), &lynkapi.DataRestore{
+		InstanceName: "test",
+		TableName:    "tasks",
+		Filter:       (&lynkapi.DataQuery_Filter{}).And("id", "t1"),
+		Version:      1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 3 || events[2].Action != lynkapi.DataHook_Restore ||
+		len(events[2].Rows) != 1 || events[2].Rows[0].Fields["title"].GetStringValue() != "first" {
+		t.Fatalf("invalid restore after-hook event %v", events)
+	}
+}
+
+func Test_Service_DataDryRun(t *testing.T) {
+
+	type Task struct {
+		Id     string `json:"id" x_attrs:"primary_key,object_id(16)"`
+		Title  string `json:"title"`
+		Status string `json:"status" x_enums:"todo,done"`
+	}
+	type Note struct {
+		Id     string `json:"id" x_attrs:"primary_key"`
+		TaskId string `json:"task_id" x_ref:"tasks.id" x_ref_delete:"cascade"`
+	}
+	type Link struct {
+		Id     string `json:"id" x_attrs:"primary_key"`
+		TaskId string `json:"task_id" x_ref:"tasks.id" x_ref_delete:"set_null"`
+	}
+	type Object struct {
+		Tasks []*Task `json:"tasks"`
+		Notes []*Note `json:"notes"`
+		Links []*Link `json:"links"`
+	}
+
+	inst, err := oneobject.NewInstance("test", &Object{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"tasks", "notes", "links"} {
+		if err := inst.TableSetup(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := lynkapi.NewService()
+	if err := s.RegisterDataService(inst); err != nil {
+		t.Fatal(err)
+	}
+
+	var before, after int
+	if err := s.RegisterDataHook("test", "tasks",
+		lynkapi.DataBeforeHook(func(ev *lynkapi.DataHookEvent) error {
+			before += 1
+			return nil
+		}),
+		lynkapi.DataAfterHook(func(ev *lynkapi.DataHookEvent) {
+			after += 1
+		}),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	count := func() int {
+		rs, err := s.DataQuery(context.Background(), &lynkapi.DataQuery{
+			InstanceName: "test",
+			TableName:    "tasks",
+			Limit:        10,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(rs.Rows)
+	}
+
+	upsert := func(id, title, status string, dryRun bool) (*lynkapi.DataResult, error) {
+		req := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    "tasks",
+			DryRun:       dryRun,
+		}
+		if id != "" {
+			req.SetField("id", id)
+		}
+		req.SetField("title", title)
+		req.SetField("status", status)
+		return s.DataUpsert(context.Background(), req)
+	}
+
+	// create, with a generated key
+	rs, err := upsert("", "first", "todo", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rs.Rows) != 1 || len(rs.Rows[0].Id) != 16 ||
+		len(rs.Changes) != 1 || rs.Changes[0].Action != lynkapi.DataRowChange_Create {
+		t.Fatalf("invalid dry run create %v", rs)
+	}
+	if before != 1 || after != 0 || count() != 0 {
+		t.Fatalf("dry run committed: hooks %d/%d, rows %d", before, after, count())
+	}
+
+	// validation
+	if _, err := upsert("", "first", "wip", true); err == nil {
+		t.Fatal("invalid enum value accepted in dry run")
+	}
+
+	if _, err := upsert("t1", "first", "todo", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := upsert("t2", "second", "todo", false); err != nil {
+		t.Fatal(err)
+	}
+
+	// update
+	rs, err = upsert("t1", "first!", "todo", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rs.Changes) != 1 || rs.Changes[0].Action != lynkapi.DataRowChange_Update ||
+		len(rs.Changes[0].Changes) != 1 || rs.Changes[0].Changes[0].Path != "title" ||
+		rs.Changes[0].Changes[0].OldValue.GetStringValue() != "first" ||
+		rs.Changes[0].Changes[0].NewValue.GetStringValue() != "first!" {
+		t.Fatalf("invalid dry run update %v", rs)
+	}
+
+	updateReq := func(dryRun bool) *lynkapi.DataUpdate {
+		return &lynkapi.DataUpdate{
+			InstanceName: "test",
+			TableName:    "tasks",
+			Fields:       []string{"status"},
+			Values:       []*structpb.Value{structpb.NewStringValue("done")},
+			Filter:       (&lynkapi.DataQuery_Filter{}).And("status", "todo"),
+			DryRun:       dryRun,
+		}
+	}
+
+	after = 0
+	rs, err = s.DataUpdate(context.Background(), updateReq(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs.Stats.RowsHit != 2 || len(rs.Changes) != 2 || after != 0 {
+		t.Fatalf("invalid dry run update %v", rs)
+	}
+	for _, ch := range rs.Changes {
+		if len(ch.Changes) != 1 || ch.Changes[0].Path != "status" {
+			t.Fatalf("invalid dry run update change %v", ch)
+		}
+	}
+
+	rs, err = s.DataUpdate(context.Background(), updateReq(false))
+	if err != nil || rs.Stats.RowsHit != 2 || after != 1 {
+		t.Fatalf("update %v %v", rs, err)
+	}
+	if rs, err = s.DataUpdate(context.Background(), updateReq(false)); err != nil || rs.Stats.RowsHit != 0 {
+		t.Fatalf("update again %v %v", rs, err)
+	}
+
+	// delete, with the rows changed by the references
+	for _, table := range []string{"notes", "links"} {
+		req := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    table,
+		}
+		req.SetField("id", table+"-1")
+		req.SetField("task_id", "t1")
+		if _, err := s.DataUpsert(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rs, err = s.DataDelete(context.Background(), &lynkapi.DataDelete{
+		InstanceName: "test",
+		TableName:    "tasks",
+		Filter:       (&lynkapi.DataQuery_Filter{}).And("id", "t1"),
+		DryRun:       true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rs.Rows) != 1 || rs.Rows[0].Id != "t1" ||
+		rs.Rows[0].Fields["status"].GetStringValue() != "done" || len(rs.Changes) != 3 ||
+		rs.Changes[0].Action != lynkapi.DataRowChange_Delete || count() != 2 {
+		t.Fatalf("invalid dry run delete %v", rs)
+	}
+	changes := map[string]*lynkapi.DataRowChange{}
+	for _, ch := range rs.Changes[1:] {
+		changes[ch.TableName] = ch
+	}
+	if ch := changes["notes"]; ch == nil || ch.Id != "notes-1" || ch.Action != lynkapi.DataRowChange_Delete {
+		t.Fatalf("invalid dry run cascade %v", rs.Changes)
+	}
+	if ch := changes["links"]; ch == nil || ch.Id != "links-1" || ch.Action != lynkapi.DataRowChange_Update ||
+		len(ch.Changes) != 1 || ch.Changes[0].Path != "task_id" {
+		t.Fatalf("invalid dry run set_null %v", rs.Changes)
+	}
+	for _, table := range []string{"notes", "links"} {
+		rs, err := s.DataQuery(context.Background(), &lynkapi.DataQuery{
+			InstanceName: "test",
+			TableName:    table,
+		})
+		if err != nil || len(rs.Rows) != 1 || rs.Rows[0].Fields["task_id"].GetStringValue() != "t1" {
+			t.Fatalf("dry run delete committed on %s: %v %v", table, rs, err)
+		}
+	}
+}
+
+type oneInstance = oneobject.Instance
+
+// deletingInstance deletes a row right after a query matched it.
+type deletingInstance struct {
+	*oneInstance
+	id string
+}
+
+func (it *deletingInstance) Query(q *lynkapi.DataQuery) (*lynkapi.DataResult, error) {
+	rs, err := it.oneInstance.Query(q)
+	if err == nil && it.id != "" {
+		del := &lynkapi.DataDelete{
+			InstanceName: q.InstanceName,
+			TableName:    q.TableName,
+			Filter:       (&lynkapi.DataQuery_Filter{}).And("id", it.id),
+		}
+		it.id = ""
+		if _, err := it.oneInstance.Delete(del); err != nil {
+			return nil, err
+		}
+	}
+	return rs, err
+}
+
+func Test_Service_DataUpdate(t *testing.T) {
+
+	type Task struct {
+		Id     string `json:"id" x_attrs:"primary_key"`
+		Status string `json:"status"`
+	}
+	type Object struct {
+		Tasks []*Task `json:"tasks"`
+	}
+
+	inst, err := oneobject.NewInstance("test", &Object{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("tasks"); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		ds = &deletingInstance{oneInstance: inst}
+		s  = lynkapi.NewService()
+	)
+	if err := s.RegisterDataService(ds); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"t1", "t2"} {
+		req := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    "tasks",
+		}
+		req.SetField("id", id)
+		req.SetField("status", "todo")
+		if _, err := s.DataUpsert(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// a row deleted once matched is not created again
+	ds.id = "t2"
+	rs, err := s.DataUpdate(context.Background(), &lynkapi.DataUpdate{
+		InstanceName: "test",
+		TableName:    "tasks",
+		Fields:       []string{"status"},
+		Values:       []*structpb.Value{structpb.NewStringValue("done")},
+		Filter:       (&lynkapi.DataQuery_Filter{}).And("status", "todo"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs.Stats.RowsHit != 2 || len(rs.Rows) != 1 || rs.Rows[0].Id != "t1" {
+		t.Fatalf("invalid update %v", rs)
+	}
+
+	if rs, err = s.DataQuery(context.Background(), &lynkapi.DataQuery{
+		InstanceName: "test",
+		TableName:    "tasks",
+	}); err != nil || len(rs.Rows) != 1 || rs.Rows[0].Id != "t1" {
+		t.Fatalf("deleted row written %v %v", rs, err)
+	}
+}
+
+// refusingInstance refuses the writes of a row.
+type refusingInstance struct {
+	*oneInstance
+	id string
+}
+
+func (it *refusingInstance) Update(q *lynkapi.DataInsert) (*lynkapi.DataResult, error) {
+	for i, name := range q.Fields {
+		if name == "id" && q.Values[i].GetStringValue() == it.id {
+			return nil, lynkapi.NewConflictError("row refused")
+		}
+	}
+	return it.oneInstance.Update(q)
+}
+
+func (it *refusingInstance) Delete(q *lynkapi.DataDelete) (*lynkapi.DataResult, error) {
+	if q.TableName == "tasks" && filterHas(q.Filter, it.id) {
+		return nil, lynkapi.NewConflictError("row refused")
+	}
+	return it.oneInstance.Delete(q)
+}
+
+func filterHas(fr *lynkapi.DataQuery_Filter, value string) bool {
+	if fr.GetValue().GetStringValue() == value {
+		return true
+	}
+	for _, inner := range fr.GetInner() {
+		if filterHas(inner, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func Test_Service_DataUpdateRefused(t *testing.T) {
+
+	type Task struct {
+		Id     string `json:"id" x_attrs:"primary_key"`
+		Status string `json:"status"`
+	}
+	type Note struct {
+		Id     string `json:"id" x_attrs:"primary_key"`
+		TaskId string `json:"task_id" x_ref:"tasks.id" x_ref_delete:"cascade"`
+	}
+	type Object struct {
+		Tasks []*Task `json:"tasks"`
+		Notes []*Note `json:"notes"`
+	}
+
+	inst, err := oneobject.NewInstance("test", &Object{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"tasks", "notes"} {
+		if err := inst.TableSetup(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var (
+		ds = &refusingInstance{oneInstance: inst}
+		s  = lynkapi.NewService()
+	)
+	if err := s.RegisterDataService(ds); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range [][]string{{"tasks", "id", "t1", "status", "todo"},
+		{"tasks", "id", "t2", "status", "todo"}, {"notes", "id", "n1", "task_id", "t2"}} {
+		req := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    v[0],
+		}
+		req.SetField(v[1], v[2])
+		req.SetField(v[3], v[4])
+		if _, err := s.DataUpsert(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	query := func(table string) []*lynkapi.DataRow {
+		rs, err := s.DataQuery(context.Background(), &lynkapi.DataQuery{
+			InstanceName: "test",
+			TableName:    table,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rs.Rows
+	}
+
+	ds.id = "t2"
+
+	{ // a row refused writes none of the rows
+		_, err := s.DataUpdate(context.Background(), &lynkapi.DataUpdate{
+			InstanceName: "test",
+			TableName:    "tasks",
+			Fields:       []string{"status"},
+			Values:       []*structpb.Value{structpb.NewStringValue("done")},
+			Filter:       (&lynkapi.DataQuery_Filter{}).And("status", "todo"),
+		})
+		if err == nil {
+			t.Fatal("refused update accepted")
+		}
+		for _, row := range query("tasks") {
+			if row.Fields["status"].GetStringValue() != "todo" {
+				t.Fatalf("refused update committed %v", row)
+			}
+		}
+	}
+
+	{ // a refused delete applies none of its cascades
+		_, err := s.DataDelete(context.Background(), &lynkapi.DataDelete{
+			InstanceName: "test",
+			TableName:    "tasks",
+			Filter:       (&lynkapi.DataQuery_Filter{}).And("id", "t2"),
+		})
+		if err == nil {
+			t.Fatal("refused delete accepted")
+		}
+		if rows := query("notes"); len(rows) != 1 || rows[0].Id != "n1" {
+			t.Fatalf("cascade of a refused delete committed %v", rows)
+		}
+	}
+}
+
+func Test_Service_DataFieldMask(t *testing.T) {
+
+	type Task struct {
+		Id    string `json:"id" x_attrs:"primary_key"`
+		Title string `json:"title"`
+		Note  string `json:"note"`
+		Done  bool   `json:"done"`
+		Score int64  `json:"score"`
+	}
+	type Object struct {
+		Tasks []*Task `json:"tasks"`
+	}
+
+	inst, err := oneobject.NewInstance("test", &Object{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("tasks"); err != nil {
+		t.Fatal(err)
+	}
+
+	s := lynkapi.NewService()
+	if err := s.RegisterDataService(inst); err != nil {
+		t.Fatal(err)
+	}
+
+	req := &lynkapi.DataInsert{
+		InstanceName: "test",
+		TableName:    "tasks",
+	}
+	req.SetField("id", "t1")
+	req.SetField("title", "first")
+	req.SetField("note", "note")
+	req.Fields = append(req.Fields, "done", "score")
+	req.Values = append(req.Values, structpb.NewBoolValue(true), structpb.NewNumberValue(5))
+	if _, err := s.DataUpsert(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+
+	row := func() *lynkapi.DataRow {
+		rs, err := s.DataQuery(context.Background(), &lynkapi.DataQuery{
+			InstanceName: "test",
+			TableName:    "tasks",
+			Filter:       (&lynkapi.DataQuery_Filter{}).And("id", "t1"),
+			Limit:        1,
+		})
+		if err != nil || len(rs.Rows) != 1 {
+			t.Fatalf("query %v %v", rs, err)
+		}
+		return rs.Rows[0]
+	}
+
+	// the fields of an update are overwritten, zero values included
+	if _, err := s.DataUpdate(context.Background(), &lynkapi.DataUpdate{
+		InstanceName: "test",
+		TableName:    "tasks",
+		Fields:       []string{"done", "score"},
+		Values:       []*structpb.Value{structpb.NewBoolValue(false), structpb.NewNumberValue(0)},
+		Filter:       (&lynkapi.DataQuery_Filter{}).And("id", "t1"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if v := row().Fields; v["done"].GetBoolValue() || v["score"].GetNumberValue() != 0 ||
+		v["title"].GetStringValue() != "first" {
+		t.Fatalf("invalid update %v", v)
+	}
+
+	// upsert with a field mask
+	req = &lynkapi.DataInsert{
+		InstanceName: "test",
+		TableName:    "tasks",
+		FieldMask:    []string{"note"},
+	}
+	req.SetField("id", "t1")
+	req.SetField("title", "ignored")
+	if _, err := s.DataUpsert(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	if v := row().Fields; v["note"].GetStringValue() != "" || v["title"].GetStringValue() != "first" {
+		t.Fatalf("invalid masked upsert %v", v)
+	}
+}
+
+func Test_Service_DataFederated(t *testing.T) {
+
+	type Order struct {
+		Id     string `json:"id" x_attrs:"primary_key"`
+		UserId string `json:"user_id"`
+		Amount int64  `json:"amount"`
+	}
+	type User struct {
+		Id   string `json:"id" x_attrs:"primary_key"`
+		Name string `json:"name"`
+	}
+	type Object struct {
+		Orders []*Order `json:"orders"`
+		Users  []*User  `json:"users"`
+	}
+	type Order2 struct {
+		Id     string `json:"id" x_attrs:"primary_key"`
+		Amount string `json:"amount"`
+	}
+	type Object2 struct {
+		Orders []*Order2 `json:"orders"`
+	}
+
+	s := lynkapi.NewService()
+	for _, v := range []struct {
+		name string
+		obj  any
+	}{
+		{"east", &Object{}},
+		{"west", &Object{}},
+		{"bad", &Object2{}},
+	} {
+		inst, err := oneobject.NewInstance(v.name, v.obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := inst.TableSetup("orders"); err != nil {
+			t.Fatal(err)
+		}
+		if v.name != "bad" {
+			if err := inst.TableSetup("users"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := s.RegisterDataService(inst); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	upsert := func(inst, table string, kvs ...any) {
+		req := &lynkapi.DataInsert{
+			InstanceName: inst,
+			TableName:    table,
+		}
+		for i := 0; i+1 < len(kvs); i += 2 {
+			v, _ := structpb.NewValue(kvs[i+1])
+			req.Fields = append(req.Fields, kvs[i].(string))
+			req.Values = append(req.Values, v)
+		}
+		if _, err := s.DataUpsert(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 150 orders per instance, so the rows of every instance are read in pages
+	for i := 0; i < 150; i++ {
+		upsert("east", "orders", "id", fmt.Sprintf("e%03d", i), "user_id", "u1", "amount", i*2)
+		upsert("west", "orders", "id", fmt.Sprintf("w%03d", i), "user_id", "u2", "amount", i*2+1)
+	}
+	upsert("west", "orders", "id", "w999", "user_id", "u3", "amount", 1000)
+	upsert("east", "users", "id", "u1", "name", "alice")
+	upsert("east", "users", "id", "u2", "name", "bob")
+
+	query := func(q *lynkapi.DataQuery) *lynkapi.DataResult {
+		q.TableName = "orders"
+		rs, err := s.DataQuery(context.Background(), q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rs
+	}
+
+	ids := func(rs *lynkapi.DataResult) string {
+		var ls []string
+		for _, row := range rs.Rows {
+			ls = append(ls, row.InstanceName+"/"+row.Id)
+		}
+		return strings.Join(ls, ",")
+	}
+
+	{ // merged in instance order
+		rs := query(&lynkapi.DataQuery{
+			InstanceNames: []string{"west", "east"},
+			Offset:        150,
+			Limit:         2,
+		})
+		if v := ids(rs); v != "west/w999,east/e000" {
+			t.Fatalf("invalid rows %s", v)
+		}
+	}
+
+	{ // sorted and paged over all the instances
+		var pages []string
+		for offset := 0; offset < 6; offset += 3 {
+			rs := query(&lynkapi.DataQuery{
+				InstanceNames: []string{"east", "west"},
+				Sort:          &lynkapi.DataQuery_SortFilter{Type: "desc", Field: "amount"},
+				Offset:        int32(offset),
+				Limit:         3,
+			})
+			pages = append(pages, ids(rs))
+		}
+		if v := strings.Join(pages, "|"); v != "west/w999,west/w149,east/e149|west/w148,east/e148,west/w147" {
+			t.Fatalf("invalid pages %s", v)
+		}
+	}
+
+	{ // inner join on the users of east
+		rs := query(&lynkapi.DataQuery{
+			InstanceNames: []string{"east", "west"},
+			Sort:          &lynkapi.DataQuery_SortFilter{Type: "desc", Field: "amount"},
+			Limit:         2,
+			Join: &lynkapi.DataQuery_Join{
+				InstanceName: "east",
+				TableName:    "users",
+				Field:        "user_id",
+				Fields:       []string{"name"},
+			},
+		})
+		if v := ids(rs); v != "west/w149,east/e149" {
+			t.Fatalf("invalid join rows %s", v)
+		}
+		if v := rs.Rows[0].Fields["users.name"].GetStringValue(); v != "bob" {
+			t.Fatalf("invalid join field %s", v)
+		}
+		if field, _ := rs.Spec.Field("users.name"); field == nil {
+			t.Fatal("join field spec not found")
+		}
+	}
+
+	{ // left join keeps the rows without a match
+		rs := query(&lynkapi.DataQuery{
+			InstanceName: "west",
+			Filter:       &lynkapi.DataQuery_Filter{Field: "user_id", Value: structpb.NewStringValue("u3")},
+			Join: &lynkapi.DataQuery_Join{
+				Type:         lynkapi.DataQuery_Join_Left,
+				InstanceName: "east",
+				TableName:    "users",
+				Field:        "user_id",
+			},
+		})
+		if v := ids(rs); v != "west/w999" || rs.Rows[0].Fields["users.name"] != nil {
+			t.Fatalf("invalid left join rows %s", v)
+		}
+	}
+
+	if _, err := s.DataQuery(context.Background(), &lynkapi.DataQuery{
+		InstanceNames: []string{"east", "bad"},
+		TableName:     "orders",
+	}); lynkapi.ParseError(err).Code != lynkapi.StatusCode_BadRequest {
+		t.Fatalf("spec mismatch: %v", err)
+	}
+}
+
+func Test_Service_DataExplain(t *testing.T) {
+
+	type Item struct {
+		Id    string `json:"id" x_attrs:"primary_key"`
+		Group string `json:"group"`
+	}
+	type Object struct {
+		Items []*Item `json:"items"`
+	}
+
+	s := lynkapi.NewService()
+	for _, name := range []string{"a", "b"} {
+		inst, err := oneobject.NewInstance(name, &Object{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := inst.TableSetup("items"); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.RegisterDataService(inst); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 30; i++ {
+			req := &lynkapi.DataInsert{
+				InstanceName: name,
+				TableName:    "items",
+			}
+			req.SetField("id", fmt.Sprintf("%s%02d", name, i))
+			req.SetField("group", fmt.Sprintf("g%d", i%3))
+			if _, err := s.DataUpsert(context.Background(), req); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	q, err := lynkapi.ParseDataQuery("select * from a.items where group = 'g1' limit 4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q.Explain = true
+	q.DictDisplay = true
+
+	rs, err := s.DataQuery(context.Background(), q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs.Stats.RowsHit != 10 || rs.Stats.RowsReturned != 4 {
+		t.Fatalf("invalid stats %v", rs.Stats)
+	}
+
+	var stages []string
+	for _, st := range rs.Plan.GetStages() {
+		stages = append(stages, st.Name)
+	}
+	if plan := rs.Plan; plan.Driver != "oneobject" || plan.Access != lynkapi.DataQueryPlan_FullScan ||
+		plan.RowsExamined != 30 || plan.RowsReturned != 4 ||
+		strings.Join(stages, ",") != "access,scan,dict_display" || plan.Stages[1].Rows != 10 {
+		t.Fatalf("invalid plan %v", plan)
+	}
+
+	// federated: the rows hit of every instance
+	q.InstanceName, q.InstanceNames = "", []string{"a", "b"}
+	q.DictDisplay = false
+	q.Offset = 8
+	if rs, err = s.DataQuery(context.Background(), q); err != nil {
+		t.Fatal(err)
+	}
+	stages = nil
+	for _, st := range rs.Plan.GetStages() {
+		stages = append(stages, st.Name)
+	}
+	if rs.Stats.RowsHit != 20 || rs.Plan.RowsExamined != 60 || len(rs.Rows) != 4 ||
+		strings.Join(stages, ",") != "instance,instance,page" {
+		t.Fatalf("invalid federated plan %v %v", rs.Stats, rs.Plan)
+	}
+
+	// not explained
+	q.Explain = false
+	if rs, err = s.DataQuery(context.Background(), q); err != nil || rs.Plan != nil {
+		t.Fatalf("plan not requested %v %v", rs.GetPlan(), err)
+	}
+}
+
+// testQueryStream passes the results of a query stream through a channel
+// without buffer, as a client reading one result at a time.
+type testQueryStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	results chan *lynkapi.DataResult
+}
+
+func (it *testQueryStream) Context() context.Context {
+	return it.ctx
+}
+
+func (it *testQueryStream) Send(rs *lynkapi.DataResult) error {
+	select {
+	case it.results <- rs:
+		return nil
+	case <-it.ctx.Done():
+		return it.ctx.Err()
+	}
+}
+
+func Test_Service_DataQueryStream(t *testing.T) {
+
+	type Item struct {
+		Id   string `json:"id" x_attrs:"primary_key"`
+		Name string `json:"name"`
+	}
+	type Object struct {
+		Items []*Item `json:"items"`
+	}
+
+	obj := &Object{}
+	for i := 0; i < 1234; i++ {
+		obj.Items = append(obj.Items, &Item{
+			Id:   fmt.Sprintf("%04d", i),
+			Name: fmt.Sprintf("item %d", i),
+		})
+	}
+	inst, err := oneobject.NewInstance("test", obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("items"); err != nil {
+		t.Fatal(err)
+	}
+	s := lynkapi.NewService()
+	if err := s.RegisterDataService(inst); err != nil {
+		t.Fatal(err)
+	}
+
+	var batches []*lynkapi.DataResult
+
+	query := func(q *lynkapi.DataQuery) (*lynkapi.DataQueryIterator, chan error) {
+		ctx, fc := context.WithCancel(context.Background())
+		stream := &testQueryStream{
+			ctx:     ctx,
+			results: make(chan *lynkapi.DataResult),
+		}
+		done := make(chan error, 1)
+		go func() {
+			done <- s.DataQueryStream(q, stream)
+			close(stream.results)
+		}()
+		batches = nil
+		return lynkapi.NewDataQueryIterator(func() (*lynkapi.DataResult, error) {
+			rs, ok := <-stream.results
+			if !ok {
+				return nil, io.EOF
+			}
+			batches = append(batches, rs)
+			return rs, nil
+		}, fc), done
+	}
+
+	for _, columnar := range []bool{false, true} {
+		iter, done := query(&lynkapi.DataQuery{
+			InstanceName: "test",
+			TableName:    "items",
+			Columnar:     columnar,
+		})
+		n := 0
+		for iter.Next() {
+			if row := iter.Row(); row.Id != fmt.Sprintf("%04d", n) || row.Fields["name"].GetStringValue() != fmt.Sprintf("item %d", n) {
+				t.Fatalf("invalid row %d %v", n, row)
+			}
+			n++
+		}
+		if err := iter.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+		if n != 1234 || len(batches) != 3 || batches[0].Spec == nil || batches[1].Spec != nil || iter.Spec() == nil {
+			t.Fatalf("columnar %v: %d rows in %d batches", columnar, n, len(batches))
+		}
+	}
+
+	{ // limit
+		iter, done := query(&lynkapi.DataQuery{
+			InstanceName: "test",
+			TableName:    "items",
+			Offset:       100,
+			Limit:        600,
+		})
+		n := 0
+		for iter.Next() {
+			n++
+		}
+		if err := <-done; err != nil || n != 600 || iter.Err() != nil {
+			t.Fatalf("limit: %d rows, %v %v", n, err, iter.Err())
+		}
+	}
+
+	{ // the server stops once the client closes the iterator
+		iter, done := query(&lynkapi.DataQuery{
+			InstanceName: "test",
+			TableName:    "items",
+		})
+		if !iter.Next() {
+			t.Fatal(iter.Err())
+		}
+		iter.Close()
+		if err := <-done; err != context.Canceled {
+			t.Fatalf("server not canceled: %v", err)
+		}
+	}
+
+	{ // no rows
+		iter, done := query(&lynkapi.DataQuery{
+			InstanceName: "test",
+			TableName:    "items",
+			Filter:       &lynkapi.DataQuery_Filter{Field: "id", Value: structpb.NewStringValue("none")},
+		})
+		if iter.Next() || iter.Err() != nil || <-done != nil {
+			t.Fatalf("no rows: %v", iter.Err())
+		}
+	}
+}
+
+func Test_Service_DataOperator(t *testing.T) {
+
+	type Item struct {
+		Id   string `json:"id" x_attrs:"primary_key"`
+		Name string `json:"name"`
+	}
+	type Object struct {
+		Items []*Item `json:"items"`
+	}
+
+	inst, err := oneobject.NewInstance("test", &Object{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("items",
+		lynkapi.NewTableOption(lynkapi.TableSpec_Option_History, "on")); err != nil {
+		t.Fatal(err)
+	}
+	s := lynkapi.NewService()
+	if err := s.RegisterDataService(inst); err != nil {
+		t.Fatal(err)
+	}
+
+	alice := lynkapi.NewAuthIdentityContext(context.Background(), &lynkapi.AuthIdentity{User: "alice"})
+
+	for i, ctx := range []context.Context{context.Background(), alice} {
+		req := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    "items",
+			Operator:     "mallory",
+		}
+		req.SetField("id", "i1")
+		req.SetField("name", fmt.Sprintf("item %d", i))
+		if _, err := s.DataUpsert(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rs, err := s.DataQuery(context.Background(), &lynkapi.DataQuery{
+		InstanceName: "test",
+		TableName:    "items",
+		History:      true,
+		Filter:       (&lynkapi.DataQuery_Filter{}).And("id", "i1"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rs.Changes) != 2 || rs.Changes[0].Operator != "alice" || rs.Changes[1].Operator != "" {
+		t.Fatalf("client operator stored as history %v", rs.Changes)
+	}
+}
+
+func Test_Service_DataRestore(t *testing.T) {
+
+	type Group struct {
+		Id string `json:"id" x_attrs:"primary_key"`
+	}
+	type User struct {
+		Id      string `json:"id" x_attrs:"primary_key"`
+		Login   string `json:"login" x_attrs:"unique_key"`
+		GroupId string `json:"group_id" x_ref:"groups.id"`
+	}
+	type Note struct {
+		Id      string `json:"id" x_attrs:"primary_key"`
+		GroupId string `json:"group_id" x_ref:"groups.id"`
+		Deleted int64  `json:"deleted" x_attrs:"deleted"`
+	}
+	type Object struct {
+		Groups []*Group `json:"groups"`
+		Users  []*User  `json:"users"`
+		Notes  []*Note  `json:"notes"`
+	}
+
+	inst, err := oneobject.NewInstance("test", &Object{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("groups"); err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("users",
+		lynkapi.NewTableOption(lynkapi.TableSpec_Option_History, "on")); err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("notes",
+		lynkapi.NewTableOption(lynkapi.TableSpec_Option_SoftDelete, "true")); err != nil {
+		t.Fatal(err)
+	}
+
+	s := lynkapi.NewService()
+	if err := s.RegisterDataService(inst); err != nil {
+		t.Fatal(err)
+	}
+
+	upsert := func(table string, kvs ...string) {
+		req := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    table,
+		}
+		for i := 0; i+1 < len(kvs); i += 2 {
+			req.SetField(kvs[i], kvs[i+1])
+		}
+		if _, err := s.DataUpsert(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	restore := func(id string, version uint64) error {
+		_, err := s.DataRestore(context.Background(), &lynkapi.DataRestore{
+			InstanceName: "test",
+			TableName:    "users",
+			Filter:       (&lynkapi.DataQuery_Filter{}).And("id", id),
+			Version:      version,
+		})
+		return err
+	}
+
+	upsert("groups", "id", "g1")
+	upsert("groups", "id", "g2")
+	upsert("users", "id", "u1", "login", "alice", "group_id", "g1")
+	upsert("users", "id", "u1", "login", "alice2", "group_id", "g2")
+
+	// the version 1 references a deleted row
+	if _, err := s.DataDelete(context.Background(), &lynkapi.DataDelete{
+		InstanceName: "test",
+		TableName:    "groups",
+		Filter:       (&lynkapi.DataQuery_Filter{}).And("id", "g1"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := restore("u1", 1); lynkapi.ParseError(err).Code != lynkapi.StatusCode_BadRequest {
+		t.Fatalf("restore of a dangling reference accepted: %v", err)
+	}
+
+	// the version 1 has the unique key of another row
+	upsert("groups", "id", "g1")
+	upsert("users", "id", "u2", "login", "alice", "group_id", "g1")
+	if err := restore("u1", 1); lynkapi.ParseError(err).Code != lynkapi.StatusCode_Conflict {
+		t.Fatalf("restore of a taken unique key accepted: %v", err)
+	}
+
+	upsert("users", "id", "u2", "login", "bob")
+	if err := restore("u1", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	{ // a write into the trash has its references checked
+		req := &lynkapi.DataInsert{
+			InstanceName: "test",
+			TableName:    "notes",
+		}
+		req.SetField("id", "n1")
+		req.SetField("group_id", "g9")
+		req.Fields = append(req.Fields, "deleted")
+		req.Values = append(req.Values, structpb.NewNumberValue(float64(time.Now().UnixMilli())))
+		if _, err := s.DataUpsert(context.Background(), req); lynkapi.ParseError(err).Code != lynkapi.StatusCode_BadRequest {
+			t.Fatalf("dangling reference written into the trash: %v", err)
+		}
+	}
+}
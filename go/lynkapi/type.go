@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -41,6 +42,10 @@ const (
 	FieldSpec_StringTerm = "string_term"
 	FieldSpec_StringText = "string_text"
 
+	FieldSpec_Ref_Restrict = "restrict"
+	FieldSpec_Ref_Cascade  = "cascade"
+	FieldSpec_Ref_SetNull  = "set_null"
+
 	fieldSpec_Any        = "any"
 	fieldSpec_AnyTypeUri = "google.golang.org/protobuf/types/known/structpb.Value"
 )
@@ -68,6 +73,8 @@ var fieldSpecAttrs = map[string]int{
 	"rows":      1,
 	"data_rows": 1,
 
+	"deleted": 1,
+
 	"rand_hex":  2,
 	"object_id": 2,
 }
@@ -168,7 +175,9 @@ func (it *TypeSpec) Rows(data *structpb.Struct) (*FieldSpec, *structpb.ListValue
 	return nil, nil
 }
 
-func (it *TypeSpec) DataMerge(dstObject, srcObject any, opts ...any) (bool, error) {
+// DataMerge merges the non-empty values of srcObject into dstObject, and
+// returns the field changes made to dstObject.
+func (it *TypeSpec) DataMerge(dstObject, srcObject any, opts ...any) ([]*DataFieldChange, error) {
 	return specDataMerge(it, dstObject, srcObject, opts...)
 }
 
@@ -246,6 +255,34 @@ func fieldSpecFuncAttrMatcher(attr string) *FieldSpec_FuncAttr {
 	return nil
 }
 
+// parseFieldSpecRef parses the reference `instance.table.field` (or
+// `table.field` in the same instance) and the action on delete.
+func parseFieldSpecRef(ref, onDelete string) (*FieldSpec_Ref, error) {
+
+	ar := strings.Split(ref, ".")
+	if len(ar) == 2 {
+		ar = append([]string{""}, ar...)
+	}
+	if len(ar) != 3 ||
+		(ar[0] != "" && !NameIdentifier.MatchString(ar[0])) ||
+		!NameIdentifier.MatchString(ar[1]) || ar[2] == "" {
+		return nil, fmt.Errorf("invalid x_ref (%s)", ref)
+	}
+
+	switch onDelete {
+	case "", FieldSpec_Ref_Restrict, FieldSpec_Ref_Cascade, FieldSpec_Ref_SetNull:
+	default:
+		return nil, fmt.Errorf("invalid x_ref_delete (%s)", onDelete)
+	}
+
+	return &FieldSpec_Ref{
+		Instance: ar[0],
+		Table:    ar[1],
+		Field:    ar[2],
+		OnDelete: onDelete,
+	}, nil
+}
+
 func (it *FieldSpec) FuncAttr(names ...string) *FieldSpec_FuncAttr {
 
 	for _, name := range names {
@@ -306,7 +343,7 @@ func (it *TableSpec) PrimaryId(fields map[string]*structpb.Value) string {
 	return ""
 }
 
-func (it *FieldSpec) DataMerge(dstObject, srcObject any, opts ...any) (bool, error) {
+func (it *FieldSpec) DataMerge(dstObject, srcObject any, opts ...any) ([]*DataFieldChange, error) {
 	return specDataMerge(&TypeSpec{
 		Fields: it.Fields,
 	}, dstObject, srcObject, opts...)
@@ -376,6 +413,7 @@ func parseSpecByStructType(rt reflect.Type) (*TypeSpec, reflect.Type, error) {
 		parseStruct func(depth int, pField *FieldSpec, rt reflect.Type)
 		parseArray  func(depth int, pField *FieldSpec, rt reflect.Type)
 		parseSet    = map[string]bool{}
+		parseErr    error
 	)
 
 	parseTagValue := func(v string, t string) *structpb.Value {
@@ -424,7 +462,7 @@ func parseSpecByStructType(rt reflect.Type) (*TypeSpec, reflect.Type, error) {
 		}
 		ar := strings.Split(vlimits, ",")
 		if len(ar) == 1 {
-			defValue := parseTagValue(ar[1], field.Type)
+			defValue := parseTagValue(ar[0], field.Type)
 			if defValue == nil {
 				return
 			}
@@ -524,6 +562,17 @@ func parseSpecByStructType(rt reflect.Type) (*TypeSpec, reflect.Type, error) {
 					}
 				}
 
+				if pt := fd.Tag.Get("x_pattern"); pt != "" {
+					if _, err := regexp.Compile(pt); err == nil {
+						if field.Opts == nil {
+							field.Opts = map[string]*structpb.Value{}
+						}
+						field.Opts["pattern"] = structpb.NewStringValue(pt)
+					} else if parseErr == nil {
+						parseErr = fmt.Errorf("field (%s) invalid x_pattern (%s)", field.TagName, pt)
+					}
+				}
+
 				if dn := fd.Tag.Get("x_dict_ns"); dn != "" {
 					ar := strings.Split(dn, ",")
 					for _, v := range ar {
@@ -635,6 +684,14 @@ func parseSpecByStructType(rt reflect.Type) (*TypeSpec, reflect.Type, error) {
 				}
 			}
 
+			if rf, rd := fd.Tag.Get("x_ref"), fd.Tag.Get("x_ref_delete"); rf != "" || rd != "" {
+				ref, err := parseFieldSpecRef(rf, rd)
+				if err != nil && parseErr == nil {
+					parseErr = fmt.Errorf("field (%s) %s", field.TagName, err.Error())
+				}
+				field.Ref = ref
+			}
+
 			if fd.Tag.Get("x_styles") != "" {
 				arr := strings.Split(fd.Tag.Get("x_styles"), ";")
 				for _, v := range arr {
@@ -696,6 +753,10 @@ func parseSpecByStructType(rt reflect.Type) (*TypeSpec, reflect.Type, error) {
 
 	parseStruct(0, baseSpec, rt)
 
+	if parseErr != nil {
+		return nil, nil, parseErr
+	}
+
 	return &TypeSpec{
 		Name:   baseSpec.Name,
 		Kind:   baseSpec.Kind,
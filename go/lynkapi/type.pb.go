@@ -118,6 +118,8 @@ type FieldSpec struct {
 	Opts map[string]*structpb.Value `protobuf:"bytes,11,rep,name=opts,proto3" json:"opts,omitempty" toml:"opts,omitempty" yaml:"opts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// description
 	Desc string `protobuf:"bytes,12,opt,name=desc,proto3" json:"desc,omitempty" toml:"desc,omitempty" yaml:"desc,omitempty"`
+	// reference to the field of a table, ex: x_ref:"instance.table.field"
+	Ref *FieldSpec_Ref `protobuf:"bytes,13,opt,name=ref,proto3" json:"ref,omitempty" toml:"ref,omitempty" yaml:"ref,omitempty"`
 	Fields []*FieldSpec `protobuf:"bytes,16,rep,name=fields,proto3" json:"fields,omitempty" toml:"fields,omitempty" yaml:"fields,omitempty"`
 }
 
@@ -223,6 +225,13 @@ func (x *FieldSpec) GetDesc() string {
 	return ""
 }
 
+func (x *FieldSpec) GetRef() *FieldSpec_Ref {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
 func (x *FieldSpec) GetFields() []*FieldSpec {
 	if x != nil {
 		return x.Fields
@@ -235,8 +244,9 @@ type ServiceStatus struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Code    string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty" toml:"code,omitempty" yaml:"code,omitempty"`
-	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty" toml:"message,omitempty" yaml:"message,omitempty"`
+	Code       string                     `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty" toml:"code,omitempty" yaml:"code,omitempty"`
+	Message    string                     `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty" toml:"message,omitempty" yaml:"message,omitempty"`
+	Violations []*ServiceStatus_Violation `protobuf:"bytes,3,rep,name=violations,proto3" json:"violations,omitempty" toml:"violations,omitempty" yaml:"violations,omitempty"`
 }
 
 func (x *ServiceStatus) Reset() {
@@ -285,6 +295,156 @@ func (x *ServiceStatus) GetMessage() string {
 	return ""
 }
 
+func (x *ServiceStatus) GetViolations() []*ServiceStatus_Violation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+type FieldSpec_Ref struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Instance string `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty" toml:"instance,omitempty" yaml:"instance,omitempty"`
+	Table    string `protobuf:"bytes,2,opt,name=table,proto3" json:"table,omitempty" toml:"table,omitempty" yaml:"table,omitempty"`
+	Field    string `protobuf:"bytes,3,opt,name=field,proto3" json:"field,omitempty" toml:"field,omitempty" yaml:"field,omitempty"`
+	OnDelete string `protobuf:"bytes,4,opt,name=on_delete,json=onDelete,proto3" json:"on_delete,omitempty" toml:"on_delete,omitempty" yaml:"on_delete,omitempty" x_enums:",restrict,cascade,set_null"`
+}
+
+func (x *FieldSpec_Ref) Reset() {
+	*x = FieldSpec_Ref{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lynkapi_type_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FieldSpec_Ref) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldSpec_Ref) ProtoMessage() {}
+
+func (x *FieldSpec_Ref) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_type_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldSpec_Ref.ProtoReflect.Descriptor instead.
+func (*FieldSpec_Ref) Descriptor() ([]byte, []int) {
+	return file_lynkapi_type_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *FieldSpec_Ref) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *FieldSpec_Ref) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+func (x *FieldSpec_Ref) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldSpec_Ref) GetOnDelete() string {
+	if x != nil {
+		return x.OnDelete
+	}
+	return ""
+}
+
+// a field of a request failing a validation rule
+type ServiceStatus_Violation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path    string          `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty" toml:"path,omitempty" yaml:"path,omitempty"` // json path of the field (ex: `items[0].name`)
+	Rule    string          `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty" toml:"rule,omitempty" yaml:"rule,omitempty" x_enums:"required,enum,min,max,pattern,type,unique"`
+	Value   *structpb.Value `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty" toml:"value,omitempty" yaml:"value,omitempty"`
+	Message string          `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty" toml:"message,omitempty" yaml:"message,omitempty"`
+}
+
+func (x *ServiceStatus_Violation) Reset() {
+	*x = ServiceStatus_Violation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_lynkapi_type_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServiceStatus_Violation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceStatus_Violation) ProtoMessage() {}
+
+func (x *ServiceStatus_Violation) ProtoReflect() protoreflect.Message {
+	mi := &file_lynkapi_type_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceStatus_Violation.ProtoReflect.Descriptor instead.
+func (*ServiceStatus_Violation) Descriptor() ([]byte, []int) {
+	return file_lynkapi_type_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *ServiceStatus_Violation) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ServiceStatus_Violation) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *ServiceStatus_Violation) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *ServiceStatus_Violation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 var File_lynkapi_type_proto protoreflect.FileDescriptor
 
 var file_lynkapi_type_proto_rawDesc = []byte{
@@ -297,7 +457,7 @@ var file_lynkapi_type_proto_rawDesc = []byte{
 	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
 	0x2a, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32,
 	0x12, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x53,
-	0x70, 0x65, 0x63, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0xf5, 0x03, 0x0a, 0x09,
+	0x70, 0x65, 0x63, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x8b, 0x05, 0x0a, 0x09,
 	0x46, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x70, 0x65, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
 	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a,
 	0x08, 0x74, 0x61, 0x67, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
@@ -316,27 +476,49 @@ var file_lynkapi_type_proto_rawDesc = []byte{
 	0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x70, 0x65, 0x63, 0x2e,
 	0x4f, 0x70, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6f, 0x70, 0x74, 0x73, 0x12,
 	0x12, 0x0a, 0x04, 0x64, 0x65, 0x73, 0x63, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64,
-	0x65, 0x73, 0x63, 0x12, 0x2a, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x10, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x46, 0x69,
-	0x65, 0x6c, 0x64, 0x53, 0x70, 0x65, 0x63, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x1a,
-	0x51, 0x0a, 0x0b, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x65, 0x73, 0x63, 0x12, 0x28, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x53, 0x70, 0x65, 0x63, 0x2e, 0x52, 0x65, 0x66, 0x52, 0x03, 0x72, 0x65, 0x66, 0x12, 0x2a, 0x0a,
+	0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x70, 0x65,
+	0x63, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x1a, 0x6a, 0x0a, 0x03, 0x52, 0x65, 0x66,
+	0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x6e, 0x5f, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x6e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x1a, 0x51, 0x0a, 0x0b, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4f, 0x0a, 0x09, 0x4f, 0x70, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xfc, 0x01, 0x0a, 0x0d, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x40, 0x0a, 0x0a, 0x76, 0x69, 0x6f,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0a, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x7b, 0x0a, 0x09, 0x56,
+	0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04,
+	0x72, 0x75, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65,
+	0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
 	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x1a, 0x4f, 0x0a, 0x09, 0x4f, 0x70, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
-	0x02, 0x38, 0x01, 0x22, 0x3d, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x42, 0x30, 0x48, 0x03, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x6c, 0x79, 0x6e, 0x6b, 0x64, 0x62, 0x2f, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70,
-	0x69, 0x2f, 0x67, 0x6f, 0x2f, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x3b, 0x6c, 0x79, 0x6e,
-	0x6b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x30, 0x48, 0x03, 0x5a, 0x2c, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x79, 0x6e, 0x6b, 0x64, 0x62, 0x2f,
+	0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x6f, 0x2f, 0x6c, 0x79, 0x6e, 0x6b, 0x61,
+	0x70, 0x69, 0x3b, 0x6c, 0x79, 0x6e, 0x6b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -351,27 +533,32 @@ func file_lynkapi_type_proto_rawDescGZIP() []byte {
 	return file_lynkapi_type_proto_rawDescData
 }
 
-var file_lynkapi_type_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_lynkapi_type_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_lynkapi_type_proto_goTypes = []interface{}{
-	(*TypeSpec)(nil),       // 0: lynkapi.TypeSpec
-	(*FieldSpec)(nil),      // 1: lynkapi.FieldSpec
-	(*ServiceStatus)(nil),  // 2: lynkapi.ServiceStatus
-	nil,                    // 3: lynkapi.FieldSpec.StylesEntry
-	nil,                    // 4: lynkapi.FieldSpec.OptsEntry
-	(*structpb.Value)(nil), // 5: google.protobuf.Value
+	(*TypeSpec)(nil),                // 0: lynkapi.TypeSpec
+	(*FieldSpec)(nil),               // 1: lynkapi.FieldSpec
+	(*ServiceStatus)(nil),           // 2: lynkapi.ServiceStatus
+	(*FieldSpec_Ref)(nil),           // 3: lynkapi.FieldSpec.Ref
+	nil,                             // 4: lynkapi.FieldSpec.StylesEntry
+	nil,                             // 5: lynkapi.FieldSpec.OptsEntry
+	(*ServiceStatus_Violation)(nil), // 6: lynkapi.ServiceStatus.Violation
+	(*structpb.Value)(nil),          // 7: google.protobuf.Value
 }
 var file_lynkapi_type_proto_depIdxs = []int32{
 	1, // 0: lynkapi.TypeSpec.fields:type_name -> lynkapi.FieldSpec
-	3, // 1: lynkapi.FieldSpec.styles:type_name -> lynkapi.FieldSpec.StylesEntry
-	4, // 2: lynkapi.FieldSpec.opts:type_name -> lynkapi.FieldSpec.OptsEntry
-	1, // 3: lynkapi.FieldSpec.fields:type_name -> lynkapi.FieldSpec
-	5, // 4: lynkapi.FieldSpec.StylesEntry.value:type_name -> google.protobuf.Value
-	5, // 5: lynkapi.FieldSpec.OptsEntry.value:type_name -> google.protobuf.Value
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	4, // 1: lynkapi.FieldSpec.styles:type_name -> lynkapi.FieldSpec.StylesEntry
+	5, // 2: lynkapi.FieldSpec.opts:type_name -> lynkapi.FieldSpec.OptsEntry
+	3, // 3: lynkapi.FieldSpec.ref:type_name -> lynkapi.FieldSpec.Ref
+	1, // 4: lynkapi.FieldSpec.fields:type_name -> lynkapi.FieldSpec
+	6, // 5: lynkapi.ServiceStatus.violations:type_name -> lynkapi.ServiceStatus.Violation
+	7, // 6: lynkapi.FieldSpec.StylesEntry.value:type_name -> google.protobuf.Value
+	7, // 7: lynkapi.FieldSpec.OptsEntry.value:type_name -> google.protobuf.Value
+	7, // 8: lynkapi.ServiceStatus.Violation.value:type_name -> google.protobuf.Value
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_lynkapi_type_proto_init() }
@@ -416,6 +603,30 @@ func file_lynkapi_type_proto_init() {
 				return nil
 			}
 		}
+		file_lynkapi_type_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FieldSpec_Ref); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_lynkapi_type_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServiceStatus_Violation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -423,7 +634,7 @@ func file_lynkapi_type_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_lynkapi_type_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -5,22 +5,140 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"regexp"
 	"slices"
+	"sort"
 	"strings"
+	"sync"
 
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
-func specDataMerge(spec *TypeSpec, dstObject, srcObject any, opts ...any) (bool, error) {
+// dataMergeValue returns the api value of a field value, nil pointers, slices
+// and maps are null.
+func dataMergeValue(v reflect.Value) *structpb.Value {
+	if !v.IsValid() {
+		return structpb.NewNullValue()
+	}
+	js, err := json.Marshal(v.Interface())
+	if err != nil {
+		return structpb.NewNullValue()
+	}
+	var value structpb.Value
+	if err := json.Unmarshal(js, &value); err != nil {
+		return structpb.NewNullValue()
+	}
+	return &value
+}
+
+var dataMergePatterns sync.Map
+
+// dataMergePattern returns the compiled x_pattern of a string field.
+func dataMergePattern(field *FieldSpec) *regexp.Regexp {
+	v, ok := field.Opts["pattern"]
+	if !ok || v.GetStringValue() == "" {
+		return nil
+	}
+	if re, ok := dataMergePatterns.Load(v.GetStringValue()); ok {
+		return re.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile(v.GetStringValue())
+	if err != nil {
+		return nil
+	}
+	dataMergePatterns.Store(v.GetStringValue(), re)
+	return re
+}
+
+// dataMergeKindType returns the field type of a value or map key kind.
+func dataMergeKindType(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Bool:
+		return FieldSpec_Bool
+	case reflect.Float32, reflect.Float64:
+		return FieldSpec_Float
+	case reflect.String:
+		return FieldSpec_String
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return FieldSpec_Int
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return FieldSpec_Uint
+	}
+	return kind.String()
+}
+
+// specDataMerge merges the non-empty values of srcObject into dstObject, and
+// returns the changes made to dstObject, with the dotted paths of the field
+// tag names (ex: `sub.name`).
+//
+// The values failing the validations (required, enums, value limits, pattern
+// and types) are skipped, and all of them are returned in a ValidationError.
+func specDataMerge(spec *TypeSpec, dstObject, srcObject any, opts ...any) ([]*DataFieldChange, error) {
 
 	var (
-		chg        = false
-		dataMerge  func(spec *FieldSpec, dstValue, srcValue reflect.Value) error
-		arrayMerge func(spec *FieldSpec, dstValue, srcValue reflect.Value) error
-		mapMerge   func(spec *FieldSpec, dstValue, srcValue reflect.Value) error
-		mergeType  DataMerge_Type
+		changes          []*DataFieldChange
+		violations       []*ServiceStatus_Violation
+		dataMerge        func(path string, spec *FieldSpec, dstValue, srcValue reflect.Value) error
+		arrayMerge       func(path string, spec *FieldSpec, dstValue, srcValue reflect.Value) error
+		arrayStructMerge func(path string, spec *FieldSpec, dstValue, srcValue reflect.Value) error
+		mapMerge         func(path string, spec *FieldSpec, dstValue, srcValue reflect.Value) error
+		mapStructMerge   func(path string, spec *FieldSpec, dstValue, srcValue reflect.Value) error
+		mergeType        DataMerge_Type
+		arrayMode        DataMerge_ArrayMode
+		mask             DataMerge_FieldMask
 	)
 
+	// maskPath returns whether the field of path is overwritten by the field
+	// mask (zero values included), and whether any of its sub fields is. All
+	// fields are merged without a mask.
+	maskPath := func(path string) (overwrite, sub bool) {
+		if mask == nil {
+			return false, true
+		}
+		for _, m := range mask {
+			if path == m || strings.HasPrefix(path, m+".") || strings.HasPrefix(path, m+"[") {
+				return true, true
+			}
+			if strings.HasPrefix(m, path+".") || strings.HasPrefix(m, path+"[") {
+				sub = true
+			}
+		}
+		return false, sub
+	}
+
+	// change records the change of a field, it must be called before the
+	// value is set.
+	change := func(path string, dstValue, srcValue reflect.Value) {
+		changes = append(changes, &DataFieldChange{
+			Path:     path,
+			OldValue: dataMergeValue(dstValue),
+			NewValue: dataMergeValue(srcValue),
+		})
+	}
+
+	// violate records a value failing the validation rule, the merge goes on
+	// to collect all the violations.
+	violate := func(path, rule string, value reflect.Value, format string, args ...any) {
+		violations = append(violations, &ServiceStatus_Violation{
+			Path:    path,
+			Rule:    rule,
+			Value:   dataMergeValue(value),
+			Message: fmt.Sprintf("field (%s) ", path) + fmt.Sprintf(format, args...),
+		})
+	}
+
+	sortKeys := func(keys []reflect.Value) {
+		sort.Slice(keys, func(i, j int) bool {
+			switch keys[i].Kind() {
+			case reflect.String:
+				return keys[i].String() < keys[j].String()
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				return keys[i].Int() < keys[j].Int()
+			}
+			return keys[i].Uint() < keys[j].Uint()
+		})
+	}
+
 	for _, opt := range opts {
 		if opt == nil {
 			continue
@@ -28,16 +146,32 @@ func specDataMerge(spec *TypeSpec, dstObject, srcObject any, opts ...any) (bool,
 		switch opt.(type) {
 		case DataMerge_Type:
 			mergeType = opt.(DataMerge_Type)
+		case DataMerge_ArrayMode:
+			arrayMode = opt.(DataMerge_ArrayMode)
+		case DataMerge_FieldMask:
+			if ls := opt.(DataMerge_FieldMask); len(ls) > 0 {
+				mask = ls
+			}
 		}
 	}
 
-	arrayMerge = func(fieldSpec *FieldSpec, dstValue, srcValue reflect.Value) error {
+	arrayMerge = func(path string, fieldSpec *FieldSpec, dstValue, srcValue reflect.Value) error {
 
 		if !dstValue.IsValid() {
 			return nil
 		}
 
-		if !srcValue.IsValid() || srcValue.Kind() != reflect.Slice || srcValue.Len() == 0 {
+		if !srcValue.IsValid() || srcValue.Kind() != reflect.Slice {
+			return nil
+		}
+
+		overwrite, _ := maskPath(path)
+
+		if srcValue.Len() == 0 {
+			if overwrite && dstValue.Len() > 0 {
+				change(path, dstValue, reflect.Value{})
+				dstValue.Set(reflect.Zero(dstValue.Type()))
+			}
 			return nil
 		}
 
@@ -45,46 +179,287 @@ func specDataMerge(spec *TypeSpec, dstObject, srcObject any, opts ...any) (bool,
 			subType = fieldSpec.Type[len("array:"):]
 		)
 
+		if subType == FieldSpec_Struct {
+			return arrayStructMerge(path, fieldSpec, dstValue, srcValue)
+		}
+
+		if mask != nil && !overwrite {
+			return nil
+		}
+
+		n := len(violations)
 		for i := 0; i < srcValue.Len(); i++ {
+			if elemType := dataMergeKindType(srcValue.Index(i).Kind()); elemType != subType {
+				violate(fmt.Sprintf("%s[%d]", path, i), ValidationRule_Type, srcValue.Index(i),
+					"invalid type (array:%s:%s)", subType, elemType)
+			}
+		}
+		if len(violations) > n {
+			return nil
+		}
 
-			switch srcValue.Index(i).Kind() {
-			case reflect.Bool:
-				if subType != FieldSpec_Bool {
-					return fmt.Errorf("invalid array:bool type")
-				}
+		if !reflect.DeepEqual(dstValue.Interface(), srcValue.Interface()) {
+			change(path, dstValue, srcValue)
+			dstValue.Set(srcValue)
+		}
 
-			case reflect.String:
-				if subType != FieldSpec_String {
-					return fmt.Errorf("invalid array:string type")
-				}
+		return nil
+	}
 
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				if subType != FieldSpec_Int {
-					return fmt.Errorf("invalid array:int type")
+	// structElem returns a pointer to a copy of the struct element v (T or *T)
+	// of an array or map, or to a new element if v is not valid.
+	structElem := func(structType reflect.Type, v reflect.Value) reflect.Value {
+		ptr := reflect.New(structType)
+		if v.IsValid() && v.Kind() == reflect.Pointer {
+			v = v.Elem()
+		}
+		if v.IsValid() && v.Kind() == reflect.Struct {
+			ptr.Elem().Set(v)
+		}
+		return ptr
+	}
+
+	// structElemValue returns the element of type elemType (T or *T) of ptr.
+	structElemValue := func(elemType reflect.Type, ptr reflect.Value) reflect.Value {
+		if elemType.Kind() == reflect.Pointer {
+			return ptr
+		}
+		return ptr.Elem()
+	}
+
+	elemNil := func(v reflect.Value) bool {
+		return !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil())
+	}
+
+	// arrayStructMerge merges the struct elements with the nested field specs,
+	// replaces the whole array, or merges the elements by primary key (as
+	// `items[id=a].name` in the changes) by the array mode.
+	arrayStructMerge = func(path string, fieldSpec *FieldSpec, dstValue, srcValue reflect.Value) error {
+
+		var (
+			elemType   = dstValue.Type().Elem()
+			structType = elemType
+			n          = len(changes)
+		)
+		if structType.Kind() == reflect.Pointer {
+			structType = structType.Elem()
+		}
+		if structType.Kind() != reflect.Struct {
+			return fmt.Errorf("invalid array:struct type")
+		}
+
+		// a masked array is replaced, the elements of an array with masked sub
+		// fields are merged
+		mode := arrayMode
+		if overwrite, _ := maskPath(path); overwrite {
+			mode = DataMerge_ArrayReplace
+		} else if mask != nil {
+			mode = DataMerge_ArrayMerge
+		}
+
+		ls := reflect.MakeSlice(dstValue.Type(), 0, dstValue.Len()+srcValue.Len())
+
+		if mode == DataMerge_ArrayReplace {
+			for i := 0; i < srcValue.Len(); i++ {
+				if elemNil(srcValue.Index(i)) {
+					continue
 				}
+				ptr := structElem(structType, reflect.Value{})
+				if err := dataMerge(fmt.Sprintf("%s[%d]", path, i), fieldSpec, ptr, srcValue.Index(i)); err != nil {
+					return err
+				}
+				ls = reflect.Append(ls, structElemValue(elemType, ptr))
+			}
+			changes = changes[:n]
+			if !reflect.DeepEqual(dstValue.Interface(), ls.Interface()) {
+				change(path, dstValue, ls)
+				dstValue.Set(ls)
+			}
+			return nil
+		}
 
-			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				if subType != FieldSpec_Uint {
-					return fmt.Errorf("invalid array:uint type")
+		pks, pkm, _ := fieldSpec.PrimaryKeys()
+		if len(pks) == 0 {
+			return fmt.Errorf("field (%s) primary key of the elements not found", fieldSpec.Name)
+		}
+		pkField := pkm[pks[0]]
+
+		elemKey := func(v reflect.Value) string {
+			if v.Kind() == reflect.Pointer {
+				if v.IsNil() {
+					return ""
 				}
+				v = v.Elem()
+			}
+			if v.Kind() != reflect.Struct {
+				return ""
+			}
+			fv := v.FieldByName(pkField.Name)
+			if !fv.IsValid() || fv.IsZero() {
+				return ""
+			}
+			return fmt.Sprint(fv.Interface())
+		}
+		elemPath := func(key string) string {
+			return fmt.Sprintf("%s[%s=%s]", path, pkField.TagName, key)
+		}
 
-			case reflect.Float32, reflect.Float64:
-				if subType != FieldSpec_Float {
-					return fmt.Errorf("invalid array:float type")
+		var (
+			srcKeys = map[string]int{}
+			nv      = len(violations)
+		)
+		for i := 0; i < srcValue.Len(); i++ {
+			if elemNil(srcValue.Index(i)) {
+				continue
+			}
+			var (
+				key     = elemKey(srcValue.Index(i))
+				keyPath = fmt.Sprintf("%s[%d].%s", path, i, pkField.TagName)
+			)
+			if key == "" {
+				violate(keyPath, ValidationRule_Required, reflect.Value{}, "primary key of the element not set")
+				continue
+			}
+			if _, ok := srcKeys[key]; ok {
+				violate(keyPath, ValidationRule_Unique, reflect.ValueOf(key), "duplicate primary key (%s) of the elements", key)
+				continue
+			}
+			srcKeys[key] = i
+		}
+		if len(violations) > nv {
+			return nil
+		}
+
+		merged := map[string]bool{}
+		for i := 0; i < dstValue.Len(); i++ {
+			var (
+				dv     = dstValue.Index(i)
+				key    = elemKey(dv)
+				j, hit = srcKeys[key]
+			)
+			if key == "" || !hit {
+				if mode == DataMerge_ArraySync {
+					change(elemPath(key), dv, reflect.Value{})
+				} else {
+					ls = reflect.Append(ls, dv)
 				}
+				continue
+			}
+			merged[key] = true
+			ptr := structElem(structType, dv)
+			if err := dataMerge(elemPath(key), fieldSpec, ptr, srcValue.Index(j)); err != nil {
+				return err
+			}
+			ls = reflect.Append(ls, structElemValue(elemType, ptr))
+		}
 
-			default:
-				return fmt.Errorf("invalid array: type")
+		for i := 0; i < srcValue.Len(); i++ {
+			sv := srcValue.Index(i)
+			if elemNil(sv) || merged[elemKey(sv)] {
+				continue
+			}
+			if overwrite, _ := maskPath(elemPath(elemKey(sv))); mask != nil && !overwrite {
+				continue
+			}
+			var (
+				key = elemKey(sv)
+				ptr = structElem(structType, reflect.Value{})
+				m   = len(changes)
+			)
+			if err := dataMerge(elemPath(key), fieldSpec, ptr, sv); err != nil {
+				return err
+			}
+			changes = changes[:m]
+			change(elemPath(key), reflect.Value{}, ptr)
+			ls = reflect.Append(ls, structElemValue(elemType, ptr))
+		}
+
+		if len(changes) > n {
+			dstValue.Set(ls)
+		}
+		return nil
+	}
+
+	// mapStructMerge merges the struct values by key (as `nodes.1.name` in the
+	// changes) with the nested field specs, the keys missing in the source are
+	// kept.
+	mapStructMerge = func(path string, fieldSpec *FieldSpec, dstValue, srcValue reflect.Value) error {
+
+		var (
+			elemType   = dstValue.Type().Elem()
+			structType = elemType
+			keyType    = dstValue.Type().Key()
+			n          = len(changes)
+		)
+		if structType.Kind() == reflect.Pointer {
+			structType = structType.Elem()
+		}
+		if structType.Kind() != reflect.Struct {
+			return fmt.Errorf("invalid map:struct type")
+		}
+
+		// a masked map is replaced, with the values of the source only
+		overwrite, _ := maskPath(path)
+
+		ls := reflect.MakeMapWithSize(dstValue.Type(), dstValue.Len()+srcValue.Len())
+		if !overwrite {
+			for iter := dstValue.MapRange(); iter.Next(); {
+				ls.SetMapIndex(iter.Key(), iter.Value())
 			}
 		}
 
-		chg = true
-		dstValue.Set(srcValue)
+		keys := srcValue.MapKeys()
+		sortKeys(keys)
 
+		for _, key := range keys {
+
+			sv := srcValue.MapIndex(key)
+			if elemNil(sv) {
+				continue
+			}
+
+			var (
+				dkey    = key.Convert(keyType)
+				dv      = dstValue.MapIndex(dkey)
+				keyPath = fmt.Sprintf("%s.%v", path, key.Interface())
+			)
+
+			if overwrite {
+				dv = reflect.Value{}
+			} else if ow, sub := maskPath(keyPath); mask != nil && !ow && (!sub || elemNil(dv)) {
+				continue
+			}
+
+			ptr := structElem(structType, dv)
+
+			if !elemNil(dv) {
+				if err := dataMerge(keyPath, fieldSpec, ptr, sv); err != nil {
+					return err
+				}
+			} else {
+				m := len(changes)
+				if err := dataMerge(keyPath, fieldSpec, ptr, sv); err != nil {
+					return err
+				}
+				changes = changes[:m]
+				change(keyPath, reflect.Value{}, ptr)
+			}
+			ls.SetMapIndex(dkey, structElemValue(elemType, ptr))
+		}
+
+		if overwrite {
+			changes = changes[:n]
+			if !reflect.DeepEqual(dstValue.Interface(), ls.Interface()) {
+				change(path, dstValue, ls)
+				dstValue.Set(ls)
+			}
+		} else if len(changes) > n {
+			dstValue.Set(ls)
+		}
 		return nil
 	}
 
-	mapMerge = func(fieldSpec *FieldSpec, dstValue, srcValue reflect.Value) error {
+	mapMerge = func(path string, fieldSpec *FieldSpec, dstValue, srcValue reflect.Value) error {
 
 		if !dstValue.IsValid() {
 			return nil
@@ -94,63 +469,67 @@ func specDataMerge(spec *TypeSpec, dstObject, srcObject any, opts ...any) (bool,
 			return nil
 		}
 
-		subType := strings.Split(fieldSpec.Type, ":")
-		if len(subType) != 2 || subType[0] != FieldSpec_String {
+		overwrite, _ := maskPath(path)
+
+		if srcValue.Len() == 0 {
+			if overwrite && dstValue.Len() > 0 {
+				change(path, dstValue, reflect.Value{})
+				dstValue.Set(reflect.Zero(dstValue.Type()))
+			}
 			return nil
 		}
 
-		for iter := srcValue.MapRange(); iter != nil && iter.Next(); {
-
-			key := iter.Key()
-			val := iter.Value()
+		subType := strings.Split(fieldSpec.Type, ":")
+		if len(subType) != 2 {
+			return nil
+		}
 
-			if key.Kind() != reflect.String {
-				continue
-			}
+		if keyType := dataMergeKindType(srcValue.Type().Key().Kind()); keyType != subType[0] ||
+			dataMergeKindType(dstValue.Type().Key().Kind()) != subType[0] {
+			violate(path, ValidationRule_Type, reflect.Value{}, "invalid map key type (%s:%s)", subType[0], keyType)
+			return nil
+		}
 
-			if val.Kind() == reflect.Pointer {
-				val = val.Elem()
-			}
+		if subType[1] == FieldSpec_Struct {
+			return mapStructMerge(path, fieldSpec, dstValue, srcValue)
+		}
 
-			switch val.Kind() {
-			case reflect.Bool:
-				if subType[1] != FieldSpec_Bool {
-					return fmt.Errorf("invalid map:bool type")
-				}
+		if mask != nil && !overwrite {
+			return nil
+		}
 
-			case reflect.String:
-				if subType[1] != FieldSpec_String {
-					return fmt.Errorf("invalid map:string type")
-				}
+		var (
+			keys = srcValue.MapKeys()
+			n    = len(violations)
+		)
+		sortKeys(keys)
 
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				if subType[1] != FieldSpec_Int {
-					return fmt.Errorf("invalid map:int type")
-				}
+		for _, key := range keys {
 
-			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				if subType[1] != FieldSpec_Uint {
-					return fmt.Errorf("invalid map:uint type")
-				}
+			val := srcValue.MapIndex(key)
 
-			case reflect.Float32, reflect.Float64:
-				if subType[1] != FieldSpec_Float {
-					return fmt.Errorf("invalid map:float type")
-				}
+			if val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+				val = val.Elem()
+			}
 
-			case reflect.Struct:
-				if subType[1] != fieldSpec_Any &&
-					subType[1] != FieldSpec_Struct {
-					return fmt.Errorf("invalid map:any|struct type")
-				}
+			if val.Kind() == reflect.Struct &&
+				(subType[1] == fieldSpec_Any || subType[1] == FieldSpec_Struct) {
+				continue
+			}
 
-			default:
-				return fmt.Errorf("invalid map: type %v", val.Kind())
+			if valType := dataMergeKindType(val.Kind()); valType != subType[1] {
+				violate(fmt.Sprintf("%s.%v", path, key.Interface()), ValidationRule_Type, val,
+					"invalid type (map:%s:%s)", subType[1], valType)
 			}
 		}
+		if len(violations) > n {
+			return nil
+		}
 
-		chg = true
-		dstValue.Set(srcValue)
+		if !reflect.DeepEqual(dstValue.Interface(), srcValue.Interface()) {
+			change(path, dstValue, srcValue)
+			dstValue.Set(srcValue)
+		}
 
 		return nil
 	}
@@ -242,28 +621,40 @@ func specDataMerge(spec *TypeSpec, dstObject, srcObject any, opts ...any) (bool,
 		return ""
 	}
 
-	requiredCheck := func(fieldSpec *FieldSpec) error {
-
-		if mergeType != DataMerge_Create &&
-			mergeType != DataMerge_Update {
-			return nil
-		}
+	// requiredCheck validates an empty value of the field, and returns false
+	// with the violation recorded.
+	requiredCheck := func(path string, fieldSpec *FieldSpec, value reflect.Value) bool {
 
 		switch mergeType {
 		case DataMerge_Create:
 			if fieldSpec.HasAttr("create_required") {
-				return fmt.Errorf("field %s update_required", fieldSpec.Name)
+				violate(path, ValidationRule_Required, value, "create_required")
+				return false
 			}
 
 		case DataMerge_Update:
 			if fieldSpec.HasAttr("update_required") {
-				return fmt.Errorf("field %s update_required", fieldSpec.Name)
+				violate(path, ValidationRule_Required, value, "update_required")
+				return false
 			}
 		}
-		return nil
+		return true
+	}
+
+	// zeroCheck validates a zero value overwriting a field by the field mask.
+	zeroCheck := func(path string, fieldSpec *FieldSpec, value reflect.Value) bool {
+		if v, ok := fieldSpec.Opts["min_value"]; ok && v.GetNumberValue() > 0 {
+			violate(path, ValidationRule_Min, value, "deny value limits (zero)")
+			return false
+		}
+		if v, ok := fieldSpec.Opts["max_value"]; ok && v.GetNumberValue() < 0 {
+			violate(path, ValidationRule_Max, value, "deny value limits (zero)")
+			return false
+		}
+		return requiredCheck(path, fieldSpec, value)
 	}
 
-	dataMerge = func(spec *FieldSpec, dstValue, srcValue reflect.Value) error {
+	dataMerge = func(path string, spec *FieldSpec, dstValue, srcValue reflect.Value) error {
 
 		if spec == nil || len(spec.Fields) == 0 ||
 			!dstValue.IsValid() ||
@@ -288,17 +679,23 @@ func specDataMerge(spec *TypeSpec, dstObject, srcObject any, opts ...any) (bool,
 		for _, fieldSpec := range spec.Fields {
 
 			var (
-				value = srcValue.FieldByName(fieldSpec.Name)
+				value     = srcValue.FieldByName(fieldSpec.Name)
+				fieldPath = fieldSpec.TagName
 			)
 
+			if fieldPath == "" {
+				fieldPath = fieldSpec.Name
+			}
+			if path != "" {
+				fieldPath = path + "." + fieldPath
+			}
+
 			if !value.IsValid() {
 				value = srcValue.FieldByName(fieldSpec.TagName)
 			}
 
 			if !value.IsValid() {
-				if err := requiredCheck(fieldSpec); err != nil {
-					return err
-				}
+				requiredCheck(fieldPath, fieldSpec, value)
 				continue
 			}
 
@@ -307,22 +704,36 @@ func specDataMerge(spec *TypeSpec, dstObject, srcObject any, opts ...any) (bool,
 				continue
 			}
 
+			overwrite, sub := maskPath(fieldPath)
+			switch value.Kind() {
+			case reflect.Slice, reflect.Map, reflect.Pointer, reflect.Struct:
+				if !overwrite && !sub {
+					continue
+				}
+			default:
+				if mask != nil && !overwrite {
+					continue
+				}
+			}
+
 			switch value.Kind() {
 			case reflect.Bool:
 				if fieldSpec.Type != FieldSpec_Bool {
-					return fmt.Errorf("invalid field (%s) type (bool:%s)", fieldSpec.Name, fieldSpec.Type)
+					violate(fieldPath, ValidationRule_Type, value, "invalid type (bool:%s)", fieldSpec.Type)
+					continue
 				}
 				if dstField.Kind() != reflect.Bool {
 					return fmt.Errorf("invalid field (%s) type (string:%v)", fieldSpec.Name, dstField.Kind())
 				}
 				if value.Bool() != dstField.Bool() {
-					chg = true
+					change(fieldPath, dstField, value)
 					dstField.SetBool(value.Bool())
 				}
 
 			case reflect.String:
 				if fieldSpec.Type != FieldSpec_String {
-					return fmt.Errorf("invalid field (%s) type (string:%s)", fieldSpec.Name, fieldSpec.Type)
+					violate(fieldPath, ValidationRule_Type, value, "invalid type (string:%s)", fieldSpec.Type)
+					continue
 				}
 				if dstField.Kind() != reflect.String {
 					return fmt.Errorf("invalid field (%s) type (string:%v)", fieldSpec.Name, dstField.Kind())
@@ -330,126 +741,187 @@ func specDataMerge(spec *TypeSpec, dstObject, srcObject any, opts ...any) (bool,
 				defValue := fieldValueLimitsString(fieldSpec)
 				if value.String() != "" {
 					if len(fieldSpec.Enums) > 0 && !slices.Contains(fieldSpec.Enums, value.String()) {
-						return fmt.Errorf("field (%s), deny by enums", fieldSpec.Name)
+						violate(fieldPath, ValidationRule_Enum, value, "deny by enums")
+						continue
+					}
+					if re := dataMergePattern(fieldSpec); re != nil && !re.MatchString(value.String()) {
+						violate(fieldPath, ValidationRule_Pattern, value, "deny by pattern (%s)", re.String())
+						continue
 					}
 					if dstField.String() != value.String() {
-						chg = true
+						change(fieldPath, dstField, value)
 						dstField.SetString(value.String())
 					}
+				} else if overwrite {
+					if !requiredCheck(fieldPath, fieldSpec, value) {
+						continue
+					}
+					if dstField.String() != "" {
+						change(fieldPath, dstField, value)
+						dstField.SetString("")
+					}
 				} else if dstField.String() == "" && defValue != "" {
-					chg = true
+					change(fieldPath, dstField, reflect.ValueOf(defValue))
 					dstField.SetString(defValue)
 				} else {
-					if err := requiredCheck(fieldSpec); err != nil {
-						return err
-					}
+					requiredCheck(fieldPath, fieldSpec, value)
 				}
 
 			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 				if fieldSpec.Type != FieldSpec_Int {
-					return fmt.Errorf("invalid field (%s) type (int:%v)", fieldSpec.Name, dstField.Kind())
+					violate(fieldPath, ValidationRule_Type, value, "invalid type (int:%s)", fieldSpec.Type)
+					continue
 				}
 				minValue, defValue, maxValue := fieldValueLimitsInt(fieldSpec)
 				if value.Int() != 0 {
-					if value.Int() < minValue || value.Int() > maxValue {
-						return fmt.Errorf("field (%s) deny value limits [%d ~ %d]", fieldSpec.Name, minValue, maxValue)
+					if value.Int() < minValue {
+						violate(fieldPath, ValidationRule_Min, value, "deny value limits [%d ~ %d]", minValue, maxValue)
+						continue
+					} else if value.Int() > maxValue {
+						violate(fieldPath, ValidationRule_Max, value, "deny value limits [%d ~ %d]", minValue, maxValue)
+						continue
 					}
 					if dstField.Int() != value.Int() {
-						chg = true
+						change(fieldPath, dstField, value)
 						dstField.SetInt(value.Int())
 					}
+				} else if overwrite {
+					if !zeroCheck(fieldPath, fieldSpec, value) {
+						continue
+					}
+					if dstField.Int() != 0 {
+						change(fieldPath, dstField, value)
+						dstField.SetInt(0)
+					}
 				} else if dstField.Int() == 0 && defValue != 0 && defValue >= minValue && defValue <= maxValue {
-					chg = true
+					change(fieldPath, dstField, reflect.ValueOf(defValue))
 					dstField.SetInt(defValue)
 				} else {
-					if err := requiredCheck(fieldSpec); err != nil {
-						return err
-					}
+					requiredCheck(fieldPath, fieldSpec, value)
 				}
 
 			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 				if fieldSpec.Type != FieldSpec_Uint {
-					return fmt.Errorf("invalid field (%s) type (uint:%v)", fieldSpec.Name, dstField.Kind())
+					violate(fieldPath, ValidationRule_Type, value, "invalid type (uint:%s)", fieldSpec.Type)
+					continue
 				}
 				minValue, defValue, maxValue := fieldValueLimitsUint(fieldSpec)
 				if value.Uint() != 0 {
-					if value.Uint() < minValue || value.Uint() > maxValue {
-						return fmt.Errorf("field (%s) deny value limits [%d ~ %d]", fieldSpec.Name, minValue, maxValue)
+					if value.Uint() < minValue {
+						violate(fieldPath, ValidationRule_Min, value, "deny value limits [%d ~ %d]", minValue, maxValue)
+						continue
+					} else if value.Uint() > maxValue {
+						violate(fieldPath, ValidationRule_Max, value, "deny value limits [%d ~ %d]", minValue, maxValue)
+						continue
 					}
 					if dstField.Uint() != value.Uint() {
-						chg = true
+						change(fieldPath, dstField, value)
 						dstField.SetUint(value.Uint())
 					}
+				} else if overwrite {
+					if !zeroCheck(fieldPath, fieldSpec, value) {
+						continue
+					}
+					if dstField.Uint() != 0 {
+						change(fieldPath, dstField, value)
+						dstField.SetUint(0)
+					}
 				} else if dstField.Uint() == 0 && defValue != 0 && defValue >= minValue && defValue <= maxValue {
-					chg = true
+					change(fieldPath, dstField, reflect.ValueOf(defValue))
 					dstField.SetUint(defValue)
 				} else {
-					if err := requiredCheck(fieldSpec); err != nil {
-						return err
-					}
+					requiredCheck(fieldPath, fieldSpec, value)
 				}
 
 			case reflect.Float32, reflect.Float64:
 				if fieldSpec.Type != FieldSpec_Float {
-					return fmt.Errorf("invalid field (%s) type (float:%v)", fieldSpec.Name, dstField.Kind())
+					violate(fieldPath, ValidationRule_Type, value, "invalid type (float:%s)", fieldSpec.Type)
+					continue
 				}
 				minValue, defValue, maxValue := fieldValueLimitsFloat(fieldSpec)
 				if value.Float() != 0 {
-					if value.Float() < minValue || value.Float() > maxValue {
-						return fmt.Errorf("field (%s) deny value limits [%f ~ %f]", fieldSpec.Name, minValue, maxValue)
+					if value.Float() < minValue {
+						violate(fieldPath, ValidationRule_Min, value, "deny value limits [%f ~ %f]", minValue, maxValue)
+						continue
+					} else if value.Float() > maxValue {
+						violate(fieldPath, ValidationRule_Max, value, "deny value limits [%f ~ %f]", minValue, maxValue)
+						continue
 					}
 					if dstField.Float() != value.Float() {
-						chg = true
+						change(fieldPath, dstField, value)
 						dstField.SetFloat(value.Float())
 					}
+				} else if overwrite {
+					if !zeroCheck(fieldPath, fieldSpec, value) {
+						continue
+					}
+					if dstField.Float() != 0 {
+						change(fieldPath, dstField, value)
+						dstField.SetFloat(0)
+					}
 				} else if dstField.Float() == 0 && defValue != 0 && defValue >= minValue && defValue <= maxValue {
-					chg = true
+					change(fieldPath, dstField, reflect.ValueOf(defValue))
 					dstField.SetFloat(defValue)
 				} else {
-					if err := requiredCheck(fieldSpec); err != nil {
-						return err
-					}
+					requiredCheck(fieldPath, fieldSpec, value)
 				}
 
 			case reflect.Slice:
 				if !strings.HasPrefix(fieldSpec.Type, "array:") {
-					return fmt.Errorf("invalid field (%s) type (array:%s)", fieldSpec.Name, fieldSpec.Type)
+					violate(fieldPath, ValidationRule_Type, value, "invalid type (array:%s)", fieldSpec.Type)
+					continue
 				}
 				if dstField.Kind() != reflect.Slice {
 					return fmt.Errorf("invalid field (%s) type (array:%s)", fieldSpec.Name, fieldSpec.Type)
 				}
-				if err := arrayMerge(fieldSpec, dstField, value); err != nil {
+				if err := arrayMerge(fieldPath, fieldSpec, dstField, value); err != nil {
 					return err
 				}
 
 			case reflect.Map:
 				if strings.Count(fieldSpec.Type, ":") != 1 {
-					return fmt.Errorf("invalid field (%s) type (map:%s)", fieldSpec.Name, fieldSpec.Type)
+					violate(fieldPath, ValidationRule_Type, value, "invalid type (map:%s)", fieldSpec.Type)
+					continue
 				}
 				if dstField.Kind() != reflect.Map {
 					return fmt.Errorf("invalid field (%s) type (map:%s)", fieldSpec.Name, fieldSpec.Type)
 				}
-				if err := mapMerge(fieldSpec, dstField, value); err != nil {
+				if err := mapMerge(fieldPath, fieldSpec, dstField, value); err != nil {
 					return err
 				}
 
 			case reflect.Pointer, reflect.Struct:
 				if fieldSpec.Type != FieldSpec_Struct {
-					return fmt.Errorf("invalid field (%s) type (struct:%s)", fieldSpec.Name, fieldSpec.Type)
+					violate(fieldPath, ValidationRule_Type, value, "invalid type (struct:%s)", fieldSpec.Type)
+					continue
 				}
 				if dstField.Kind() != reflect.Pointer && dstField.Kind() != reflect.Struct {
 					return fmt.Errorf("invalid field (%s) type (struct:%s)", fieldSpec.Name, fieldSpec.Type)
 				}
+				if mask != nil && value.Kind() == reflect.Pointer && value.IsNil() {
+					if dstField.Kind() == reflect.Pointer && dstField.IsNil() {
+						continue
+					}
+					if overwrite {
+						if !dstField.IsZero() {
+							change(fieldPath, dstField, reflect.Value{})
+							dstField.Set(reflect.Zero(dstField.Type()))
+						}
+						continue
+					}
+					// the masked sub fields are cleared
+					value = reflect.New(value.Type().Elem())
+				}
 				if dstField.Kind() == reflect.Pointer {
 					if dstField.IsNil() {
 						dstField.Set(reflect.New(dstField.Type().Elem()))
 					}
 
-					if err := dataMerge(fieldSpec, dstField, value); err != nil {
+					if err := dataMerge(fieldPath, fieldSpec, dstField, value); err != nil {
 						return err
 					}
 				} else if dstField.Kind() == reflect.Struct {
-					if err := dataMerge(fieldSpec, dstField, value); err != nil {
+					if err := dataMerge(fieldPath, fieldSpec, dstField, value); err != nil {
 						return err
 					}
 				}
@@ -459,11 +931,16 @@ func specDataMerge(spec *TypeSpec, dstObject, srcObject any, opts ...any) (bool,
 		return nil
 	}
 
-	err := dataMerge(&FieldSpec{
+	err := dataMerge("", &FieldSpec{
 		Fields: spec.Fields,
 	}, reflect.ValueOf(dstObject), reflect.ValueOf(srcObject))
+	if err == nil && len(violations) > 0 {
+		err = &ValidationError{
+			Violations: violations,
+		}
+	}
 
-	return chg, err
+	return changes, err
 }
 
 func NewRequest(serviceName, methodName string, obj any) *Request {
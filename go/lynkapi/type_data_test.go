@@ -16,9 +16,13 @@ package lynkapi_test
 
 import (
 	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/lynkdb/lynkapi/go/lynkapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	// "google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -65,12 +69,375 @@ func Test_DataMerge(t *testing.T) {
 		},
 	}
 
-	if _, err := spec.DataMerge(base, update); err != nil {
+	changes, err := spec.DataMerge(base, update)
+	if err != nil {
 		t.Fatal(err)
 	}
 
 	js, _ = json.MarshalIndent(base, "", "  ")
 	t.Logf("map %v", string(js))
+
+	var paths []string
+	for _, ch := range changes {
+		paths = append(paths, ch.Path)
+	}
+	if strings.Join(paths, ",") != "name,int,obj1.name,obj2.name,array,array_float,map" {
+		t.Fatalf("invalid changes %v", paths)
+	}
+	if ch := changes[4]; len(ch.OldValue.GetListValue().GetValues()) != 1 ||
+		len(ch.NewValue.GetListValue().GetValues()) != 2 {
+		t.Fatalf("invalid array change %v", ch)
+	}
+
+	if changes, err = spec.DataMerge(base, update); err != nil || len(changes) != 0 {
+		t.Fatalf("invalid changes of an unchanged merge %v %v", changes, err)
+	}
+
+	changes, err = spec.DataMerge(base, &Obj{
+		Obj2: &Obj1{
+			Name: "test3",
+		},
+	})
+	if err != nil || len(changes) != 1 || changes[0].Path != "obj2.name" ||
+		changes[0].OldValue.GetStringValue() != "test2" ||
+		changes[0].NewValue.GetStringValue() != "test3" {
+		t.Fatalf("invalid nested change %v %v", changes, err)
+	}
+}
+
+func Test_DataMerge_ArrayStruct(t *testing.T) {
+
+	type Item struct {
+		Id     string `json:"id" x_attrs:"primary_key"`
+		Name   string `json:"name"`
+		Status string `json:"status" x_enums:"todo,done"`
+		Qty    int64  `json:"qty" x_value_limits:"1,1,10"`
+	}
+
+	type Obj struct {
+		Items []*Item `json:"items"`
+	}
+
+	spec, _, err := lynkapi.NewSpecFromStruct(Obj{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newBase := func() *Obj {
+		return &Obj{
+			Items: []*Item{
+				{Id: "a", Name: "apple", Status: "todo", Qty: 1},
+				{Id: "b", Name: "banana", Status: "todo", Qty: 2},
+			},
+		}
+	}
+	update := &Obj{
+		Items: []*Item{
+			{Id: "b", Status: "done"},
+			{Id: "c", Name: "cherry"},
+		},
+	}
+
+	paths := func(changes []*lynkapi.DataFieldChange) string {
+		var ls []string
+		for _, ch := range changes {
+			ls = append(ls, ch.Path)
+		}
+		return strings.Join(ls, ",")
+	}
+
+	// replace
+	base := newBase()
+	changes, err := spec.DataMerge(base, update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paths(changes) != "items" || len(base.Items) != 2 ||
+		base.Items[0].Id != "b" || base.Items[0].Name != "" || base.Items[1].Qty != 1 {
+		t.Fatalf("invalid replace merge %v", paths(changes))
+	}
+
+	// merge
+	base = newBase()
+	changes, err = spec.DataMerge(base, update, lynkapi.DataMerge_ArrayMerge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paths(changes) != "items[id=b].status,items[id=c]" {
+		t.Fatalf("invalid merge changes %v", paths(changes))
+	}
+	if len(base.Items) != 3 || base.Items[0].Name != "apple" ||
+		base.Items[1].Name != "banana" || base.Items[1].Status != "done" || base.Items[1].Qty != 2 ||
+		base.Items[2].Id != "c" || base.Items[2].Qty != 1 {
+		t.Fatalf("invalid merge items %v", base.Items)
+	}
+	if v := changes[1].NewValue.GetStructValue(); v == nil || v.Fields["name"].GetStringValue() != "cherry" {
+		t.Fatalf("invalid new element change %v", changes[1])
+	}
+
+	// sync
+	base = newBase()
+	changes, err = spec.DataMerge(base, update, lynkapi.DataMerge_ArraySync)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paths(changes) != "items[id=a],items[id=b].status,items[id=c]" ||
+		len(base.Items) != 2 || base.Items[0].Id != "b" || base.Items[1].Id != "c" {
+		t.Fatalf("invalid sync changes %v", paths(changes))
+	}
+	if changes[0].OldValue.GetStructValue() == nil || changes[0].NewValue.GetNullValue() != 0 {
+		t.Fatalf("invalid delete element change %v", changes[0])
+	}
+
+	// validation of the nested fields
+	for _, item := range []*Item{
+		{Id: "a", Status: "wip"},
+		{Id: "a", Qty: 11},
+		{Name: "no id"},
+	} {
+		base = newBase()
+		if _, err := spec.DataMerge(base, &Obj{Items: []*Item{item}}, lynkapi.DataMerge_ArrayMerge); err == nil {
+			t.Fatalf("invalid element accepted %v", item)
+		}
+		if base.Items[0].Status != "todo" || base.Items[0].Qty != 1 {
+			t.Fatalf("invalid element merged %v", base.Items[0])
+		}
+	}
+	if _, err := spec.DataMerge(newBase(), &Obj{Items: []*Item{{Id: "a"}, {Id: "a"}}},
+		lynkapi.DataMerge_ArrayMerge); err == nil {
+		t.Fatal("duplicate primary key accepted")
+	}
+}
+
+func Test_DataMerge_Map(t *testing.T) {
+
+	type Node struct {
+		Name   string `json:"name"`
+		Role   string `json:"role" x_enums:"leader,follower"`
+		Weight int64  `json:"weight" x_value_limits:"1,10,100"`
+	}
+
+	type Obj struct {
+		Nodes  map[uint32]*Node `json:"nodes"`
+		Labels map[int64]string `json:"labels"`
+	}
+
+	spec, _, err := lynkapi.NewSpecFromStruct(Obj{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if field := spec.Field("nodes"); field == nil || field.Type != "uint:struct" {
+		t.Fatalf("invalid map spec %v", field)
+	}
+
+	base := &Obj{
+		Nodes: map[uint32]*Node{
+			1: {Name: "n1", Role: "leader", Weight: 20},
+			2: {Name: "n2", Role: "follower", Weight: 20},
+		},
+	}
+
+	changes, err := spec.DataMerge(base, &Obj{
+		Nodes: map[uint32]*Node{
+			2:  {Role: "leader"},
+			10: {Name: "n10"},
+		},
+		Labels: map[int64]string{
+			7: "seven",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	for _, ch := range changes {
+		paths = append(paths, ch.Path)
+	}
+	if strings.Join(paths, ",") != "nodes.2.role,nodes.10,labels" {
+		t.Fatalf("invalid changes %v", paths)
+	}
+	if len(base.Nodes) != 3 || base.Nodes[1].Role != "leader" ||
+		base.Nodes[2].Name != "n2" || base.Nodes[2].Role != "leader" ||
+		base.Nodes[10].Name != "n10" || base.Nodes[10].Weight != 10 ||
+		base.Labels[7] != "seven" {
+		t.Fatalf("invalid merge %v %v", base.Nodes, base.Labels)
+	}
+
+	for _, node := range []*Node{
+		{Role: "observer"},
+		{Weight: 101},
+	} {
+		if _, err := spec.DataMerge(base, &Obj{
+			Nodes: map[uint32]*Node{1: node},
+		}); err == nil {
+			t.Fatalf("invalid node accepted %v", node)
+		}
+	}
+	if base.Nodes[1].Role != "leader" || base.Nodes[1].Weight != 20 {
+		t.Fatalf("invalid node merged %v", base.Nodes[1])
+	}
+}
+
+func Test_DataMerge_FieldMask(t *testing.T) {
+
+	type Sub struct {
+		Name string `json:"name"`
+		Size int64  `json:"size"`
+	}
+
+	type Obj struct {
+		Name    string            `json:"name"`
+		Count   int64             `json:"count"`
+		Level   int64             `json:"level" x_value_limits:"1,1,9"`
+		Enabled bool              `json:"enabled"`
+		Tags    []string          `json:"tags"`
+		Labels  map[string]string `json:"labels"`
+		Sub     *Sub              `json:"sub"`
+	}
+
+	spec, _, err := lynkapi.NewSpecFromStruct(Obj{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newBase := func() *Obj {
+		return &Obj{
+			Name:    "base",
+			Count:   3,
+			Level:   2,
+			Enabled: true,
+			Tags:    []string{"a"},
+			Labels:  map[string]string{"k": "v"},
+			Sub:     &Sub{Name: "sub", Size: 5},
+		}
+	}
+
+	// zero values of the masked fields, the others are kept
+	base := newBase()
+	changes, err := spec.DataMerge(base, &Obj{Name: "other"},
+		lynkapi.DataMerge_FieldMask{"count", "enabled", "tags", "labels", "sub.size"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base.Name != "base" || base.Count != 0 || base.Level != 2 || base.Enabled ||
+		base.Tags != nil || base.Labels != nil || base.Sub.Name != "sub" || base.Sub.Size != 0 {
+		t.Fatalf("invalid masked merge %v %v", base, base.Sub)
+	}
+	var paths []string
+	for _, ch := range changes {
+		paths = append(paths, ch.Path)
+	}
+	if strings.Join(paths, ",") != "count,enabled,tags,labels,sub.size" {
+		t.Fatalf("invalid changes %v", paths)
+	}
+
+	// a masked struct is replaced
+	base = newBase()
+	if _, err := spec.DataMerge(base, &Obj{Sub: &Sub{Name: "new"}},
+		lynkapi.DataMerge_FieldMask{"sub"}); err != nil {
+		t.Fatal(err)
+	}
+	if base.Sub.Name != "new" || base.Sub.Size != 0 {
+		t.Fatalf("invalid masked struct %v", base.Sub)
+	}
+	if _, err := spec.DataMerge(base, &Obj{}, lynkapi.DataMerge_FieldMask{"sub"}); err != nil || base.Sub != nil {
+		t.Fatalf("invalid masked nil struct %v %v", base.Sub, err)
+	}
+
+	// the zero value is validated
+	base = newBase()
+	if _, err := spec.DataMerge(base, &Obj{}, lynkapi.DataMerge_FieldMask{"level"}); err == nil {
+		t.Fatal("zero value out of limits accepted")
+	}
+}
+
+func Test_DataMerge_Violations(t *testing.T) {
+
+	type Item struct {
+		Id     string `json:"id" x_attrs:"primary_key"`
+		Status string `json:"status" x_enums:"todo,done"`
+		Qty    int64  `json:"qty" x_value_limits:"1,1,10"`
+	}
+
+	type Obj struct {
+		Name  string  `json:"name" x_attrs:"create_required"`
+		Code  string  `json:"code" x_pattern:"^[a-z]{3}$"`
+		Level int64   `json:"level" x_value_limits:"1,1,9"`
+		Items []*Item `json:"items"`
+	}
+
+	spec, _, err := lynkapi.NewSpecFromStruct(Obj{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// an invalid pattern is a parse error
+	type BadObj struct {
+		Code string `json:"code" x_pattern:"^[a-z"`
+	}
+	if _, _, err := lynkapi.NewSpecFromStruct(BadObj{}); err == nil ||
+		!strings.Contains(err.Error(), "x_pattern") {
+		t.Fatalf("invalid pattern accepted %v", err)
+	}
+
+	base := &Obj{}
+	_, err = spec.DataMerge(base, &Obj{
+		Code:  "ABC1",
+		Level: 12,
+		Items: []*Item{
+			{Id: "a", Status: "open", Qty: 1},
+			{Id: "b", Status: "done", Qty: 20},
+		},
+	}, lynkapi.DataMerge_Create, lynkapi.DataMerge_ArrayReplace)
+
+	var verr *lynkapi.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("invalid validation error %v", err)
+	}
+	var ls []string
+	for _, v := range verr.Violations {
+		ls = append(ls, v.Path+":"+v.Rule)
+	}
+	if strings.Join(ls, ",") != "name:required,code:pattern,level:max,items[0].status:enum,items[1].qty:max" {
+		t.Fatalf("invalid violations %v", ls)
+	}
+	if v := verr.Violations[1]; v.Value.GetStringValue() != "ABC1" {
+		t.Fatalf("invalid violation value %v", v.Value)
+	}
+	if base.Code != "" || base.Level != 0 {
+		t.Fatalf("invalid merge with violations %v", base)
+	}
+
+	// duplicate primary keys of the elements
+	_, err = spec.DataMerge(&Obj{}, &Obj{
+		Name:  "obj",
+		Items: []*Item{{Id: "a"}, {Id: "a"}},
+	}, lynkapi.DataMerge_ArrayMerge)
+	if !errors.As(err, &verr) || len(verr.Violations) != 1 ||
+		verr.Violations[0].Path != "items[1].id" || verr.Violations[0].Rule != lynkapi.ValidationRule_Unique {
+		t.Fatalf("invalid violations %v", err)
+	}
+
+	// the violations travel in the status
+	st := lynkapi.ParseError(err)
+	if st.Code != lynkapi.StatusCode_BadRequest || len(st.Violations) != 1 {
+		t.Fatalf("invalid status %v", st)
+	}
+	gst, ok := status.FromError(err)
+	if !ok || gst.Code() != codes.InvalidArgument || len(gst.Details()) != 1 {
+		t.Fatalf("invalid rpc status %v", gst)
+	}
+	if st, ok := gst.Details()[0].(*lynkapi.ServiceStatus); !ok || len(st.Violations) != 1 ||
+		st.Violations[0].Path != "items[1].id" {
+		t.Fatalf("invalid rpc status details %v", gst.Details())
+	}
+
+	// a valid merge
+	if _, err = spec.DataMerge(&Obj{}, &Obj{Name: "obj", Code: "abc", Level: 3},
+		lynkapi.DataMerge_Create); err != nil {
+		t.Fatal(err)
+	}
 }
 
 /**
@@ -0,0 +1,318 @@
+// Copyright 2024 Eryx <evorui at gmail dot com>, All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lynkcli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chzyer/readline"
+	"github.com/olekukonko/tablewriter"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/lynkdb/lynkapi/go/lynkapi"
+)
+
+func init() {
+	RegisterCommonCommand(new(cmdDataExport))
+	RegisterCommonCommand(new(cmdDataImport))
+	RegisterCommonCommand(new(cmdDataSelect))
+	RegisterCommonCommand(new(cmdDataExplain))
+}
+
+func dataTableSpec(instanceName, tableName string) (*lynkapi.TableSpec, error) {
+	if instanceName == "" || tableName == "" {
+		return nil, fmt.Errorf("-instance and -table required")
+	}
+	rs := client.DataProject(&lynkapi.DataProjectRequest{})
+	if err := rs.Status.Err(); err != nil {
+		return nil, err
+	}
+	for _, inst := range rs.Instances {
+		if inst.Name != instanceName {
+			continue
+		}
+		if spec := inst.TableSpec(tableName); spec != nil {
+			return spec, nil
+		}
+		return nil, fmt.Errorf("table (%s) not found", tableName)
+	}
+	return nil, fmt.Errorf("instance (%s) not found", instanceName)
+}
+
+func dataFormat(fg FlagSet, file string) (string, error) {
+	format := fg.Value("format").String()
+	if format == "" {
+		format = lynkapi.DataFormatFromFile(file)
+	}
+	switch format {
+	case lynkapi.DataFormat_CSV, lynkapi.DataFormat_JSONL:
+		return format, nil
+	}
+	return "", fmt.Errorf("-format (csv or jsonl) required")
+}
+
+type cmdDataExport struct{}
+
+func (cmdDataExport) Spec() BaseCommandSpec {
+	return BaseCommandSpec{
+		Path: "data-export",
+		Desc: "data-export -instance <name> -table <name> -out <file.csv|file.jsonl> [-filter field=value] [-limit n]",
+	}
+}
+
+func (cmdDataExport) Action(fg FlagSet, l *readline.Instance) (string, error) {
+
+	var (
+		instanceName = fg.Value("instance").String()
+		tableName    = fg.Value("table").String()
+		file         = fg.Value("out").String()
+	)
+
+	if _, err := dataTableSpec(instanceName, tableName); err != nil {
+		return "", err
+	}
+	if file == "" {
+		return "", fmt.Errorf("-out required")
+	}
+
+	format, err := dataFormat(fg, file)
+	if err != nil {
+		return "", err
+	}
+
+	q := &lynkapi.DataQuery{
+		InstanceName: instanceName,
+		TableName:    tableName,
+		Limit:        int32(fg.Value("limit").Int64()),
+	}
+	if v := fg.Value("filter").String(); v != "" {
+		for _, kv := range strings.Split(v, ",") {
+			if n := strings.Index(kv, "="); n > 0 {
+				q.AddFilter(kv[:n], kv[n+1:])
+			} else {
+				return "", fmt.Errorf("invalid -filter (%s), expected field=value", kv)
+			}
+		}
+	}
+
+	// the rows are streamed to a temporary file, renamed once complete
+	fp, err := os.OpenFile(file+".tmp", os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0640)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file + ".tmp")
+	defer fp.Close()
+
+	n, err := lynkapi.DataExport(lynkapi.NewClientDataTransfer(client), q, fp, format)
+	if err == nil {
+		err = fp.Close()
+	}
+	if err == nil {
+		err = os.Rename(file+".tmp", file)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%d rows exported to %s\n", n, file), nil
+}
+
+type cmdDataImport struct{}
+
+func (cmdDataImport) Spec() BaseCommandSpec {
+	return BaseCommandSpec{
+		Path: "data-import",
+		Desc: "data-import -instance <name> -table <name> -in <file.csv|file.jsonl> [-igsert] [-dry-run]",
+	}
+}
+
+func (cmdDataImport) Action(fg FlagSet, l *readline.Instance) (string, error) {
+
+	var (
+		instanceName = fg.Value("instance").String()
+		tableName    = fg.Value("table").String()
+		file         = fg.Value("in").String()
+	)
+
+	spec, err := dataTableSpec(instanceName, tableName)
+	if err != nil {
+		return "", err
+	}
+	if file == "" {
+		return "", fmt.Errorf("-in required")
+	}
+
+	format, err := dataFormat(fg, file)
+	if err != nil {
+		return "", err
+	}
+
+	fp, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer fp.Close()
+
+	report, err := lynkapi.DataImport(lynkapi.NewClientDataTransfer(client), spec, fp, &lynkapi.DataImportOptions{
+		InstanceName: instanceName,
+		TableName:    tableName,
+		Format:       format,
+		Igsert:       fg.Has("igsert"),
+		DryRun:       fg.Has("dry-run"),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for _, e := range report.Errors {
+		if e.Id != "" {
+			fmt.Fprintf(&sb, "line %d (%s): %s\n", e.Line, e.Id, e.Error)
+		} else {
+			fmt.Fprintf(&sb, "line %d: %s\n", e.Line, e.Error)
+		}
+	}
+	if fg.Has("dry-run") {
+		fmt.Fprintf(&sb, "dry-run: %d rows, %d valid, %d errors\n",
+			report.Rows, report.Valid, len(report.Errors))
+	} else {
+		fmt.Fprintf(&sb, "%d rows, %d written, %d errors\n",
+			report.Rows, report.Written, len(report.Errors))
+	}
+
+	return sb.String(), nil
+}
+
+// dataQueryText parses a query text, an error points at its column.
+func dataQueryText(text string) (*lynkapi.DataQuery, error) {
+	q, err := lynkapi.ParseDataQuery(text)
+	if err != nil {
+		if terr, ok := err.(*lynkapi.DataQueryTextError); ok {
+			return nil, fmt.Errorf("%s\n  %s\n  %s^", terr.Message, text, strings.Repeat(" ", terr.Column-1))
+		}
+		return nil, err
+	}
+	if q.InstanceName == "" {
+		return nil, fmt.Errorf("instance name required (from <instance>.<table>)")
+	}
+	return q, nil
+}
+
+type cmdDataSelect struct{}
+
+func (cmdDataSelect) Spec() BaseCommandSpec {
+	return BaseCommandSpec{
+		Path: "select",
+		Desc: "select <fields|*> from <instance>.<table> [where ...] [order by field [asc|desc]] [limit n] [offset n]",
+	}
+}
+
+func (cmdDataSelect) Action(fg FlagSet, l *readline.Instance) (string, error) {
+
+	q, err := dataQueryText(strings.Join(fg.rawArgs, " "))
+	if err != nil {
+		return "", err
+	}
+
+	rs := client.DataQuery(q)
+	if rs.Status.Code == lynkapi.StatusCode_NotFound {
+		return "no rows\n", nil
+	}
+	if err := rs.Status.Err(); err != nil {
+		return "", err
+	}
+
+	fields := q.Fields
+	if len(fields) == 0 && rs.Spec != nil {
+		for _, field := range rs.Spec.Fields {
+			fields = append(fields, field.TagName)
+		}
+	}
+
+	var (
+		tbuf  bytes.Buffer
+		table = tablewriter.NewTable(&tbuf)
+	)
+	table.Header(fields)
+
+	for _, row := range rs.Rows {
+		vals := make([]string, len(fields))
+		for i, name := range fields {
+			switch v := row.Fields[name]; v.GetKind().(type) {
+			case nil:
+			case *structpb.Value_StringValue:
+				vals[i] = v.GetStringValue()
+			default:
+				b, _ := v.MarshalJSON()
+				vals[i] = string(b)
+			}
+		}
+		table.Append(vals)
+	}
+	table.Render()
+
+	return fmt.Sprintf("%s%d rows\n", tbuf.String(), len(rs.Rows)), nil
+}
+
+type cmdDataExplain struct{}
+
+func (cmdDataExplain) Spec() BaseCommandSpec {
+	return BaseCommandSpec{
+		Path: "explain",
+		Desc: "explain select ...",
+	}
+}
+
+func (cmdDataExplain) Action(fg FlagSet, l *readline.Instance) (string, error) {
+
+	q, err := dataQueryText(strings.TrimSpace(strings.TrimPrefix(strings.Join(fg.rawArgs, " "), "explain")))
+	if err != nil {
+		return "", err
+	}
+	q.Explain = true
+
+	rs := client.DataQuery(q)
+	if rs.Status.Code != lynkapi.StatusCode_NotFound {
+		if err := rs.Status.Err(); err != nil {
+			return "", err
+		}
+	}
+	if rs.Plan == nil {
+		return "", fmt.Errorf("no plan returned")
+	}
+
+	var (
+		plan  = rs.Plan
+		tbuf  bytes.Buffer
+		table = tablewriter.NewTable(&tbuf)
+	)
+	table.Header([]string{"Stage", "Detail", "Rows", "Time"})
+	for _, st := range plan.Stages {
+		table.Append([]string{st.Name, st.Detail, fmt.Sprintf("%d", st.Rows), fmt.Sprintf("%dus", st.TimeUs)})
+	}
+	table.Render()
+
+	access := plan.Access
+	if plan.Index != "" {
+		access += " (index " + plan.Index + ")"
+	}
+
+	return fmt.Sprintf("driver %s, access %s\n%srows examined %d, returned %d, hit %d, time %dus\n",
+		plan.Driver, access, tbuf.String(), plan.RowsExamined, plan.RowsReturned,
+		rs.Stats.GetRowsHit(), plan.TimeUs), nil
+}
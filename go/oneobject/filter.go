@@ -0,0 +1,289 @@
+package oneobject
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/lynkdb/lynkapi/go/lynkapi"
+)
+
+// The filters are matched on the struct fields of the rows: the conditions
+// eq, ne, gt, gte, lt, lte, in and like (% matches any characters, _ one
+// character) in and/or groups at any depth. The values are compared by the
+// kind of the field (valueMatch and valueCompare), strings in byte order.
+
+// filterCheck rejects the filter types and ops not supported.
+func filterCheck(filter *lynkapi.DataQuery_Filter) error {
+	if filter == nil {
+		return nil
+	}
+	switch filter.Type {
+	case "", "and", "or":
+	default:
+		return lynkapi.NewBadRequestError(fmt.Sprintf("filter type (%s) not supported", filter.Type))
+	}
+	if filter.Field != "" {
+		switch filter.Op {
+		case "", lynkapi.DataQuery_Filter_Eq, lynkapi.DataQuery_Filter_Ne,
+			lynkapi.DataQuery_Filter_Gt, lynkapi.DataQuery_Filter_Gte,
+			lynkapi.DataQuery_Filter_Lt, lynkapi.DataQuery_Filter_Lte:
+		case lynkapi.DataQuery_Filter_In:
+			if filter.Value.GetListValue() == nil {
+				return lynkapi.NewBadRequestError(fmt.Sprintf("filter (%s) in: list value required", filter.Field))
+			}
+		case lynkapi.DataQuery_Filter_Like:
+			if _, ok := filter.Value.GetKind().(*structpb.Value_StringValue); !ok {
+				return lynkapi.NewBadRequestError(fmt.Sprintf("filter (%s) like: string value required", filter.Field))
+			}
+		default:
+			return lynkapi.NewBadRequestError(fmt.Sprintf("filter op (%s) not supported", filter.Op))
+		}
+	}
+	for _, fr := range filter.Inner {
+		if err := filterCheck(fr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// filterFields returns the field conditions of the filter and its nested
+// groups.
+func filterFields(filter *lynkapi.DataQuery_Filter) []*lynkapi.DataQuery_Filter {
+	if filter == nil {
+		return nil
+	}
+	var ls []*lynkapi.DataQuery_Filter
+	if filter.Field != "" {
+		ls = append(ls, filter)
+	}
+	for _, fr := range filter.Inner {
+		ls = append(ls, filterFields(fr)...)
+	}
+	return ls
+}
+
+// filterKeys returns the equality conditions every row matching the filter
+// meets: the ones of the filter and of its nested and-groups, not the ones
+// of the or-groups.
+func filterKeys(filter *lynkapi.DataQuery_Filter) []*lynkapi.DataQuery_Filter {
+	if filter == nil || filter.Type == "or" {
+		return nil
+	}
+	var ls []*lynkapi.DataQuery_Filter
+	if filter.Field != "" && (filter.Op == "" || filter.Op == lynkapi.DataQuery_Filter_Eq) {
+		ls = append(ls, filter)
+	}
+	for _, fr := range filter.Inner {
+		ls = append(ls, filterKeys(fr)...)
+	}
+	return ls
+}
+
+// filterMatch returns true if the row matches the filter, field returns the
+// value of a field of the row (invalid for a field without value).
+func filterMatch(filter *lynkapi.DataQuery_Filter, field func(tagName string) reflect.Value) bool {
+	if filter == nil {
+		return true
+	}
+	if filter.Type == "or" {
+		for _, fr := range filter.Inner {
+			if filterMatch(fr, field) {
+				return true
+			}
+		}
+		return len(filter.Inner) == 0
+	}
+	if filter.Field != "" && filter.Value != nil && !condMatch(field(filter.Field), filter) {
+		return false
+	}
+	for _, fr := range filter.Inner {
+		if !filterMatch(fr, field) {
+			return false
+		}
+	}
+	return true
+}
+
+func condMatch(fv reflect.Value, fr *lynkapi.DataQuery_Filter) bool {
+	if !fv.IsValid() {
+		return false
+	}
+	switch fr.Op {
+	case "", lynkapi.DataQuery_Filter_Eq:
+		return valueMatch(fv, fr.Value)
+
+	case lynkapi.DataQuery_Filter_Ne:
+		return !valueMatch(fv, fr.Value)
+
+	case lynkapi.DataQuery_Filter_In:
+		for _, v := range fr.Value.GetListValue().GetValues() {
+			if valueMatch(fv, v) {
+				return true
+			}
+		}
+		return false
+
+	case lynkapi.DataQuery_Filter_Like:
+		return fv.Kind() == reflect.String && likeMatch(fv.String(), fr.Value.GetStringValue())
+	}
+
+	n, ok := valueCompare(fv, fr.Value)
+	if !ok {
+		return false
+	}
+	switch fr.Op {
+	case lynkapi.DataQuery_Filter_Gt:
+		return n > 0
+	case lynkapi.DataQuery_Filter_Gte:
+		return n >= 0
+	case lynkapi.DataQuery_Filter_Lt:
+		return n < 0
+	case lynkapi.DataQuery_Filter_Lte:
+		return n <= 0
+	}
+	return false
+}
+
+// rowMatch returns true if the row matches the filter.
+func rowMatch(tbl *table, v reflect.Value, filter *lynkapi.DataQuery_Filter) bool {
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if filter == nil || !v.IsValid() || v.Kind() != reflect.Struct {
+		return true
+	}
+	return filterMatch(filter, func(tagName string) reflect.Value {
+		return tbl.rowField(v, tagName)
+	})
+}
+
+// rowField returns the struct field of the row with the tag name, an invalid
+// value if not found.
+func (it *table) rowField(v reflect.Value, tagName string) reflect.Value {
+	for _, fd := range it.field.Fields {
+		if fd.TagName == tagName || fd.TagName == lowerName(tagName) {
+			return v.FieldByName(fd.Name)
+		}
+	}
+	return reflect.Value{}
+}
+
+// fieldValue returns the field value of a row (ex: of the history) as a
+// value of the kind of the field, an invalid value if not set.
+func fieldValue(v *structpb.Value) reflect.Value {
+	switch v.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		return reflect.ValueOf(v.GetStringValue())
+	case *structpb.Value_NumberValue:
+		if n := v.GetNumberValue(); n == float64(int64(n)) {
+			return reflect.ValueOf(int64(n))
+		}
+		return reflect.ValueOf(v.GetNumberValue())
+	case *structpb.Value_BoolValue:
+		return reflect.ValueOf(v.GetBoolValue())
+	}
+	return reflect.Value{}
+}
+
+func reflectNumber(fv reflect.Value) (float64, bool) {
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(fv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(fv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return fv.Float(), true
+	}
+	return 0, false
+}
+
+// valueCompare orders the field value and the filter value, the number
+// fields by the number of the value (or of the string), the string fields
+// by the string (or the number of the field).
+func valueCompare(fv reflect.Value, v *structpb.Value) (int, bool) {
+	if f, ok := reflectNumber(fv); ok {
+		switch v.GetKind().(type) {
+		case *structpb.Value_NumberValue:
+			return cmp.Compare(f, v.GetNumberValue()), true
+		case *structpb.Value_StringValue:
+			n, err := strconv.ParseFloat(v.GetStringValue(), 64)
+			return cmp.Compare(f, n), err == nil
+		}
+		return 0, false
+	}
+	if fv.Kind() == reflect.String {
+		switch v.GetKind().(type) {
+		case *structpb.Value_StringValue:
+			return cmp.Compare(fv.String(), v.GetStringValue()), true
+		case *structpb.Value_NumberValue:
+			f, err := strconv.ParseFloat(fv.String(), 64)
+			return cmp.Compare(f, v.GetNumberValue()), err == nil
+		}
+	}
+	return 0, false
+}
+
+// reflectCompare orders two field values for a sort, the values not set
+// first.
+func reflectCompare(a, b reflect.Value) int {
+	switch {
+	case !a.IsValid() || !b.IsValid():
+		if a.IsValid() {
+			return 1
+		} else if b.IsValid() {
+			return -1
+		}
+		return 0
+	}
+	if af, ok := reflectNumber(a); ok {
+		if bf, ok := reflectNumber(b); ok {
+			return cmp.Compare(af, bf)
+		}
+	}
+	switch {
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case a.Kind() == reflect.Bool && b.Kind() == reflect.Bool:
+		if a.Bool() == b.Bool() {
+			return 0
+		} else if a.Bool() {
+			return 1
+		}
+		return -1
+	}
+	return 0
+}
+
+// likeMatch matches s against the pattern of a like condition: % matches
+// any characters, _ one character.
+func likeMatch(s, pattern string) bool {
+	var (
+		sr, pr     = []rune(s), []rune(pattern)
+		i, j       = 0, 0
+		star, mark = -1, 0
+	)
+	for i < len(sr) {
+		switch {
+		case j < len(pr) && pr[j] == '%':
+			star, mark = j, i
+			j++
+		case j < len(pr) && (pr[j] == '_' || pr[j] == sr[i]):
+			i++
+			j++
+		case star >= 0:
+			mark++
+			i, j = mark, star+1
+		default:
+			return false
+		}
+	}
+	for j < len(pr) && pr[j] == '%' {
+		j++
+	}
+	return j == len(pr)
+}
@@ -0,0 +1,344 @@
+package oneobject
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/lynkdb/lynkapi/go/codec"
+	"github.com/lynkdb/lynkapi/go/lynkapi"
+)
+
+const historyDefaultLimit = 100
+
+// table name -> row id -> versions in ascending order
+type historyData map[string]map[string][]*lynkapi.DataRowChange
+
+func historyLimit(opt string) int {
+	if n, err := strconv.Atoi(opt); err == nil && n > 0 {
+		return n
+	}
+	return historyDefaultLimit
+}
+
+// historyAppend records one row change, it must be called with the write
+// lock held. The version list of a row is never modified in place, so a
+// reader holding a copy of it sees a stable history.
+func (it *Instance) historyAppend(tbl *table, operator, action string, prev, next map[string]*structpb.Value) {
+
+	opt := tbl.spec.Option(lynkapi.TableSpec_Option_History)
+	if opt == "" {
+		return
+	}
+
+	ch := lynkapi.NewDataRowChange(action, "", prev, next)
+	if action == lynkapi.DataRowChange_Update && len(ch.Changes) == 0 {
+		return
+	}
+	ch.Id = tbl.spec.PrimaryId(ch.Fields)
+	ch.Operator = operator
+
+	if it.history == nil {
+		it.history = historyData{}
+	}
+	rows, ok := it.history[tbl.name]
+	if !ok {
+		rows = map[string][]*lynkapi.DataRowChange{}
+		it.history[tbl.name] = rows
+	}
+
+	vs := rows[ch.Id]
+	if n := len(vs); n > 0 {
+		ch.Version = vs[n-1].Version + 1
+	} else {
+		ch.Version = 1
+	}
+	vs = append(vs[:len(vs):len(vs)], ch)
+	if limit := historyLimit(opt); len(vs) > limit {
+		vs = vs[len(vs)-limit:]
+	}
+	rows[ch.Id] = vs
+}
+
+func (it *Instance) historySnapshot(tbl *table) (reflect.Value, map[string][]*lynkapi.DataRowChange, error) {
+
+	it.mu.RLock()
+	defer it.mu.RUnlock()
+
+	hit, err := findValue(tbl.path, reflect.ValueOf(it.object))
+	if err != nil {
+		return hit, nil, err
+	}
+
+	rows := map[string][]*lynkapi.DataRowChange{}
+	for id, vs := range it.history[tbl.name] {
+		rows[id] = vs
+	}
+
+	return reflect.ValueOf(hit.Interface()), rows, nil
+}
+
+func (it *Instance) historyQuery(
+	q *lynkapi.DataQuery,
+	tbl *table,
+	rs *lynkapi.DataResult,
+) error {
+
+	if tbl.spec.Option(lynkapi.TableSpec_Option_History) == "" {
+		return lynkapi.NewBadRequestError("table history not enabled")
+	}
+
+	hit, rows, err := it.historySnapshot(tbl)
+	if err != nil {
+		return err
+	}
+
+	var (
+		ids     []string
+		current = map[string]map[string]*structpb.Value{}
+		deleted []string
+	)
+
+	for i := 0; i < hit.Len(); i++ {
+		if rowDeleted(tbl, hit.Index(i)) > 0 {
+			continue
+		}
+		if fields := rowFields(hit.Index(i)); fields != nil {
+			id := tbl.spec.PrimaryId(fields)
+			current[id] = fields
+			ids = append(ids, id)
+		}
+	}
+	for id := range rows {
+		if _, ok := current[id]; !ok {
+			deleted = append(deleted, id)
+		}
+	}
+	sort.Strings(deleted)
+	ids = append(ids, deleted...)
+
+	match := func(fields map[string]*structpb.Value) bool {
+		return filterMatch(q.Filter, func(tagName string) reflect.Value {
+			if v, ok := fields[tagName]; ok {
+				return fieldValue(v)
+			}
+			return fieldValue(fields[lowerName(tagName)])
+		})
+	}
+
+	for _, id := range ids {
+
+		vs := rows[id]
+
+		if q.History {
+
+			fields := current[id]
+			if fields == nil && len(vs) > 0 {
+				fields = vs[len(vs)-1].Fields
+			}
+			if !match(fields) {
+				continue
+			}
+
+			for i := len(vs) - 1; i >= 0 && len(rs.Changes) < int(q.Limit); i-- {
+				if q.AsOf > 0 && vs[i].Created > q.AsOf {
+					continue
+				}
+				rs.Changes = append(rs.Changes, proto.Clone(vs[i]).(*lynkapi.DataRowChange))
+			}
+
+			if len(rs.Changes) >= int(q.Limit) {
+				break
+			}
+			continue
+		}
+
+		fields, ok := lynkapi.DataRowChangesAsOf(vs, current[id], q.AsOf)
+		if !ok || !match(fields) {
+			continue
+		}
+
+		rs.Rows = append(rs.Rows, &lynkapi.DataRow{
+			Id: id,
+			Fields: proto.Clone(&structpb.Struct{
+				Fields: fields,
+			}).(*structpb.Struct).Fields,
+		})
+
+		if len(rs.Rows) >= int(q.Limit) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// Restore brings one row back to a recorded version, or to its state at the
+// point in time as_of, or out of the trash if neither is set. The restore
+// itself is recorded as a new version.
+func (it *Instance) Restore(q *lynkapi.DataRestore) (*lynkapi.DataResult, error) {
+
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	tbl, ok := it.tables[q.TableName]
+	if !ok {
+		return nil, errors.New("table not found")
+	}
+
+	if (q.Version > 0 || q.AsOf > 0) &&
+		tbl.spec.Option(lynkapi.TableSpec_Option_History) == "" {
+		return nil, lynkapi.NewBadRequestError("table history not enabled")
+	}
+
+	vtbl, err := findValue(tbl.path, reflect.ValueOf(it.object))
+	if err != nil {
+		return nil, err
+	}
+
+	if !q.DryRun {
+		it.trashExpire(tbl, vtbl)
+	}
+
+	if err := filterCheck(q.Filter); err != nil {
+		return nil, err
+	}
+
+	idx, err := primaryKeyFilter(tbl, q.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		id      = tbl.spec.PrimaryId(idx)
+		pos     = primaryKeyIndex(tbl, vtbl, idx)
+		current map[string]*structpb.Value
+		target  map[string]*structpb.Value
+	)
+	if pos >= 0 && rowDeleted(tbl, vtbl.Index(pos)) == 0 {
+		current = rowFields(vtbl.Index(pos))
+	}
+
+	switch {
+	case q.Version > 0:
+		ch := lynkapi.DataRowChangeVersion(it.history[tbl.name][id], q.Version)
+		if ch == nil || ch.Action == lynkapi.DataRowChange_Delete {
+			return nil, lynkapi.NewNotFoundError("version not found")
+		}
+		target = ch.Fields
+
+	case q.AsOf > 0:
+		fields, ok := lynkapi.DataRowChangesAsOf(it.history[tbl.name][id], current, q.AsOf)
+		if !ok {
+			return nil, lynkapi.NewNotFoundError("row not found at as_of")
+		}
+		target = fields
+
+	case tbl.deleted != nil:
+		if pos < 0 || current != nil {
+			return nil, lynkapi.NewNotFoundError("row not found in trash")
+		}
+		target = rowFields(vtbl.Index(pos))
+
+	default:
+		return nil, lynkapi.NewBadRequestError("version or as_of not setup")
+	}
+
+	tp := vtbl.Type().Elem()
+	if tp.Kind() == reflect.Pointer {
+		tp = tp.Elem()
+	}
+
+	dst := reflect.New(tp)
+
+	js, _ := codec.Json.Encode(target)
+	if err := codec.Json.Decode(js, dst.Interface()); err != nil {
+		return nil, err
+	}
+	if tbl.deleted != nil {
+		rowSetDeleted(tbl, dst, 0)
+	}
+
+	if err := restoreUniqueCheck(tbl, vtbl, pos, dst); err != nil {
+		return nil, err
+	}
+
+	fields := rowFields(dst)
+
+	if q.DryRun {
+		return &lynkapi.DataResult{
+			Status: lynkapi.NewServiceStatusOK(),
+			Spec:   tbl.spec,
+			Rows: []*lynkapi.DataRow{
+				{
+					Id:     id,
+					Fields: fields,
+				},
+			},
+		}, nil
+	}
+
+	if pos >= 0 {
+		ls := sliceCopy(vtbl, 0)
+		ls.Index(pos).Set(rowElem(vtbl.Type().Elem(), dst))
+		vtbl.Set(ls)
+	} else {
+		vtbl.Set(reflect.Append(sliceCopy(vtbl, 1), rowElem(vtbl.Type().Elem(), dst)))
+	}
+
+	it.historyAppend(tbl, q.Operator, lynkapi.DataRowChange_Restore, current, fields)
+
+	if err := it.Flush(); err != nil {
+		return nil, err
+	}
+
+	return &lynkapi.DataResult{
+		Status: lynkapi.NewServiceStatusOK(),
+		Spec:   tbl.spec,
+		Rows: []*lynkapi.DataRow{
+			{
+				Id:     id,
+				Fields: fields,
+			},
+		},
+	}, nil
+}
+
+// restoreUniqueCheck refuses a restored row whose unique keys are taken by
+// another live row.
+func restoreUniqueCheck(tbl *table, vtbl reflect.Value, pos int, dst reflect.Value) error {
+
+	_, pkm, ukm := tbl.field.PrimaryKeys()
+
+	if dst.Kind() == reflect.Pointer {
+		dst = dst.Elem()
+	}
+
+	for i := 0; i < vtbl.Len(); i++ {
+		if i == pos || rowDeleted(tbl, vtbl.Index(i)) > 0 {
+			continue
+		}
+		row := vtbl.Index(i)
+		if row.Kind() == reflect.Pointer {
+			row = row.Elem()
+		}
+		if !row.IsValid() || row.Kind() != reflect.Struct {
+			continue
+		}
+		for _, fd := range ukm {
+			if _, ok := pkm[fd.TagName]; ok {
+				continue
+			}
+			v := dst.FieldByName(fd.Name)
+			if v.IsValid() && !v.IsZero() && v.Interface() == row.FieldByName(fd.Name).Interface() {
+				return lynkapi.NewConflictError(fmt.Sprintf("unique key (%s) taken by another row", fd.TagName))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,357 @@
+package oneobject
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lynkdb/lynkapi/go/codec"
+	"github.com/lynkdb/lynkapi/go/lynkapi"
+)
+
+// The spec of every table is stored with its schema version next to the data
+// file (file + ".schema"). When TableSetup finds the stored spec differs from
+// the current one, the migrations of the table with a version above the
+// stored one are run in order on the stored rows, and the setup fails if a
+// field is still removed or retyped without a migration.
+
+const (
+	Migration_Rename  = "rename"
+	Migration_Convert = "convert"
+	Migration_Default = "default"
+	Migration_Drop    = "drop"
+)
+
+// Migration is a change of a table field, passed as an argument of TableSetup.
+type Migration struct {
+	Version int64
+	Type    string
+	Field   string
+
+	// rename: the new field name
+	To string
+
+	// default: the value of the rows the field is not set
+	Value any
+
+	// convert: converts a json value (string, json.Number, bool, map, slice)
+	// to the new field type, or nil to convert the basic types
+	Convert func(v any) (any, error)
+}
+
+func NewMigrationRename(version int64, field, to string) *Migration {
+	return &Migration{Version: version, Type: Migration_Rename, Field: field, To: to}
+}
+
+func NewMigrationConvert(version int64, field string, fn func(v any) (any, error)) *Migration {
+	return &Migration{Version: version, Type: Migration_Convert, Field: field, Convert: fn}
+}
+
+func NewMigrationDefault(version int64, field string, value any) *Migration {
+	return &Migration{Version: version, Type: Migration_Default, Field: field, Value: value}
+}
+
+func NewMigrationDrop(version int64, field string) *Migration {
+	return &Migration{Version: version, Type: Migration_Drop, Field: field}
+}
+
+type schemaData struct {
+	Tables map[string]*schemaTable `json:"tables"`
+}
+
+type schemaTable struct {
+	Version int64              `json:"version"`
+	Spec    *lynkapi.TableSpec `json:"spec"`
+}
+
+// SchemaVersion returns the applied schema version of the table.
+func (it *Instance) SchemaVersion(tableName string) int64 {
+	it.mu.RLock()
+	defer it.mu.RUnlock()
+	if st, ok := it.schema.Tables[tableName]; ok {
+		return st.Version
+	}
+	return 0
+}
+
+// schemaSetup checks and migrates the stored rows of a new table and records
+// its schema, it must be called with the write lock held.
+func (it *Instance) schemaSetup(tbl *table, migrations []*Migration) error {
+
+	sort.SliceStable(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	var (
+		stored  = it.schema.Tables[tbl.name]
+		version int64
+		pending []*Migration
+	)
+
+	if stored != nil && stored.Spec != nil {
+		version = stored.Version
+		for _, m := range migrations {
+			if m.Version > stored.Version {
+				pending = append(pending, m)
+			}
+		}
+		if err := schemaCheck(stored.Spec, tbl.spec, pending); err != nil {
+			return fmt.Errorf("table (%s) schema v%d: %s", tbl.name, stored.Version, err.Error())
+		}
+		if len(pending) > 0 {
+			if err := it.schemaMigrate(tbl, pending); err != nil {
+				return fmt.Errorf("table (%s) migrate: %s", tbl.name, err.Error())
+			}
+		}
+	}
+
+	if n := len(migrations); n > 0 && migrations[n-1].Version > version {
+		version = migrations[n-1].Version
+	}
+
+	if it.schema.Tables == nil {
+		it.schema.Tables = map[string]*schemaTable{}
+	}
+	it.schema.Tables[tbl.name] = &schemaTable{
+		Version: version,
+		Spec:    tbl.spec,
+	}
+
+	if it.file != "" {
+		// the migrated rows are stored first, a failed flush leaves the
+		// stored version as is and the migrations run again
+		if len(pending) > 0 && it.flusher != nil {
+			if err := it.flusher(); err != nil {
+				return err
+			}
+		}
+		b, _ := codec.Json.Encode(&it.schema)
+		if err := ioutil.WriteFile(it.file+".schema", b, 0640); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// schemaCheck applies the migrations to the field types of the stored spec,
+// and compares them to the current spec.
+func schemaCheck(stored, spec *lynkapi.TableSpec, migrations []*Migration) error {
+
+	types := map[string]string{}
+	for _, field := range stored.Fields {
+		types[field.TagName] = field.Type
+	}
+
+	for _, m := range migrations {
+		cur, _ := spec.Field(m.Field)
+		switch m.Type {
+		case Migration_Rename:
+			if m.To == "" {
+				return fmt.Errorf("migration v%d: rename to not setup", m.Version)
+			}
+			if typ, ok := types[m.Field]; ok {
+				types[m.To] = typ
+				delete(types, m.Field)
+			}
+
+		case Migration_Convert, Migration_Default:
+			if cur == nil {
+				return fmt.Errorf("migration v%d: field (%s) not found", m.Version, m.Field)
+			}
+			if _, ok := types[m.Field]; ok || m.Type == Migration_Default {
+				types[m.Field] = cur.Type
+			}
+
+		case Migration_Drop:
+			delete(types, m.Field)
+
+		default:
+			return fmt.Errorf("migration v%d: invalid type (%s)", m.Version, m.Type)
+		}
+	}
+
+	for tag, typ := range types {
+		cur, _ := spec.Field(tag)
+		if cur == nil {
+			return fmt.Errorf("field (%s) removed without migration", tag)
+		}
+		if cur.Type != typ {
+			return fmt.Errorf("field (%s) type changed (%s to %s) without migration", tag, typ, cur.Type)
+		}
+	}
+
+	return nil
+}
+
+// schemaMigrate runs the migrations on the stored (json) rows of the table,
+// and replaces the rows of the table with them.
+func (it *Instance) schemaMigrate(tbl *table, migrations []*Migration) error {
+
+	if len(it.raw) == 0 {
+		return nil
+	}
+
+	var (
+		data    map[string]any
+		rows    []any
+		objPath = tbl.objectPath()
+	)
+
+	// the numbers are kept as json.Number, so the int64 values out of the
+	// float64 precision are not changed
+	dec := json.NewDecoder(bytes.NewReader(it.raw))
+	dec.UseNumber()
+	if err := dec.Decode(&data); err != nil {
+		return err
+	}
+
+	for i, name := range objPath {
+		v, ok := data[name]
+		if !ok || v == nil {
+			return nil
+		}
+		if i+1 < len(objPath) {
+			if data, ok = v.(map[string]any); !ok {
+				return errors.New("invalid object path")
+			}
+		} else if rows, ok = v.([]any); !ok {
+			return errors.New("invalid table rows")
+		}
+	}
+
+	for _, m := range migrations {
+		for _, v := range rows {
+			row, ok := v.(map[string]any)
+			if !ok {
+				continue
+			}
+			fv, ok := row[m.Field]
+			switch m.Type {
+			case Migration_Rename:
+				if ok {
+					row[m.To] = fv
+					delete(row, m.Field)
+				}
+
+			case Migration_Convert:
+				if ok && fv != nil {
+					fn := m.Convert
+					if fn == nil {
+						field, _ := tbl.spec.Field(m.Field)
+						fn = schemaConvertFunc(field)
+					}
+					cv, err := fn(fv)
+					if err != nil {
+						return fmt.Errorf("migration v%d: field (%s) value (%v): %s",
+							m.Version, m.Field, fv, err.Error())
+					}
+					row[m.Field] = cv
+				}
+
+			case Migration_Default:
+				if !ok || fv == nil {
+					row[m.Field] = m.Value
+				}
+
+			case Migration_Drop:
+				delete(row, m.Field)
+			}
+		}
+	}
+
+	b, err := json.Marshal(rows)
+	if err != nil {
+		return err
+	}
+
+	hit, err := findValue(tbl.path, reflect.ValueOf(it.object))
+	if err != nil {
+		return err
+	}
+
+	ls := reflect.New(hit.Type())
+	if err := json.Unmarshal(b, ls.Interface()); err != nil {
+		return err
+	}
+	hit.Set(ls.Elem())
+
+	return nil
+}
+
+func (it *table) objectPath() []string {
+	return strings.Split(it.name, "__")
+}
+
+// schemaConvertFunc converts the basic json values (string, json.Number,
+// bool) to the field type.
+func schemaConvertFunc(field *lynkapi.FieldSpec) func(v any) (any, error) {
+	return func(v any) (any, error) {
+		switch field.Type {
+		case lynkapi.FieldSpec_String:
+			switch v := v.(type) {
+			case string:
+				return v, nil
+			case json.Number:
+				return v.String(), nil
+			case bool:
+				return strconv.FormatBool(v), nil
+			}
+
+		case lynkapi.FieldSpec_Int, lynkapi.FieldSpec_Uint, lynkapi.FieldSpec_Float:
+			switch v := v.(type) {
+			case string:
+				if v = strings.TrimSpace(v); v == "" {
+					return json.Number("0"), nil
+				}
+				return schemaNumber(field.Type, v)
+			case json.Number:
+				return schemaNumber(field.Type, v.String())
+			case bool:
+				if v {
+					return json.Number("1"), nil
+				}
+				return json.Number("0"), nil
+			}
+
+		case lynkapi.FieldSpec_Bool:
+			switch v := v.(type) {
+			case string:
+				if v == "" {
+					return false, nil
+				}
+				return strconv.ParseBool(v)
+			case json.Number:
+				f, err := v.Float64()
+				return f != 0, err
+			case bool:
+				return v, nil
+			}
+		}
+		return nil, fmt.Errorf("can not convert %T to %s", v, field.Type)
+	}
+}
+
+// schemaNumber checks the number s fits the field type, and returns it as a
+// json.Number (without a float64 conversion).
+func schemaNumber(typ, s string) (json.Number, error) {
+	var err error
+	switch typ {
+	case lynkapi.FieldSpec_Int:
+		_, err = strconv.ParseInt(s, 10, 64)
+	case lynkapi.FieldSpec_Uint:
+		_, err = strconv.ParseUint(s, 10, 64)
+	default:
+		_, err = strconv.ParseFloat(s, 64)
+	}
+	if err != nil {
+		return "", err
+	}
+	return json.Number(s), nil
+}
@@ -1,13 +1,18 @@
 package oneobject
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"reflect"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"google.golang.org/protobuf/types/known/structpb"
 
@@ -16,13 +21,16 @@ import (
 )
 
 type Instance struct {
-	mu      sync.Mutex
+	mu      sync.RWMutex
 	name    string
 	file    string
 	spec    *lynkapi.TypeSpec
 	object  any
 	tables  map[string]*table
 	flusher Flusher
+	history historyData
+	schema  schemaData
+	raw     []byte // the stored object, used by migrations
 }
 
 type Flusher func() error
@@ -32,11 +40,13 @@ type table struct {
 	name  string
 	spec  *lynkapi.TableSpec
 	field *lynkapi.FieldSpec
+
+	deleted *lynkapi.FieldSpec // soft-delete field
 }
 
 func (it *Instance) Instance() *lynkapi.DataInstance {
-	it.mu.Lock()
-	defer it.mu.Unlock()
+	it.mu.RLock()
+	defer it.mu.RUnlock()
 
 	di := &lynkapi.DataInstance{
 		Name: it.name,
@@ -88,9 +98,23 @@ func NewInstanceFromFile(name, file string, obj any, args ...any) (*Instance, er
 		return nil, err
 	}
 
+	var schema schemaData
+	if b, err := ioutil.ReadFile(file + ".schema"); err == nil {
+		if err = codec.Json.Decode(b, &schema); err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
 	if err == nil {
 		if err = codec.Json.Decode(b, obj); err != nil {
-			return nil, err
+			// a field retyped since the stored schema is checked (and
+			// migrated) by TableSetup
+			var typeErr *json.UnmarshalTypeError
+			if !errors.As(err, &typeErr) || len(schema.Tables) == 0 {
+				return nil, err
+			}
 		}
 	}
 
@@ -99,12 +123,29 @@ func NewInstanceFromFile(name, file string, obj any, args ...any) (*Instance, er
 		return nil, err
 	}
 	inst.file = file
+	inst.schema = schema
+	inst.raw = b
+
+	if b, err := ioutil.ReadFile(file + ".history"); err == nil {
+		if err = codec.Json.Decode(b, &inst.history); err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
 
 	inst.flusher = func() error {
 		b, _ := codec.Json.Encode(inst.object, &codec.JsonOptions{
 			Width: 120,
 		})
-		return ioutil.WriteFile(inst.file, b, 0640)
+		if err := ioutil.WriteFile(inst.file, b, 0640); err != nil {
+			return err
+		}
+		if len(inst.history) > 0 {
+			b, _ = codec.Json.Encode(inst.history)
+			return ioutil.WriteFile(inst.file+".history", b, 0640)
+		}
+		return nil
 	}
 
 	return inst, nil
@@ -136,19 +177,16 @@ func NewInstance(name string, obj any, args ...any) (*Instance, error) {
 
 func (it *Instance) Query(q *lynkapi.DataQuery) (*lynkapi.DataResult, error) {
 
-	it.mu.Lock()
-	defer it.mu.Unlock()
-
-	tbl, ok := it.tables[q.TableName]
-	if !ok {
-		return nil, errors.New("table not found")
-	}
-
-	hit, err := findValue(tbl.path, reflect.ValueOf(it.object))
+	tbl, hit, err := it.snapshot(q.TableName)
 	if err != nil {
 		return nil, err
 	}
 
+	return it.query(q, tbl, hit)
+}
+
+func (it *Instance) query(q *lynkapi.DataQuery, tbl *table, hit reflect.Value) (*lynkapi.DataResult, error) {
+
 	indexFields := map[string]*lynkapi.FieldSpec{}
 	for _, fd := range tbl.field.Fields {
 		indexFields[fd.TagName] = fd
@@ -158,113 +196,170 @@ func (it *Instance) Query(q *lynkapi.DataQuery) (*lynkapi.DataResult, error) {
 		q.Limit = 10
 	}
 
-	var (
-		rs = &lynkapi.DataResult{
-			Spec: tbl.spec,
+	if q.Trash && tbl.deleted == nil {
+		return nil, lynkapi.NewBadRequestError("table soft-delete not enabled")
+	}
+
+	rs := &lynkapi.DataResult{
+		Spec: tbl.spec,
+	}
+
+	if err := filterCheck(q.Filter); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, fr := range filterFields(q.Filter) {
+		tp, ok := indexFields[lowerName(fr.Field)]
+		if !ok {
+			return nil, errors.New("filter/field not found")
 		}
-		filters = map[string]*structpb.Value{}
-	)
+		if !slices.Contains(names, tp.TagName) {
+			names = append(names, tp.TagName)
+		}
+	}
 
-	orderDef := int32(0)
-	sortFilter := func(row *lynkapi.DataRow) *lynkapi.DataRow {
-		// TODO
-		if q.Sort != nil && q.Sort.Field != "" {
-			if v, ok := row.Fields[q.Sort.Field]; ok && v.GetNumberValue() > 0 {
-				row.InterOrder = int64(v.GetNumberValue())
-			} else {
-				orderDef += 1
-				row.InterOrder = row.InterOrder
-			}
+	var sortField *lynkapi.FieldSpec
+	if q.Sort != nil && q.Sort.Field != "" {
+		if sortField = indexFields[lowerName(q.Sort.Field)]; sortField == nil {
+			return nil, errors.New("sort/field not found")
 		}
-		return row
 	}
 
-	if q.Filter != nil {
-		if len(q.Filter.Field) > 0 {
-			tp, ok := indexFields[lowerName(q.Filter.Field)]
-			if !ok {
-				return nil, errors.New("filter/field not found")
-			}
-			switch tp.Type {
-			case lynkapi.FieldSpec_String:
-				filters[tp.Name] = q.Filter.Value
-			}
-		} else if len(q.Filter.Inner) > 0 {
-			for _, fr := range q.Filter.Inner {
-				tp, ok := indexFields[lowerName(fr.Field)]
-				if !ok {
-					return nil, errors.New("filter/field not found")
-				}
-				switch tp.Type {
-				case lynkapi.FieldSpec_String:
-					filters[tp.Name] = fr.Value
-				}
-			}
+	var (
+		start    = time.Now()
+		examined int64
+		rowsHit  int64
+		returned int
+	)
+
+	if q.Explain {
+		rs.Plan = &lynkapi.DataQueryPlan{
+			Driver: "oneobject",
+			Access: lynkapi.DataQueryPlan_FullScan,
 		}
 	}
 
-	for i := 0; i < hit.Len() && len(rs.Rows) < int(q.Limit); i++ {
-		v := hit.Index(i)
-		if v.Kind() == reflect.Pointer {
-			v = v.Elem()
+	if q.History || q.AsOf > 0 {
+		if err := it.historyQuery(q, tbl, rs); err != nil {
+			return nil, err
 		}
-		if !v.IsValid() || v.Kind() != reflect.Struct {
-			continue
+		// the changes of a history query, or the rows as of a time
+		examined, rowsHit = int64(hit.Len()), int64(len(rs.Changes)+len(rs.Rows))
+		rs.Plan.AddStage("history", "", int(rowsHit), start)
+		if sortField != nil {
+			start := time.Now()
+			sortRows(q, sortField, rs.Rows)
+			rs.Plan.AddStage("sort", q.Sort.Field, len(rs.Rows), start)
 		}
-		frHit := 0
-		if len(filters) > 0 {
-			for name, frValue := range filters {
-				fv := v.FieldByName(name)
-				if !fv.IsValid() {
+	} else {
+
+		var (
+			offset = int(q.Offset)
+			rows   []reflect.Value
+		)
+
+		// without a sort, the rows after the page are only counted for the
+		// rows hit
+		for i := 0; i < hit.Len(); i++ {
+			examined += 1
+			v := hit.Index(i)
+			if v.Kind() == reflect.Pointer {
+				v = v.Elem()
+			}
+			if !v.IsValid() || v.Kind() != reflect.Struct {
+				continue
+			}
+			if (rowDeleted(tbl, v) > 0) != q.Trash {
+				continue
+			}
+			if !rowMatch(tbl, v, q.Filter) {
+				continue
+			}
+			rowsHit += 1
+			if sortField == nil {
+				if offset > 0 {
+					offset -= 1
 					continue
 				}
-				switch frValue.Kind.(type) {
-				case *structpb.Value_StringValue:
-					if fv.String() == frValue.GetStringValue() {
-						frHit += 1
-					}
+				if len(rows) >= int(q.Limit) {
+					continue
 				}
 			}
-		}
-		if frHit != len(filters) {
-			continue
+			rows = append(rows, v)
 		}
 
-		// anyValue, err := lynkapi.ConvertReflectValueToApiValue(v)
-		// if err != nil {
-		// 	fmt.Println("err", err)
-		// 	continue
-		// }
-		// rs.Items = append(rs.Items, anyValue)
+		if rs.Plan != nil {
+			sort.Strings(names)
+			detail := "no filter"
+			if len(names) > 0 {
+				detail = "filter on " + strings.Join(names, ",")
+			}
+			rs.Plan.AddStage("scan", detail, int(rowsHit), start)
+		}
 
-		fieldValues, err := lynkapi.ConvertReflectValueToMapValue(v)
-		if err != nil {
-			continue
+		// a sort orders all the rows hit, then the page is cut
+		if sortField != nil {
+			start := time.Now()
+			sort.SliceStable(rows, func(i, j int) bool {
+				n := reflectCompare(rows[i].FieldByName(sortField.Name), rows[j].FieldByName(sortField.Name))
+				if q.Sort.Type == "desc" {
+					n = -n
+				}
+				return n < 0
+			})
+			rows = rows[min(offset, len(rows)):min(offset+int(q.Limit), len(rows))]
+			rs.Plan.AddStage("sort", q.Sort.Field, len(rows), start)
 		}
 
-		row := &lynkapi.DataRow{
-			Id:     tbl.spec.PrimaryId(fieldValues),
-			Fields: fieldValues,
+		// the columns of a columnar query are read from the rows, without
+		// their json encoding
+		if q.Columnar {
+			w := lynkapi.NewDataColWriter(tbl.spec)
+			for _, v := range rows {
+				w.Append(v)
+			}
+			rs.Cols, returned = w.Cols(), w.Len()
+			rows = nil
 		}
 
-		row = sortFilter(row)
+		for _, v := range rows {
 
-		rs.Rows = append(rs.Rows, row)
-	}
+			// anyValue, err := lynkapi.ConvertReflectValueToApiValue(v)
+			// if err != nil {
+			// 	fmt.Println("err", err)
+			// 	continue
+			// }
+			// rs.Items = append(rs.Items, anyValue)
 
-	if q.Sort != nil {
-		if q.Sort.Type == "desc" {
-			sort.Slice(rs.Rows, func(i, j int) bool {
-				return rs.Rows[i].InterOrder > rs.Rows[j].InterOrder
-			})
-		} else {
-			sort.Slice(rs.Rows, func(i, j int) bool {
-				return rs.Rows[i].InterOrder < rs.Rows[j].InterOrder
+			fieldValues, err := lynkapi.ConvertReflectValueToMapValue(v)
+			if err != nil {
+				continue
+			}
+
+			rs.Rows = append(rs.Rows, &lynkapi.DataRow{
+				Id:     tbl.spec.PrimaryId(fieldValues),
+				Fields: fieldValues,
 			})
 		}
 	}
 
-	if len(rs.Rows) == 0 {
+	returned += len(rs.Rows)
+
+	rs.Stats = &lynkapi.DataResult_Stats{
+		RowsReturned: int32(returned),
+		RowsHit:      rowsHit,
+		Offset:       q.Offset,
+		Limit:        q.Limit,
+	}
+
+	if rs.Plan != nil {
+		rs.Plan.RowsExamined = examined
+		rs.Plan.RowsReturned = int64(returned + len(rs.Changes))
+		rs.Plan.TimeUs = time.Since(start).Microseconds()
+	}
+
+	if returned == 0 && len(rs.Changes) == 0 {
 		rs.Status = lynkapi.NewServiceStatus(lynkapi.StatusCode_NotFound, "")
 	} else {
 		rs.Status = lynkapi.NewServiceStatusOK()
@@ -273,10 +368,22 @@ func (it *Instance) Query(q *lynkapi.DataQuery) (*lynkapi.DataResult, error) {
 	return rs, nil
 }
 
+// sortRows orders the rows (of a history query) by the sort field.
+func sortRows(q *lynkapi.DataQuery, field *lynkapi.FieldSpec, rows []*lynkapi.DataRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		n := reflectCompare(fieldValue(rows[i].Fields[field.TagName]), fieldValue(rows[j].Fields[field.TagName]))
+		if q.Sort.Type == "desc" {
+			n = -n
+		}
+		return n < 0
+	})
+}
+
 const (
 	kInsertRaw int = iota + 1
 	kInsertIgsert
 	kInsertUpsert
+	kInsertUpdate
 )
 
 func (it *Instance) Insert(q *lynkapi.DataInsert) (*lynkapi.DataResult, error) {
@@ -291,6 +398,11 @@ func (it *Instance) Upsert(q *lynkapi.DataInsert) (*lynkapi.DataResult, error) {
 	return it.insert(q, kInsertUpsert)
 }
 
+// Update writes the row only if it exists, it never creates a row.
+func (it *Instance) Update(q *lynkapi.DataInsert) (*lynkapi.DataResult, error) {
+	return it.insert(q, kInsertUpdate)
+}
+
 func (it *Instance) insert(q *lynkapi.DataInsert, typ int) (*lynkapi.DataResult, error) {
 
 	if len(q.Fields) == 0 || len(q.Fields) != len(q.Values) {
@@ -310,6 +422,10 @@ func (it *Instance) insert(q *lynkapi.DataInsert, typ int) (*lynkapi.DataResult,
 		return nil, err
 	}
 
+	if !q.DryRun {
+		it.trashExpire(tbl, vtbl)
+	}
+
 	var (
 		pks, pkm, ukm = tbl.field.PrimaryKeys()
 		data          = map[string]*structpb.Value{}
@@ -390,7 +506,17 @@ func (it *Instance) insert(q *lynkapi.DataInsert, typ int) (*lynkapi.DataResult,
 		return nil, err
 	}
 
-	rs := &lynkapi.DataResult{}
+	// only Delete and Restore move a row into or out of the trash
+	if rowDeleted(tbl, reqData) != 0 {
+		return nil, lynkapi.NewBadRequestError(fmt.Sprintf("soft-delete field (%s) not writable",
+			tbl.deleted.TagName))
+	}
+
+	var (
+		rs      = &lynkapi.DataResult{}
+		match   = -1
+		trashed []int
+	)
 
 	for i := 0; i < vtbl.Len(); i++ {
 		row := vtbl.Index(i)
@@ -415,37 +541,86 @@ func (it *Instance) insert(q *lynkapi.DataInsert, typ int) (*lynkapi.DataResult,
 			continue
 		}
 
+		// a row in the trash never takes a write, its keys are reused by
+		// the new row and the trashed row is purged
+		if rowDeleted(tbl, row) > 0 {
+			trashed = append(trashed, i)
+			continue
+		}
+
+		if match < 0 {
+			match = i
+		}
+	}
+
+	if match >= 0 {
+
+		row := vtbl.Index(match)
+		if row.Kind() == reflect.Pointer {
+			row = row.Elem()
+		}
+
 		switch typ {
 		case kInsertRaw:
 			return rs, lynkapi.NewConflictError("row exist")
 
-		case kInsertUpsert:
-			if _, err := tbl.field.DataMerge(vtbl.Index(i).Interface(), reqData.Interface()); err != nil {
+		case kInsertUpsert, kInsertUpdate:
+			dst := rowPointer(cloneValue(vtbl.Index(match)))
+			if _, err := tbl.field.DataMerge(dst.Interface(), reqData.Interface(),
+				lynkapi.DataMerge_FieldMask(q.FieldMask)); err != nil {
 				return nil, err
 			}
+			rowSetNulls(tbl, dst, q)
+
+			if q.DryRun {
+				return writeResult(tbl, lynkapi.DataRowChange_Update, rowFields(row), rowFields(dst)), nil
+			}
+
+			ls := sliceCopy(vtbl, 0)
+			ls.Index(match).Set(rowElem(vtbl.Type().Elem(), dst))
+			vtbl.Set(ls)
+			trashPurge(vtbl, trashed)
+
+			it.historyAppend(tbl, q.Operator, lynkapi.DataRowChange_Update,
+				rowFields(row), rowFields(dst))
 
 			if err := it.Flush(); err != nil {
 				return nil, err
 			}
+			return writeResult(tbl, lynkapi.DataRowChange_Update, rowFields(row), rowFields(dst)), nil
 		}
 
 		rs.Status = lynkapi.NewServiceStatusOK()
 		return rs, nil
 	}
 
+	if typ == kInsertUpdate {
+		rs.Status = lynkapi.NewServiceStatus(lynkapi.StatusCode_NotFound, "")
+		return rs, nil
+	}
+
 	dst := reflect.New(tp)
 	_, err = tbl.field.DataMerge(dst.Interface(), reqData.Interface())
 	if err != nil {
 		return nil, err
 	}
-	vtbl.Set(reflect.Append(vtbl, dst))
+	rowSetNulls(tbl, dst, q)
+
+	if q.DryRun {
+		return writeResult(tbl, lynkapi.DataRowChange_Create, nil, rowFields(dst)), nil
+	}
+
+	trashPurge(vtbl, trashed)
+	vtbl.Set(reflect.Append(sliceCopy(vtbl, 1), rowElem(vtbl.Type().Elem(), dst)))
+
+	it.historyAppend(tbl, q.Operator, lynkapi.DataRowChange_Create,
+		nil, rowFields(dst))
 
 	if err := it.Flush(); err != nil {
 		return nil, err
 	}
 
-	rs.Status = lynkapi.NewServiceStatusOK()
-	return rs, nil
+	return writeResult(tbl, lynkapi.DataRowChange_Create, nil, rowFields(dst)), nil
 }
 
 func (it *Instance) Delete(q *lynkapi.DataDelete) (*lynkapi.DataResult, error) {
@@ -463,7 +638,99 @@ func (it *Instance) Delete(q *lynkapi.DataDelete) (*lynkapi.DataResult, error) {
 		return nil, err
 	}
 
-	if q.Filter == nil || len(q.Filter.Inner) == 0 {
+	if err := filterCheck(q.Filter); err != nil {
+		return nil, err
+	}
+
+	idx, err := primaryKeyFilter(tbl, q.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := &lynkapi.DataResult{}
+
+	if q.DryRun {
+		rs.Status = lynkapi.NewServiceStatusOK()
+		i := primaryKeyIndex(tbl, vtbl, idx)
+		if i < 0 || !rowMatch(tbl, vtbl.Index(i), q.Filter) ||
+			(tbl.deleted != nil && !q.Purge && rowDeleted(tbl, vtbl.Index(i)) > 0) {
+			return rs, nil
+		}
+		return writeResult(tbl, lynkapi.DataRowChange_Delete, rowFields(vtbl.Index(i)), nil), nil
+	}
+
+	flush := it.trashExpire(tbl, vtbl)
+
+	switch i := primaryKeyIndex(tbl, vtbl, idx); {
+	case i < 0 || !rowMatch(tbl, vtbl.Index(i), q.Filter):
+
+	case tbl.deleted != nil && !q.Purge:
+		if rowDeleted(tbl, vtbl.Index(i)) == 0 {
+			rs = writeResult(tbl, lynkapi.DataRowChange_Delete, rowFields(vtbl.Index(i)), nil)
+			it.trashMove(tbl, vtbl, i, q.Operator)
+			flush = true
+		}
+
+	default:
+
+		var (
+			prev    = rowFields(vtbl.Index(i))
+			trashed = rowDeleted(tbl, vtbl.Index(i)) > 0
+		)
+
+		ls := reflect.New(vtbl.Type()).Elem()
+		for j := 0; j < i; j++ {
+			ls.Set(reflect.Append(ls, vtbl.Index(j)))
+		}
+		for j := i + 1; j < vtbl.Len(); j++ {
+			ls.Set(reflect.Append(ls, vtbl.Index(j)))
+		}
+		vtbl.Set(ls)
+
+		if !trashed {
+			it.historyAppend(tbl, q.Operator, lynkapi.DataRowChange_Delete, prev, nil)
+		}
+		rs = writeResult(tbl, lynkapi.DataRowChange_Delete, prev, nil)
+		flush = true
+	}
+
+	if flush {
+		if err := it.Flush(); err != nil {
+			return nil, err
+		}
+	}
+
+	rs.Status = lynkapi.NewServiceStatusOK()
+	return rs, nil
+}
+
+// DryRun reports the writes with dry_run are validated and returned without
+// commit.
+func (it *Instance) DryRun() bool {
+	return true
+}
+
+func (it *Instance) Columnar() bool {
+	return true
+}
+
+// dryRunResult returns the row and the change of a write with dry_run.
+func writeResult(tbl *table, action string, prev, next map[string]*structpb.Value) *lynkapi.DataResult {
+	ch := lynkapi.NewDataRowChange(action, "", prev, next)
+	ch.Id = tbl.spec.PrimaryId(ch.Fields)
+	return &lynkapi.DataResult{
+		Status: lynkapi.NewServiceStatusOK(),
+		Rows: []*lynkapi.DataRow{{
+			Id:     ch.Id,
+			Fields: ch.Fields,
+		}},
+		Changes: []*lynkapi.DataRowChange{ch},
+	}
+}
+
+func primaryKeyFilter(tbl *table, filter *lynkapi.DataQuery_Filter) (map[string]*structpb.Value, error) {
+
+	if filter == nil || (filter.Field == "" && len(filter.Inner) == 0) {
 		return nil, errors.New("filter not found")
 	}
 
@@ -471,7 +738,7 @@ func (it *Instance) Delete(q *lynkapi.DataDelete) (*lynkapi.DataResult, error) {
 		idx         = map[string]*structpb.Value{}
 		pks, pkm, _ = tbl.field.PrimaryKeys()
 	)
-	for _, fr := range q.Filter.Inner {
+	for _, fr := range filterKeys(filter) {
 		if fr.Value == nil {
 			continue
 		}
@@ -493,8 +760,47 @@ func (it *Instance) Delete(q *lynkapi.DataDelete) (*lynkapi.DataResult, error) {
 	if len(idx) != len(pks) {
 		return nil, errors.New("primary-key not found")
 	}
+	return idx, nil
+}
 
-	rs := &lynkapi.DataResult{}
+// valueMatch returns true if the field value equals the filter value, the
+// values are compared by the kind of the field, so an int field matches the
+// number 1 and the string "1".
+func valueMatch(fv reflect.Value, v *structpb.Value) bool {
+	switch fv.Kind() {
+	case reflect.String:
+		if _, ok := v.GetKind().(*structpb.Value_NumberValue); ok {
+			return fv.String() == strconv.FormatFloat(v.GetNumberValue(), 'f', -1, 64)
+		}
+		return fv.String() == v.GetStringValue()
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if _, ok := v.GetKind().(*structpb.Value_StringValue); ok {
+			i, err := strconv.ParseInt(v.GetStringValue(), 10, 64)
+			return err == nil && fv.Int() == i
+		}
+		return float64(fv.Int()) == v.GetNumberValue()
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if _, ok := v.GetKind().(*structpb.Value_StringValue); ok {
+			i, err := strconv.ParseUint(v.GetStringValue(), 10, 64)
+			return err == nil && fv.Uint() == i
+		}
+		return float64(fv.Uint()) == v.GetNumberValue()
+
+	case reflect.Float32, reflect.Float64:
+		n, ok := valueCompare(fv, v)
+		return ok && n == 0
+
+	case reflect.Bool:
+		return fv.Bool() == v.GetBoolValue()
+	}
+	return false
+}
+
+func primaryKeyIndex(tbl *table, vtbl reflect.Value, idx map[string]*structpb.Value) int {
+
+	pks, pkm, _ := tbl.field.PrimaryKeys()
 
 	for i := 0; i < vtbl.Len(); i++ {
 		v := vtbl.Index(i)
@@ -517,31 +823,43 @@ func (it *Instance) Delete(q *lynkapi.DataDelete) (*lynkapi.DataResult, error) {
 				}
 			}
 		}
-		if pkhit != len(pks) {
-			continue
+		if pkhit == len(pks) {
+			return i
 		}
+	}
+	return -1
+}
 
-		ls := reflect.New(vtbl.Type()).Elem()
-		for j := 0; j < i; j++ {
-			ls.Set(reflect.Append(ls, vtbl.Index(j)))
-		}
-		for j := i + 1; j < vtbl.Len(); j++ {
-			ls.Set(reflect.Append(ls, vtbl.Index(j)))
-		}
-		vtbl.Set(ls)
+func rowFields(v reflect.Value) map[string]*structpb.Value {
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		return nil
+	}
+	fields, _ := lynkapi.ConvertReflectValueToMapValue(v)
+	return fields
+}
 
-		if err := it.Flush(); err != nil {
-			return nil, err
+// rowSetNulls clears the fields set to an explicit null value, which the
+// DataMerge takes as empty values and leaves untouched.
+func rowSetNulls(tbl *table, dst reflect.Value, q *lynkapi.DataInsert) {
+	for i, name := range q.Fields {
+		if _, ok := q.Values[i].GetKind().(*structpb.Value_NullValue); !ok {
+			continue
+		}
+		for _, fd := range tbl.field.Fields {
+			if fd.TagName != name {
+				continue
+			}
+			if fv := dst.Elem().FieldByName(fd.Name); fv.CanSet() {
+				fv.Set(reflect.Zero(fv.Type()))
+			}
 		}
-
-		break
 	}
-
-	rs.Status = lynkapi.NewServiceStatusOK()
-	return rs, nil
 }
 
-func (it *Instance) TableSetup(path string) error {
+func (it *Instance) TableSetup(path string, args ...any) error {
 
 	var (
 		fields = strings.Split(strings.TrimSpace(path), "__")
@@ -592,16 +910,50 @@ func (it *Instance) TableSetup(path string) error {
 	if err != nil {
 		return err
 	}
-	it.tables[tableName] = &table{
-		name: tableName,
-		path: hitPath,
-		spec: &lynkapi.TableSpec{
+	var (
+		deleted    *lynkapi.FieldSpec
+		migrations []*Migration
+		spec       = &lynkapi.TableSpec{
 			Name:   tableName,
 			Kind:   hitField.Kind,
 			Fields: hitField.Fields,
-		},
+		}
+	)
+
+	for _, arg := range args {
+		if arg == nil {
+			continue
+		}
+		switch arg.(type) {
+		case lynkapi.TableOption:
+			opt := arg.(lynkapi.TableOption)
+			spec.SetOption(opt.Name, opt.Value)
+
+		case *Migration:
+			migrations = append(migrations, arg.(*Migration))
+		}
+	}
+
+	if spec.Option(lynkapi.TableSpec_Option_SoftDelete) != "" {
+		if deleted, err = trashField(hitField); err != nil {
+			return err
+		}
+	}
+
+	tbl := &table{
+		name:  tableName,
+		path:  hitPath,
+		spec:  spec,
 		field: hitField,
+
+		deleted: deleted,
 	}
+
+	if err := it.schemaSetup(tbl, migrations); err != nil {
+		return err
+	}
+
+	it.tables[tableName] = tbl
 	return nil
 }
 
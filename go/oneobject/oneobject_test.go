@@ -2,7 +2,14 @@ package oneobject_test
 
 import (
 	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/lynkdb/lynkapi/go/lynkapi"
 	"github.com/lynkdb/lynkapi/go/oneobject"
@@ -100,6 +107,39 @@ func Test_Instance(t *testing.T) {
 		}
 	}
 
+	{ // nested and-groups
+		q := &lynkapi.DataQuery{
+			TableName: "options",
+			Filter: &lynkapi.DataQuery_Filter{
+				Inner: []*lynkapi.DataQuery_Filter{
+					(&lynkapi.DataQuery_Filter{}).And("name", "name-2"),
+					(&lynkapi.DataQuery_Filter{}).And("value", "value-2"),
+				},
+			},
+		}
+		if rs, err := inst.Query(q); err != nil || len(rs.Rows) != 1 {
+			t.Fatalf("invalid nested filter result %v %v", rs, err)
+		}
+		q.Filter.Inner[1] = (&lynkapi.DataQuery_Filter{}).And("name", "name-1")
+		if rs, err := inst.Query(q); err != nil || len(rs.Rows) != 0 {
+			t.Fatalf("invalid conflicting filter result %v %v", rs, err)
+		}
+
+		for _, fr := range []*lynkapi.DataQuery_Filter{
+			{Type: "func", Field: "name"},
+			{Field: "name", Op: "between", Value: structpb.NewStringValue("name-1")},
+			{Field: "name", Op: lynkapi.DataQuery_Filter_In, Value: structpb.NewStringValue("name-1")},
+		} {
+			_, err := inst.Query(&lynkapi.DataQuery{
+				TableName: "options",
+				Filter:    fr,
+			})
+			if lynkapi.ParseError(err).Code != lynkapi.StatusCode_BadRequest {
+				t.Fatalf("filter %v not rejected: %v", fr, err)
+			}
+		}
+	}
+
 	{ // insert
 		upsert := &lynkapi.DataInsert{
 			TableName: "options",
@@ -192,3 +232,516 @@ func Test_Instance(t *testing.T) {
 		}
 	}
 }
+
+func Test_Instance_Filter(t *testing.T) {
+
+	type Person struct {
+		Id     string  `json:"id" x_attrs:"primary_key"`
+		Name   string  `json:"name"`
+		Age    int64   `json:"age"`
+		Score  float64 `json:"score"`
+		Status string  `json:"status"`
+	}
+	type Object struct {
+		Persons []*Person `json:"persons"`
+	}
+
+	inst, err := oneobject.NewInstance("test", &Object{
+		Persons: []*Person{
+			{Id: "p1", Name: "carol", Age: 25, Score: 1.5, Status: "active"},
+			{Id: "p2", Name: "alice", Age: 41, Score: 2.5, Status: "active"},
+			{Id: "p3", Name: "bob", Age: 35, Status: "blocked"},
+			{Id: "p4", Name: "dave", Age: 52, Score: 0.5, Status: "pending"},
+			{Id: "p5", Name: "Eve", Age: -1, Status: "active"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inst.TableSetup("persons"); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := func(text string) string {
+		q, err := lynkapi.ParseDataQuery(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rs, err := inst.Query(q)
+		if err != nil {
+			t.Fatalf("%s: %v", text, err)
+		}
+		var ls []string
+		for _, row := range rs.Rows {
+			ls = append(ls, row.Id)
+		}
+		return strings.Join(ls, ",")
+	}
+
+	for text, want := range map[string]string{
+		"select * from persons where age > 30 and status in ('active','blocked') order by name desc": "p3,p2",
+		"select * from persons where age >= 35 and age <= 41":                                        "p2,p3",
+		"select * from persons where age < 0 or score > 2":                                           "p2,p5",
+		"select * from persons where status != 'active'":                                             "p3,p4",
+		"select * from persons where score = 1.5":                                                    "p1",
+		"select * from persons where name like '_a%'":                                                "p1,p4",
+		"select * from persons where name like '%e'":                                                 "p2,p4,p5",
+		"select * from persons where (status = 'active' or status = 'pending') and age > 30":         "p2,p4",
+		"select * from persons order by name":                                                        "p5,p2,p3,p1,p4",
+		"select * from persons order by age desc limit 2 offset 1":                                   "p2,p3",
+		"select * from persons order by score limit 2":                                               "p3,p5",
+	} {
+		if got := ids(text); got != want {
+			t.Fatalf("%s: rows %s, want %s", text, got, want)
+		}
+	}
+
+	// the delete matches the other conditions on the row of the key
+	del := &lynkapi.DataDelete{
+		TableName: "persons",
+		Filter:    (&lynkapi.DataQuery_Filter{}).And("id", "p1"),
+	}
+	del.Filter.Inner = append(del.Filter.Inner, &lynkapi.DataQuery_Filter{
+		Field: "age", Op: lynkapi.DataQuery_Filter_Gt, Value: structpb.NewNumberValue(30),
+	})
+	if _, err := inst.Delete(del); err != nil {
+		t.Fatal(err)
+	}
+	if got := ids("select * from persons where id = 'p1'"); got != "p1" {
+		t.Fatalf("row deleted out of the filter")
+	}
+}
+
+func Test_Instance_Snapshot(t *testing.T) {
+
+	cfg := &ConfigObject{
+		Name: "test",
+		Options: []*ConfigItem{
+			{
+				Name:  "name-1",
+				Value: "value-1",
+			},
+		},
+	}
+
+	inst, err := oneobject.NewInstance("test", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := inst.TableSetup("options"); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		prevTable = cfg.Options
+		prevRow   = cfg.Options[0]
+	)
+
+	{ // update is copy-on-write
+		upsert := &lynkapi.DataInsert{
+			TableName: "options",
+		}
+		upsert.SetField("name", "name-1")
+		upsert.SetField("value", "value-1-1")
+
+		if _, err := inst.Upsert(upsert); err != nil {
+			t.Fatal(err)
+		}
+
+		if prevRow.Value != "value-1" || len(prevTable) != 1 || prevTable[0] != prevRow {
+			t.Fatal("snapshot row changed by update")
+		}
+		if cfg.Options[0].Value != "value-1-1" {
+			t.Fatal("update not applied")
+		}
+	}
+
+	{ // concurrent readers and writers
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(2)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 50; j++ {
+					upsert := &lynkapi.DataInsert{
+						TableName: "options",
+					}
+					upsert.SetField("name", fmt.Sprintf("name-%d-%d", i, j%5))
+					upsert.SetField("value", fmt.Sprintf("value-%d", j))
+					if _, err := inst.Upsert(upsert); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+			}(i)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 50; j++ {
+					if _, err := inst.Query(&lynkapi.DataQuery{
+						TableName: "options",
+						Limit:     100,
+					}); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		rs, err := inst.Query(&lynkapi.DataQuery{
+			TableName: "options",
+			Limit:     100,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rs.Rows) != 21 {
+			t.Fatalf("invalid hits %d", len(rs.Rows))
+		}
+	}
+}
+
+func Test_Instance_History(t *testing.T) {
+
+	cfg := &ConfigObject{
+		Name: "test",
+	}
+
+	inst, err := oneobject.NewInstance("test", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := inst.TableSetup("options",
+		lynkapi.NewTableOption(lynkapi.TableSpec_Option_History, "on")); err != nil {
+		t.Fatal(err)
+	}
+
+	var tc []int64
+
+	for _, v := range []string{"value-1", "value-2", "value-3"} {
+		upsert := &lynkapi.DataInsert{
+			TableName: "options",
+			Operator:  "tester",
+		}
+		upsert.SetField("name", "name-1")
+		upsert.SetField("value", v)
+		if _, err := inst.Upsert(upsert); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(2e6)
+		tc = append(tc, time.Now().UnixMilli())
+		time.Sleep(2e6)
+	}
+
+	{ // delete
+		del := &lynkapi.DataDelete{
+			TableName: "options",
+			Filter:    &lynkapi.DataQuery_Filter{},
+		}
+		del.Filter.And("name", "name-1")
+		if _, err := inst.Delete(del); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	{ // versions
+		q := &lynkapi.DataQuery{
+			TableName: "options",
+			History:   true,
+			Filter:    &lynkapi.DataQuery_Filter{},
+		}
+		q.Filter.And("name", "name-1")
+		rs, err := inst.Query(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rs.Changes) != 4 {
+			t.Fatalf("invalid versions %d", len(rs.Changes))
+		}
+		if ch := rs.Changes[0]; ch.Version != 4 || ch.Action != lynkapi.DataRowChange_Delete {
+			t.Fatalf("invalid version %v", ch)
+		}
+		if ch := rs.Changes[2]; ch.Operator != "tester" || len(ch.Changes) != 1 ||
+			ch.Changes[0].Path != "value" ||
+			ch.Changes[0].OldValue.GetStringValue() != "value-1" ||
+			ch.Changes[0].NewValue.GetStringValue() != "value-2" {
+			t.Fatalf("invalid version %v", ch)
+		}
+	}
+
+	{ // point-in-time read
+		rs, err := inst.Query(&lynkapi.DataQuery{
+			TableName: "options",
+			AsOf:      tc[1],
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rs.Rows) != 1 || rs.Rows[0].Fields["value"].GetStringValue() != "value-2" {
+			t.Fatalf("invalid as_of rows %v", rs.Rows)
+		}
+		if rs.Stats.RowsHit != 1 {
+			t.Fatalf("invalid as_of rows hit %d", rs.Stats.RowsHit)
+		}
+	}
+
+	{ // restore
+		rt := &lynkapi.DataRestore{
+			TableName: "options",
+			Filter:    &lynkapi.DataQuery_Filter{},
+			Version:   1,
+		}
+		rt.Filter.And("name", "name-1")
+		if _, err := inst.Restore(rt); err != nil {
+			t.Fatal(err)
+		}
+		if len(cfg.Options) != 1 || cfg.Options[0].Value != "value-1" {
+			t.Fatal("restore not applied")
+		}
+	}
+}
+
+func Test_Instance_Trash(t *testing.T) {
+
+	type TrashItem struct {
+		Name    string `json:"name" x_attrs:"primary_key"`
+		Value   string `json:"value"`
+		Deleted int64  `json:"deleted" x_attrs:"deleted"`
+	}
+
+	type TrashObject struct {
+		Items []*TrashItem `json:"items"`
+	}
+
+	obj := &TrashObject{
+		Items: []*TrashItem{
+			{Name: "name-1", Value: "value-1"},
+			{Name: "name-2", Value: "value-2"},
+			{Name: "name-3", Value: "value-3", Deleted: time.Now().Add(-2 * time.Hour).UnixMilli()},
+		},
+	}
+
+	inst, err := oneobject.NewInstance("test", obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := inst.TableSetup("items",
+		lynkapi.NewTableOption(lynkapi.TableSpec_Option_SoftDelete, "1h")); err != nil {
+		t.Fatal(err)
+	}
+
+	query := func(trash bool) []*lynkapi.DataRow {
+		rs, err := inst.Query(&lynkapi.DataQuery{
+			TableName: "items",
+			Trash:     trash,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rs.Rows
+	}
+
+	{ // soft delete
+		del := &lynkapi.DataDelete{
+			TableName: "items",
+			Filter:    &lynkapi.DataQuery_Filter{},
+		}
+		del.Filter.And("name", "name-1")
+		if _, err := inst.Delete(del); err != nil {
+			t.Fatal(err)
+		}
+		if rows := query(false); len(rows) != 1 || rows[0].Id != "name-2" {
+			t.Fatalf("invalid rows %v", rows)
+		}
+		// name-3 expired out of the trash
+		if rows := query(true); len(rows) != 1 || rows[0].Id != "name-1" {
+			t.Fatalf("invalid trash %v", rows)
+		}
+	}
+
+	{ // restore
+		rt := &lynkapi.DataRestore{
+			TableName: "items",
+			Filter:    &lynkapi.DataQuery_Filter{},
+		}
+		rt.Filter.And("name", "name-1")
+		if _, err := inst.Restore(rt); err != nil {
+			t.Fatal(err)
+		}
+		if rows := query(false); len(rows) != 2 {
+			t.Fatalf("invalid rows %v", rows)
+		}
+		if _, err := inst.Restore(rt); lynkapi.ParseError(err).Code != lynkapi.StatusCode_NotFound {
+			t.Fatal("restore of live row")
+		}
+	}
+
+	{ // purge
+		del := &lynkapi.DataDelete{
+			TableName: "items",
+			Filter:    &lynkapi.DataQuery_Filter{},
+			Purge:     true,
+		}
+		del.Filter.And("name", "name-2")
+		if _, err := inst.Delete(del); err != nil {
+			t.Fatal(err)
+		}
+		if len(obj.Items) != 1 || len(query(true)) != 0 {
+			t.Fatal("purge not applied")
+		}
+	}
+
+	// insert and upsert of a trashed key create a new row, the trashed row
+	// is purged
+	for _, typ := range []string{"insert", "upsert"} {
+		del := &lynkapi.DataDelete{
+			TableName: "items",
+			Filter:    &lynkapi.DataQuery_Filter{},
+		}
+		del.Filter.And("name", "name-1")
+		if _, err := inst.Delete(del); err != nil {
+			t.Fatal(err)
+		}
+
+		req := &lynkapi.DataInsert{
+			TableName: "items",
+		}
+		req.SetField("name", "name-1")
+		if typ == "insert" {
+			_, err = inst.Insert(req)
+		} else {
+			_, err = inst.Upsert(req)
+		}
+		if err != nil {
+			t.Fatalf("%s of trashed key: %v", typ, err)
+		}
+		if rows := query(false); len(rows) != 1 || rows[0].Id != "name-1" ||
+			rows[0].Fields["value"].GetStringValue() != "" {
+			t.Fatalf("%s of trashed key: invalid rows %v", typ, rows)
+		}
+		if rows := query(true); len(rows) != 0 || len(obj.Items) != 1 {
+			t.Fatalf("%s of trashed key: trash not purged %v", typ, rows)
+		}
+
+		obj.Items[0].Value = "value-1"
+	}
+
+	{ // the delete time is not writable
+		req := &lynkapi.DataInsert{
+			TableName: "items",
+		}
+		req.SetField("name", "name-1")
+		req.Fields = append(req.Fields, "deleted")
+		req.Values = append(req.Values, structpb.NewNumberValue(float64(time.Now().UnixMilli())))
+		if _, err := inst.Upsert(req); lynkapi.ParseError(err).Code != lynkapi.StatusCode_BadRequest {
+			t.Fatalf("soft delete by upsert: %v", err)
+		}
+		req = &lynkapi.DataInsert{
+			TableName: "items",
+		}
+		req.SetField("name", "name-9")
+		req.Fields = append(req.Fields, "deleted")
+		req.Values = append(req.Values, structpb.NewNumberValue(float64(time.Now().UnixMilli())))
+		if _, err := inst.Insert(req); lynkapi.ParseError(err).Code != lynkapi.StatusCode_BadRequest {
+			t.Fatalf("insert into the trash: %v", err)
+		}
+		if rows := query(false); len(rows) != 1 || len(query(true)) != 0 {
+			t.Fatalf("invalid rows %v", rows)
+		}
+	}
+}
+
+func Test_Instance_Migration(t *testing.T) {
+
+	type ItemV1 struct {
+		Id    string `json:"id" x_attrs:"primary_key"`
+		Name  string `json:"name"`
+		Count string `json:"count"`
+		Seq   int64  `json:"seq"`
+	}
+	type ObjectV1 struct {
+		Items []*ItemV1 `json:"items"`
+	}
+
+	type ItemV2 struct {
+		Id     string `json:"id" x_attrs:"primary_key"`
+		Title  string `json:"title"`
+		Count  int64  `json:"count"`
+		Status string `json:"status"`
+		Seq    int64  `json:"seq"`
+	}
+	type ObjectV2 struct {
+		Items []*ItemV2 `json:"items"`
+	}
+
+	file := filepath.Join(t.TempDir(), "data.json")
+
+	{ // v1
+		obj := &ObjectV1{}
+		inst, err := oneobject.NewInstanceFromFile("test", file, obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := inst.TableSetup("items"); err != nil {
+			t.Fatal(err)
+		}
+		for _, id := range []string{"a", "b"} {
+			req := &lynkapi.DataInsert{TableName: "items"}
+			req.SetField("id", id)
+			req.SetField("name", "item "+id)
+			req.SetField("count", "3")
+			if _, err := inst.Upsert(req); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// the int64 values out of the float64 precision
+		obj.Items[1].Count = "9007199254740993"
+		obj.Items[1].Seq = 1<<60 + 1
+		if err := inst.Flush(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	migrations := []any{
+		oneobject.NewMigrationRename(2, "name", "title"),
+		oneobject.NewMigrationConvert(2, "count", nil),
+		oneobject.NewMigrationDefault(3, "status", "active"),
+	}
+
+	{ // v2 without migrations
+		inst, err := oneobject.NewInstanceFromFile("test", file, &ObjectV2{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := inst.TableSetup("items"); err == nil {
+			t.Fatal("incompatible schema accepted")
+		}
+		if err := inst.TableSetup("items", migrations[1:]...); err == nil {
+			t.Fatal("incompatible schema accepted (rename not declared)")
+		}
+	}
+
+	for i := 0; i < 2; i++ { // v2 migrated, then reopened
+		obj := &ObjectV2{}
+		inst, err := oneobject.NewInstanceFromFile("test", file, obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := inst.TableSetup("items", migrations...); err != nil {
+			t.Fatal(err)
+		}
+		if v := inst.SchemaVersion("items"); v != 3 {
+			t.Fatalf("invalid schema version %d", v)
+		}
+		if len(obj.Items) != 2 || obj.Items[0].Title != "item a" ||
+			obj.Items[0].Count != 3 || obj.Items[1].Status != "active" ||
+			obj.Items[1].Count != 9007199254740993 || obj.Items[1].Seq != 1<<60+1 {
+			js, _ := json.Marshal(obj.Items)
+			t.Fatalf("invalid migrated rows %s", string(js))
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package oneobject
+
+import (
+	"errors"
+	"reflect"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/lynkdb/lynkapi/go/lynkapi"
+)
+
+// Writes never modify a table slice or its rows in place: every change
+// builds a new slice (and a deep copy of the changed row) which is then
+// swapped into the object under the write lock. A reader only holds the
+// read lock while taking the slice header, and then iterates it with no
+// lock held, seeing the table exactly as it was at that moment.
+
+func (it *Instance) snapshot(tableName string) (*table, reflect.Value, error) {
+
+	it.mu.RLock()
+	defer it.mu.RUnlock()
+
+	tbl, ok := it.tables[tableName]
+	if !ok {
+		return nil, reflect.Value{}, errors.New("table not found")
+	}
+
+	hit, err := findValue(tbl.path, reflect.ValueOf(it.object))
+	if err != nil {
+		return nil, hit, err
+	}
+
+	return tbl, reflect.ValueOf(hit.Interface()), nil
+}
+
+type instanceSnapshot struct {
+	inst   *Instance
+	tables map[string]reflect.Value
+}
+
+// Snapshot returns a reader of all tables as they are at this moment, used
+// for a consistent backup of the instance.
+func (it *Instance) Snapshot() (lynkapi.DataSnapshot, error) {
+
+	it.mu.RLock()
+	defer it.mu.RUnlock()
+
+	snap := &instanceSnapshot{
+		inst:   it,
+		tables: map[string]reflect.Value{},
+	}
+
+	for name, tbl := range it.tables {
+		hit, err := findValue(tbl.path, reflect.ValueOf(it.object))
+		if err != nil {
+			return nil, err
+		}
+		snap.tables[name] = reflect.ValueOf(hit.Interface())
+	}
+
+	return snap, nil
+}
+
+func (it *instanceSnapshot) Query(q *lynkapi.DataQuery) (*lynkapi.DataResult, error) {
+
+	it.inst.mu.RLock()
+	tbl, ok := it.inst.tables[q.TableName]
+	it.inst.mu.RUnlock()
+
+	hit, ok2 := it.tables[q.TableName]
+	if !ok || !ok2 {
+		return nil, errors.New("table not found")
+	}
+
+	return it.inst.query(q, tbl, hit)
+}
+
+func sliceCopy(ls reflect.Value, grow int) reflect.Value {
+	dst := reflect.MakeSlice(ls.Type(), ls.Len(), ls.Len()+grow)
+	reflect.Copy(dst, ls)
+	return dst
+}
+
+// rowPointer returns a pointer to the row struct, so it can be used as a
+// DataMerge destination whether the table holds []T or []*T.
+func rowPointer(row reflect.Value) reflect.Value {
+	if row.Kind() == reflect.Pointer {
+		return row
+	}
+	ptr := reflect.New(row.Type())
+	ptr.Elem().Set(row)
+	return ptr
+}
+
+func rowElem(elemType reflect.Type, ptr reflect.Value) reflect.Value {
+	if elemType.Kind() == reflect.Pointer {
+		return ptr
+	}
+	return ptr.Elem()
+}
+
+func cloneValue(src reflect.Value) reflect.Value {
+
+	if !src.IsValid() {
+		return src
+	}
+
+	switch src.Kind() {
+
+	case reflect.Pointer:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		if msg, ok := src.Interface().(proto.Message); ok {
+			return reflect.ValueOf(proto.Clone(msg))
+		}
+		dst := reflect.New(src.Type().Elem())
+		dst.Elem().Set(cloneValue(src.Elem()))
+		return dst
+
+	case reflect.Struct:
+		dst := reflect.New(src.Type()).Elem()
+		dst.Set(src)
+		for i := 0; i < dst.NumField(); i++ {
+			if fv := dst.Field(i); fv.CanSet() {
+				fv.Set(cloneValue(src.Field(i)))
+			}
+		}
+		return dst
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		dst := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(cloneValue(src.Index(i)))
+		}
+		return dst
+
+	case reflect.Map:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		dst := reflect.MakeMapWithSize(src.Type(), src.Len())
+		for iter := src.MapRange(); iter.Next(); {
+			dst.SetMapIndex(iter.Key(), cloneValue(iter.Value()))
+		}
+		return dst
+	}
+
+	return src
+}
@@ -0,0 +1,136 @@
+package oneobject
+
+import (
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/lynkdb/lynkapi/go/lynkapi"
+)
+
+const trashDefaultRetention = 30 * 24 * time.Hour
+
+// A table with the option soft_delete keeps deleted rows in place, the field
+// with attr `deleted` holds the delete time (unix milliseconds) and is zero
+// for live rows. Rows stay in the trash until restored, purged or expired.
+//
+// A trashed row is invisible to writes: Insert and Upsert of a row with the
+// key of a trashed row create a new row and purge the trashed one. Expired
+// rows are purged by the next write of the table, a table that is only read
+// keeps them in the trash (and restorable) past the retention. The delete
+// time is set by Delete and cleared by Restore only, a write with a nonzero
+// delete time is refused.
+
+func trashField(field *lynkapi.FieldSpec) (*lynkapi.FieldSpec, error) {
+	for _, fd := range field.Fields {
+		if !fd.HasAttr("deleted") {
+			continue
+		}
+		switch fd.Type {
+		case lynkapi.FieldSpec_Int, lynkapi.FieldSpec_Uint:
+			return fd, nil
+		}
+		return nil, errors.New("soft-delete field must be int or uint")
+	}
+	return nil, errors.New("soft-delete field (attr deleted) not setup")
+}
+
+func trashRetention(opt string) time.Duration {
+	if d, err := time.ParseDuration(opt); err == nil && d > 0 {
+		return d
+	}
+	return trashDefaultRetention
+}
+
+func rowDeleted(tbl *table, v reflect.Value) int64 {
+	if tbl.deleted == nil {
+		return 0
+	}
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		return 0
+	}
+	fv := v.FieldByName(tbl.deleted.Name)
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return fv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(fv.Uint())
+	}
+	return 0
+}
+
+func rowSetDeleted(tbl *table, ptr reflect.Value, tn int64) {
+	fv := ptr.Elem().FieldByName(tbl.deleted.Name)
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fv.SetInt(tn)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		fv.SetUint(uint64(tn))
+	}
+}
+
+// trashExpire purges the rows deleted before the retention period, it must
+// be called with the write lock held.
+func (it *Instance) trashExpire(tbl *table, vtbl reflect.Value) bool {
+
+	if tbl.deleted == nil {
+		return false
+	}
+
+	var (
+		retention = trashRetention(tbl.spec.Option(lynkapi.TableSpec_Option_SoftDelete))
+		expired   = time.Now().Add(-retention).UnixMilli()
+		ls        = reflect.MakeSlice(vtbl.Type(), 0, vtbl.Len())
+	)
+
+	for i := 0; i < vtbl.Len(); i++ {
+		if tn := rowDeleted(tbl, vtbl.Index(i)); tn > 0 && tn < expired {
+			continue
+		}
+		ls = reflect.Append(ls, vtbl.Index(i))
+	}
+
+	if ls.Len() == vtbl.Len() {
+		return false
+	}
+
+	vtbl.Set(ls)
+	return true
+}
+
+func (it *Instance) trashMove(tbl *table, vtbl reflect.Value, pos int, operator string) {
+
+	var (
+		row = vtbl.Index(pos)
+		dst = rowPointer(cloneValue(row))
+	)
+	rowSetDeleted(tbl, dst, time.Now().UnixMilli())
+
+	ls := sliceCopy(vtbl, 0)
+	ls.Index(pos).Set(rowElem(vtbl.Type().Elem(), dst))
+	vtbl.Set(ls)
+
+	it.historyAppend(tbl, operator, lynkapi.DataRowChange_Delete, rowFields(row), nil)
+}
+
+// trashPurge removes the trashed rows at the positions of idx (ascending), it
+// must be called with the write lock held.
+func trashPurge(vtbl reflect.Value, idx []int) {
+
+	if len(idx) == 0 {
+		return
+	}
+
+	ls := reflect.MakeSlice(vtbl.Type(), 0, vtbl.Len())
+	for i := 0; i < vtbl.Len(); i++ {
+		if len(idx) > 0 && idx[0] == i {
+			idx = idx[1:]
+			continue
+		}
+		ls = reflect.Append(ls, vtbl.Index(i))
+	}
+	vtbl.Set(ls)
+}